@@ -2,8 +2,13 @@ package vmcommon
 
 import (
 	"bytes"
+	"encoding/binary"
+	"fmt"
 
 	"github.com/subrahamanyam341/andes-core-16/core"
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	"github.com/subrahamanyam341/andes-core-16/core/pubkeyConverter"
+	"github.com/subrahamanyam341/andes-core-16/hashing"
 )
 
 // SystemAccountAddress is the hard-coded address in which we save global settings on all shards
@@ -110,3 +115,140 @@ func ParseVMTypeFromContractAddress(contractAddress []byte) ([]byte, error) {
 	endIndex := NumInitCharactersForScAddress
 	return contractAddress[startIndex:endIndex], nil
 }
+
+// ComputeSmartContractAddress returns the address of the smart contract deployed by the creator with the provided
+// nonce. The address is the hash of the creator address and the little endian nonce, cut to the creator address
+// length, in which the first bytes are replaced by the zero prefix followed by the VM type and the last bytes are
+// copied from the creator address, so that the contract lands in the same shard as its creator.
+func ComputeSmartContractAddress(hasher hashing.Hasher, creatorAddress []byte, creatorNonce uint64, vmType []byte) ([]byte, error) {
+	if check.IfNil(hasher) {
+		return nil, ErrNilHasher
+	}
+	if len(creatorAddress) <= NumInitCharactersForScAddress {
+		return nil, ErrInvalidAddressLength
+	}
+	if len(vmType) != VMTypeLen {
+		return nil, ErrInvalidVMTypeLength
+	}
+
+	buffNonce := make([]byte, 8)
+	binary.LittleEndian.PutUint64(buffNonce, creatorNonce)
+	addressAndNonce := make([]byte, 0, len(creatorAddress)+len(buffNonce))
+	addressAndNonce = append(addressAndNonce, creatorAddress...)
+	addressAndNonce = append(addressAndNonce, buffNonce...)
+
+	base := hasher.Compute(string(addressAndNonce))
+	if len(base) < len(creatorAddress) {
+		return nil, ErrInvalidAddressLength
+	}
+	base = base[:len(creatorAddress)]
+
+	prefixLen := NumInitCharactersForScAddress - VMTypeLen
+	copy(base[:prefixLen], make([]byte, prefixLen))
+	copy(base[prefixLen:NumInitCharactersForScAddress], vmType)
+	copy(base[len(base)-ShardIdentiferLen:], creatorAddress[len(creatorAddress)-ShardIdentiferLen:])
+
+	return base, nil
+}
+
+// VerifySmartContractAddress checks that the contract address is the one computed by ComputeSmartContractAddress
+// for the creator and nonce, using the VM type found in the contract address
+func VerifySmartContractAddress(hasher hashing.Hasher, contractAddress []byte, creatorAddress []byte, creatorNonce uint64) error {
+	if !IsSmartContractAddress(contractAddress) || IsEmptyAddress(contractAddress) {
+		return fmt.Errorf("%w: not a smart contract address", ErrSmartContractAddressMismatch)
+	}
+	if !IsSameShardSuffix(contractAddress, creatorAddress) {
+		return fmt.Errorf("%w: not in the creator shard", ErrSmartContractAddressMismatch)
+	}
+
+	vmType, err := ParseVMTypeFromContractAddress(contractAddress)
+	if err != nil {
+		return err
+	}
+	expected, err := ComputeSmartContractAddress(hasher, creatorAddress, creatorNonce, vmType)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(expected, contractAddress) {
+		return fmt.Errorf("%w: not deployed by the creator with nonce %d", ErrSmartContractAddressMismatch, creatorNonce)
+	}
+
+	return nil
+}
+
+// IsSameShardSuffix returns true if the addresses have the same length and end with the same shard identifier bytes
+func IsSameShardSuffix(address []byte, otherAddress []byte) bool {
+	if len(address) != len(otherAddress) || len(address) < ShardIdentiferLen {
+		return false
+	}
+
+	return bytes.Equal(address[len(address)-ShardIdentiferLen:], otherAddress[len(otherAddress)-ShardIdentiferLen:])
+}
+
+// AddressKind is the kind of account an address belongs to
+type AddressKind int
+
+const (
+	// AddressKindUser is the address of a user account
+	AddressKindUser AddressKind = iota
+	// AddressKindSmartContract is the address of a smart contract deployed on a shard
+	AddressKindSmartContract
+	// AddressKindMetachainSystemSC is the address of a system smart contract on the metachain
+	AddressKindMetachainSystemSC
+	// AddressKindDCTSystemSC is the address of the DCT issuing system smart contract
+	AddressKindDCTSystemSC
+	// AddressKindSystemAccount is the address of the system account holding the global settings
+	AddressKindSystemAccount
+	// AddressKindEmpty is the empty or all zeros address
+	AddressKindEmpty
+)
+
+// String returns the human-readable name of the address kind
+func (kind AddressKind) String() string {
+	switch kind {
+	case AddressKindUser:
+		return "user"
+	case AddressKindSmartContract:
+		return "smart contract"
+	case AddressKindMetachainSystemSC:
+		return "metachain system smart contract"
+	case AddressKindDCTSystemSC:
+		return "DCT system smart contract"
+	case AddressKindSystemAccount:
+		return "system account"
+	case AddressKindEmpty:
+		return "empty"
+	default:
+		return fmt.Sprintf("unknown address kind: %d", int(kind))
+	}
+}
+
+// ClassifyAddress returns the kind of account the address belongs to. For the smart contract kinds the VM type
+// found in the address is also returned, for the other kinds it is nil.
+func ClassifyAddress(address []byte) (AddressKind, []byte) {
+	if IsEmptyAddress(address) {
+		return AddressKindEmpty, nil
+	}
+	if IsSystemAccountAddress(address) {
+		return AddressKindSystemAccount, nil
+	}
+	if !IsSmartContractAddress(address) {
+		return AddressKindUser, nil
+	}
+
+	vmType, _ := ParseVMTypeFromContractAddress(address)
+	if bytes.Equal(address, core.DCTSCAddress) {
+		return AddressKindDCTSystemSC, vmType
+	}
+	if len(address) >= ShardIdentiferLen && IsSmartContractOnMetachain(address[len(address)-ShardIdentiferLen:], address) {
+		return AddressKindMetachainSystemSC, vmType
+	}
+
+	return AddressKindSmartContract, vmType
+}
+
+// NewBech32AddressConverter creates a converter between the addresses of the given length and their bech32 form
+// with the given human-readable part
+func NewBech32AddressConverter(hrp string, addressLen int) (core.PubkeyConverter, error) {
+	return pubkeyConverter.NewBech32PubkeyConverter(addressLen, hrp)
+}
@@ -1,10 +1,17 @@
 package vmcommon
 
 import (
+	"bytes"
 	"encoding/hex"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core"
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	"github.com/subrahamanyam341/andes-core-16/core/pubkeyConverter"
+	"github.com/subrahamanyam341/andes-core-16/hashing/sha256"
 )
 
 func TestAddress_isSmartContractAddress(t *testing.T) {
@@ -93,3 +100,114 @@ func Test_ParseVMTypeFromContractAddress_Invalid(t *testing.T) {
 	assert.Nil(t, vmType)
 	assert.Equal(t, ErrInvalidVMType, err)
 }
+
+func TestClassifyAddress(t *testing.T) {
+	t.Parallel()
+
+	scAddress, _ := hex.DecodeString("000000000000000005006e4f90488e27342f9a46e1809452c85ee7186566bd5e")
+	metachainSCAddress := append(make([]byte, 30), 255, 255)
+	metachainSCAddress[9] = 1
+	metachainSCAddress[29] = 5
+
+	testCases := []struct {
+		address      []byte
+		expectedKind AddressKind
+		expectedVM   []byte
+	}{
+		{address: nil, expectedKind: AddressKindEmpty},
+		{address: make([]byte, 32), expectedKind: AddressKindEmpty},
+		{address: SystemAccountAddress, expectedKind: AddressKindSystemAccount},
+		{address: core.DCTSCAddress, expectedKind: AddressKindDCTSystemSC, expectedVM: []byte{0, 1}},
+		{address: metachainSCAddress, expectedKind: AddressKindMetachainSystemSC, expectedVM: []byte{0, 1}},
+		{address: scAddress, expectedKind: AddressKindSmartContract, expectedVM: []byte{5, 0}},
+		{address: bytes.Repeat([]byte{1}, 32), expectedKind: AddressKindUser},
+	}
+	for _, tc := range testCases {
+		kind, vmType := ClassifyAddress(tc.address)
+		assert.Equal(t, tc.expectedKind, kind, kind.String())
+		assert.Equal(t, tc.expectedVM, vmType, kind.String())
+	}
+}
+
+func TestNewBech32AddressConverter(t *testing.T) {
+	t.Parallel()
+
+	converter, err := NewBech32AddressConverter("", 32)
+	assert.True(t, check.IfNil(converter))
+	assert.True(t, errors.Is(err, pubkeyConverter.ErrInvalidHrpPrefix))
+
+	converter, err = NewBech32AddressConverter("erd", 0)
+	assert.True(t, check.IfNil(converter))
+	assert.True(t, errors.Is(err, pubkeyConverter.ErrInvalidAddressLength))
+
+	converter, err = NewBech32AddressConverter("erd", 32)
+	require.False(t, check.IfNil(converter))
+	assert.Nil(t, err)
+	assert.Equal(t, 32, converter.Len())
+
+	encoded, err := converter.Encode(core.DCTSCAddress)
+	require.Nil(t, err)
+	decoded, err := converter.Decode(encoded)
+	assert.Nil(t, err)
+	assert.Equal(t, core.DCTSCAddress, decoded)
+
+	_, err = converter.Decode(encoded[:len(encoded)-1] + "q")
+	assert.NotNil(t, err)
+	otherHRP, _ := NewBech32AddressConverter("moa", 32)
+	_, err = otherHRP.Decode(encoded)
+	assert.NotNil(t, err)
+}
+
+func TestComputeSmartContractAddress(t *testing.T) {
+	t.Parallel()
+
+	hasher := sha256.NewSha256()
+	creator := bytes.Repeat([]byte{7}, 32)
+	vmType := []byte{5, 0}
+
+	_, err := ComputeSmartContractAddress(nil, creator, 0, vmType)
+	assert.Equal(t, ErrNilHasher, err)
+	_, err = ComputeSmartContractAddress(hasher, creator[:NumInitCharactersForScAddress], 0, vmType)
+	assert.Equal(t, ErrInvalidAddressLength, err)
+	_, err = ComputeSmartContractAddress(hasher, bytes.Repeat([]byte{7}, 33), 0, vmType)
+	assert.Equal(t, ErrInvalidAddressLength, err)
+	_, err = ComputeSmartContractAddress(hasher, creator, 0, []byte{5})
+	assert.Equal(t, ErrInvalidVMTypeLength, err)
+
+	address, err := ComputeSmartContractAddress(hasher, creator, 1, vmType)
+	require.Nil(t, err)
+	kind, parsedVMType := ClassifyAddress(address)
+	assert.Equal(t, AddressKindSmartContract, kind)
+	assert.Equal(t, vmType, parsedVMType)
+	assert.True(t, IsSameShardSuffix(creator, address))
+
+	// the derivation must not change, as the deployed contracts depend on it
+	assert.Equal(t, "00000000000000000500bca6c8dd10bb423e77a3c4f050160ad0ca349a4c0707", hex.EncodeToString(address))
+
+	otherAddress, _ := ComputeSmartContractAddress(hasher, creator, 2, vmType)
+	assert.NotEqual(t, address, otherAddress)
+}
+
+func TestVerifySmartContractAddress(t *testing.T) {
+	t.Parallel()
+
+	hasher := sha256.NewSha256()
+	creator := bytes.Repeat([]byte{7}, 32)
+	address, _ := ComputeSmartContractAddress(hasher, creator, 1, []byte{5, 0})
+
+	assert.Nil(t, VerifySmartContractAddress(hasher, address, creator, 1))
+
+	err := VerifySmartContractAddress(hasher, address, creator, 2)
+	assert.True(t, errors.Is(err, ErrSmartContractAddressMismatch))
+	err = VerifySmartContractAddress(hasher, creator, creator, 1)
+	assert.True(t, errors.Is(err, ErrSmartContractAddressMismatch))
+	err = VerifySmartContractAddress(hasher, make([]byte, 32), creator, 1)
+	assert.True(t, errors.Is(err, ErrSmartContractAddressMismatch))
+
+	otherShardCreator := append(bytes.Repeat([]byte{7}, 31), 8)
+	err = VerifySmartContractAddress(hasher, address, otherShardCreator, 1)
+	assert.True(t, errors.Is(err, ErrSmartContractAddressMismatch))
+
+	err = VerifySmartContractAddress(nil, address, creator, 1)
+	assert.Equal(t, ErrNilHasher, err)
+}
@@ -0,0 +1,237 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"fmt"
+
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+)
+
+const maxLenForNonce = 8
+
+// ArgumentError is returned by the arguments schema for an invalid or missing argument
+type ArgumentError struct {
+	Index int
+	Name  string
+	Err   error
+}
+
+// Error returns the error message, prefixed with the index and the name of the argument
+func (e *ArgumentError) Error() string {
+	if len(e.Name) == 0 {
+		return fmt.Sprintf("argument %d: %s", e.Index, e.Err.Error())
+	}
+
+	return fmt.Sprintf("argument %d (%s): %s", e.Index, e.Name, e.Err.Error())
+}
+
+// Unwrap returns the cause of the error
+func (e *ArgumentError) Unwrap() error {
+	return e.Err
+}
+
+// TokenIdentifierArgument returns a non-empty token identifier argument
+func TokenIdentifierArgument(name string) ArgumentInfo {
+	return ArgumentInfo{Name: name, Type: ArgumentTypeTokenIdentifier}
+}
+
+// NonceArgument returns a nonce argument, of at most 8 bytes
+func NonceArgument(name string) ArgumentInfo {
+	return ArgumentInfo{Name: name, Type: ArgumentTypeNonce}
+}
+
+// BigUintArgument returns a big unsigned integer argument. Its length is not checked if maxLength is 0.
+func BigUintArgument(name string, maxLength int) ArgumentInfo {
+	return ArgumentInfo{Name: name, Type: ArgumentTypeBigUint, MaxLength: maxLength}
+}
+
+// AddressArgument returns an address argument, with the length of the caller address
+func AddressArgument(name string) ArgumentInfo {
+	return ArgumentInfo{Name: name, Type: ArgumentTypeAddress}
+}
+
+// BoolArgument returns a bool argument: empty or 0 for false and 1 for true
+func BoolArgument(name string) ArgumentInfo {
+	return ArgumentInfo{Name: name, Type: ArgumentTypeBool}
+}
+
+// BytesArgument returns an argument that is not checked
+func BytesArgument(name string) ArgumentInfo {
+	return ArgumentInfo{Name: name, Type: ArgumentTypeBytes}
+}
+
+// StringArgument returns a human-readable string argument, which is not checked
+func StringArgument(name string) ArgumentInfo {
+	return ArgumentInfo{Name: name, Type: ArgumentTypeString}
+}
+
+// ArgumentsSchema is the declarative layout of the arguments of a built-in function: the fixed arguments, followed
+// by a group of variadic arguments, which is repeated as a whole, and by the trailing arguments
+type ArgumentsSchema struct {
+	fixed             []ArgumentInfo
+	variadic          []ArgumentInfo
+	minNumOfVariadics int
+	trailing          []ArgumentInfo
+	err               error
+}
+
+// NewArgumentsSchema creates a schema that accepts exactly the provided arguments
+func NewArgumentsSchema(fixed ...ArgumentInfo) *ArgumentsSchema {
+	return &ArgumentsSchema{
+		fixed: fixed,
+		err:   ErrInvalidArguments,
+	}
+}
+
+// WithVariadic returns a copy of the schema that also accepts the group of arguments, repeated at least minNumOfGroups
+// times, after the fixed arguments
+func (schema *ArgumentsSchema) WithVariadic(minNumOfGroups int, group ...ArgumentInfo) *ArgumentsSchema {
+	copied := *schema
+	copied.variadic = group
+	copied.minNumOfVariadics = minNumOfGroups
+
+	return &copied
+}
+
+// WithTrailing returns a copy of the schema that also requires the arguments after the variadic ones
+func (schema *ArgumentsSchema) WithTrailing(trailing ...ArgumentInfo) *ArgumentsSchema {
+	copied := *schema
+	copied.trailing = trailing
+
+	return &copied
+}
+
+// WithError returns a copy of the schema whose Validate returns the provided error, instead of ErrInvalidArguments,
+// for the invalid or missing arguments
+func (schema *ArgumentsSchema) WithError(err error) *ArgumentsSchema {
+	copied := *schema
+	copied.err = err
+
+	return &copied
+}
+
+// Arguments returns the description of the arguments accepted by the schema
+func (schema *ArgumentsSchema) Arguments() []ArgumentInfo {
+	arguments := make([]ArgumentInfo, 0, len(schema.fixed)+len(schema.variadic)+len(schema.trailing))
+	arguments = append(arguments, schema.fixed...)
+	for _, argument := range schema.variadic {
+		argument.Repeated = true
+		argument.Optional = schema.minNumOfVariadics == 0 && len(schema.trailing) == 0
+		arguments = append(arguments, argument)
+	}
+
+	return append(arguments, schema.trailing...)
+}
+
+// Validate checks the arguments of the call against the schema. The addresses must have the length of the caller
+// address. It returns the plain errors checked by the built-in functions: ErrNilVmInput for a nil input,
+// ErrInvalidAddressLength for an invalid address and the schema error, ErrInvalidArguments by default, for any other
+// invalid or missing argument. ValidateArguments returns the error naming the argument.
+func (schema *ArgumentsSchema) Validate(vmInput *vmcommon.ContractCallInput) error {
+	if vmInput == nil {
+		return ErrNilVmInput
+	}
+
+	err := schema.ValidateArguments(vmInput.Arguments, len(vmInput.CallerAddr))
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, ErrInvalidAddressLength):
+		return ErrInvalidAddressLength
+	default:
+		return schema.err
+	}
+}
+
+// ValidateArguments checks the arguments against the schema, the addresses must have the provided length. The
+// returned error is an *ArgumentError, which names the first invalid or missing argument.
+func (schema *ArgumentsSchema) ValidateArguments(arguments [][]byte, addressLength int) error {
+	layout, err := schema.layout(len(arguments))
+	if err != nil {
+		return err
+	}
+
+	for index, argument := range arguments {
+		err = validateArgument(layout[index], argument, addressLength)
+		if err != nil {
+			return &ArgumentError{Index: index, Name: layout[index].Name, Err: err}
+		}
+	}
+
+	return nil
+}
+
+// layout returns the description of each argument, for the provided number of arguments
+func (schema *ArgumentsSchema) layout(numArguments int) ([]ArgumentInfo, error) {
+	numFixed := len(schema.fixed) + len(schema.trailing)
+	minNumArguments := numFixed + schema.minNumOfVariadics*len(schema.variadic)
+	if numArguments < minNumArguments {
+		expected := schema.layoutWithGroups(schema.minNumOfVariadics)
+		return nil, &ArgumentError{
+			Index: numArguments,
+			Name:  expected[numArguments].Name,
+			Err:   fmt.Errorf("%w: missing argument, expected at least %d arguments", ErrInvalidArguments, minNumArguments),
+		}
+	}
+
+	numVariadics := numArguments - numFixed
+	if len(schema.variadic) == 0 {
+		if numVariadics > 0 {
+			return nil, &ArgumentError{
+				Index: numFixed,
+				Err:   fmt.Errorf("%w: unexpected argument, expected %d arguments", ErrInvalidArguments, numFixed),
+			}
+		}
+
+		return schema.layoutWithGroups(0), nil
+	}
+
+	numGroups := numVariadics / len(schema.variadic)
+	incompleteGroupLength := numVariadics % len(schema.variadic)
+	if incompleteGroupLength != 0 {
+		return nil, &ArgumentError{
+			Index: numArguments,
+			Name:  schema.variadic[incompleteGroupLength].Name,
+			Err:   fmt.Errorf("%w: missing argument, expected groups of %d arguments", ErrInvalidArguments, len(schema.variadic)),
+		}
+	}
+
+	return schema.layoutWithGroups(numGroups), nil
+}
+
+func (schema *ArgumentsSchema) layoutWithGroups(numGroups int) []ArgumentInfo {
+	layout := make([]ArgumentInfo, 0, len(schema.fixed)+numGroups*len(schema.variadic)+len(schema.trailing))
+	layout = append(layout, schema.fixed...)
+	for i := 0; i < numGroups; i++ {
+		layout = append(layout, schema.variadic...)
+	}
+
+	return append(layout, schema.trailing...)
+}
+
+func validateArgument(info ArgumentInfo, argument []byte, addressLength int) error {
+	switch info.Type {
+	case ArgumentTypeTokenIdentifier:
+		if len(argument) == 0 {
+			return fmt.Errorf("%w: empty token identifier", ErrInvalidArguments)
+		}
+	case ArgumentTypeNonce:
+		if len(argument) > maxLenForNonce {
+			return fmt.Errorf("%w: max length for nonce is %d", ErrInvalidArguments, maxLenForNonce)
+		}
+	case ArgumentTypeAddress:
+		if len(argument) != addressLength {
+			return fmt.Errorf("%w: expected %d bytes", ErrInvalidAddressLength, addressLength)
+		}
+	case ArgumentTypeBool:
+		if len(argument) > 1 || (len(argument) == 1 && argument[0] > 1) {
+			return fmt.Errorf("%w: invalid bool", ErrInvalidArguments)
+		}
+	}
+
+	if info.MaxLength > 0 && len(argument) > info.MaxLength {
+		return fmt.Errorf("%w: max length is %d", ErrInvalidArguments, info.MaxLength)
+	}
+
+	return nil
+}
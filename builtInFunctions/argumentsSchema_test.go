@@ -0,0 +1,160 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+)
+
+func requireArgumentError(t *testing.T, err error, index int, name string, cause error) {
+	var argumentErr *ArgumentError
+	require.True(t, errors.As(err, &argumentErr), "%v", err)
+	assert.Equal(t, index, argumentErr.Index)
+	assert.Equal(t, name, argumentErr.Name)
+	assert.True(t, errors.Is(err, cause), "%v", err)
+}
+
+func TestArgumentsSchema_ValidateNumberOfArguments(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fixed arguments", func(t *testing.T) {
+		t.Parallel()
+
+		schema := NewArgumentsSchema(BytesArgument("first"), BytesArgument("second"))
+		assert.Nil(t, schema.ValidateArguments([][]byte{{1}, {2}}, 32))
+
+		err := schema.ValidateArguments([][]byte{{1}}, 32)
+		requireArgumentError(t, err, 1, "second", ErrInvalidArguments)
+		assert.Equal(t, "argument 1 (second): invalid arguments to process built-in function: missing argument, expected at least 2 arguments", err.Error())
+
+		err = schema.ValidateArguments([][]byte{{1}, {2}, {3}}, 32)
+		requireArgumentError(t, err, 2, "", ErrInvalidArguments)
+		assert.Equal(t, "argument 2: invalid arguments to process built-in function: unexpected argument, expected 2 arguments", err.Error())
+	})
+	t.Run("variadic arguments", func(t *testing.T) {
+		t.Parallel()
+
+		schema := NewArgumentsSchema(BytesArgument("first")).WithVariadic(1, BytesArgument("key"), BytesArgument("value"))
+		assert.Nil(t, schema.ValidateArguments([][]byte{{1}, {2}, {3}}, 32))
+		assert.Nil(t, schema.ValidateArguments([][]byte{{1}, {2}, {3}, {4}, {5}}, 32))
+
+		err := schema.ValidateArguments([][]byte{{1}}, 32)
+		requireArgumentError(t, err, 1, "key", ErrInvalidArguments)
+
+		err = schema.ValidateArguments([][]byte{{1}, {2}, {3}, {4}}, 32)
+		requireArgumentError(t, err, 4, "value", ErrInvalidArguments)
+
+		optional := NewArgumentsSchema(BytesArgument("first")).WithVariadic(0, BytesArgument("argument"))
+		assert.Nil(t, optional.ValidateArguments([][]byte{{1}}, 32))
+		assert.Nil(t, optional.ValidateArguments([][]byte{{1}, {2}, {3}}, 32))
+	})
+	t.Run("trailing arguments", func(t *testing.T) {
+		t.Parallel()
+
+		schema := NewArgumentsSchema(BytesArgument("first")).
+			WithVariadic(1, StringArgument("uri")).
+			WithTrailing(AddressArgument("address"))
+		address := bytes.Repeat([]byte{1}, 32)
+		assert.Nil(t, schema.ValidateArguments([][]byte{{1}, {2}, address}, 32))
+		assert.Nil(t, schema.ValidateArguments([][]byte{{1}, {2}, {3}, address}, 32))
+
+		err := schema.ValidateArguments([][]byte{{1}, address}, 32)
+		requireArgumentError(t, err, 2, "address", ErrInvalidArguments)
+
+		err = schema.ValidateArguments([][]byte{{1}, {2}, {3}}, 32)
+		requireArgumentError(t, err, 2, "address", ErrInvalidAddressLength)
+	})
+}
+
+func TestArgumentsSchema_ValidateArgumentTypes(t *testing.T) {
+	t.Parallel()
+
+	schema := NewArgumentsSchema(
+		TokenIdentifierArgument("token"),
+		NonceArgument("nonce"),
+		BigUintArgument("value", 4),
+		AddressArgument("address"),
+		BoolArgument("flag"),
+	)
+	validArguments := func() [][]byte {
+		return [][]byte{[]byte("TKN-abcdef"), {0xff}, {1, 2, 3, 4}, []byte("address"), {1}}
+	}
+	require.Nil(t, schema.ValidateArguments(validArguments(), len("address")))
+
+	testCases := []struct {
+		index    int
+		name     string
+		argument []byte
+		cause    error
+	}{
+		{index: 0, name: "token", argument: nil, cause: ErrInvalidArguments},
+		{index: 1, name: "nonce", argument: make([]byte, 9), cause: ErrInvalidArguments},
+		{index: 2, name: "value", argument: make([]byte, 5), cause: ErrInvalidArguments},
+		{index: 3, name: "address", argument: []byte("other address"), cause: ErrInvalidAddressLength},
+		{index: 4, name: "flag", argument: []byte{2}, cause: ErrInvalidArguments},
+		{index: 4, name: "flag", argument: []byte{0, 1}, cause: ErrInvalidArguments},
+	}
+	for _, testCase := range testCases {
+		arguments := validArguments()
+		arguments[testCase.index] = testCase.argument
+		err := schema.ValidateArguments(arguments, len("address"))
+		requireArgumentError(t, err, testCase.index, testCase.name, testCase.cause)
+	}
+
+	arguments := validArguments()
+	arguments[1] = nil
+	arguments[4] = []byte{0}
+	assert.Nil(t, schema.ValidateArguments(arguments, len("address")))
+}
+
+func TestArgumentsSchema_Validate(t *testing.T) {
+	t.Parallel()
+
+	schema := NewArgumentsSchema(AddressArgument("address")).WithVariadic(0, BoolArgument("flag"))
+	assert.Equal(t, ErrNilVmInput, schema.Validate(nil))
+
+	vmInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr: []byte("caller"),
+			Arguments:  [][]byte{[]byte("sender")},
+		},
+	}
+	assert.Nil(t, schema.Validate(vmInput))
+
+	vmInput.Arguments = [][]byte{[]byte("receiver")}
+	assert.Equal(t, ErrInvalidAddressLength, schema.Validate(vmInput))
+
+	vmInput.Arguments = [][]byte{[]byte("sender"), {2}}
+	assert.Equal(t, ErrInvalidArguments, schema.Validate(vmInput))
+
+	vmInput.Arguments = nil
+	assert.Equal(t, ErrInvalidArguments, schema.Validate(vmInput))
+
+	expectedErr := errors.New("expected error")
+	assert.Equal(t, expectedErr, schema.WithError(expectedErr).Validate(vmInput))
+	assert.Equal(t, ErrInvalidArguments, schema.Validate(vmInput))
+}
+
+func TestArgumentsSchema_Arguments(t *testing.T) {
+	t.Parallel()
+
+	schema := NewArgumentsSchema(TokenIdentifierArgument("token")).WithVariadic(0, StringArgument("uri"))
+	expected := []ArgumentInfo{
+		{Name: "token", Type: ArgumentTypeTokenIdentifier},
+		{Name: "uri", Type: ArgumentTypeString, Optional: true, Repeated: true},
+	}
+	assert.Equal(t, expected, schema.Arguments())
+
+	withTrailing := schema.WithTrailing(AddressArgument("address"))
+	expected = []ArgumentInfo{
+		{Name: "token", Type: ArgumentTypeTokenIdentifier},
+		{Name: "uri", Type: ArgumentTypeString, Repeated: true},
+		{Name: "address", Type: ArgumentTypeAddress},
+	}
+	assert.Equal(t, expected, withTrailing.Arguments())
+	assert.Equal(t, 2, len(schema.Arguments()))
+}
@@ -1,10 +1,21 @@
 package builtInFunctions
 
+// activeGateHandler is implemented by the built-in functions whose activation can be further restricted by a gate,
+// like the round activation handler
+type activeGateHandler interface {
+	addActiveGate(gate func() bool)
+}
+
 type baseAlwaysActiveHandler struct {
+	activeGate func() bool
 }
 
-// IsActive returns true as this built-in function is always active
+// IsActive returns true as this built-in function is always active, unless it was gated
 func (b baseAlwaysActiveHandler) IsActive() bool {
+	if b.activeGate != nil {
+		return b.activeGate()
+	}
+
 	return trueHandler()
 }
 
@@ -13,6 +24,10 @@ func (b baseAlwaysActiveHandler) IsInterfaceNil() bool {
 	return false
 }
 
+func (b *baseAlwaysActiveHandler) addActiveGate(gate func() bool) {
+	b.activeGate = composeActiveGate(b.activeGate, gate)
+}
+
 type baseActiveHandler struct {
 	activeHandler func() bool
 }
@@ -26,3 +41,17 @@ func (b *baseActiveHandler) IsActive() bool {
 func (b *baseActiveHandler) IsInterfaceNil() bool {
 	return b == nil
 }
+
+func (b *baseActiveHandler) addActiveGate(gate func() bool) {
+	b.activeHandler = composeActiveGate(b.activeHandler, gate)
+}
+
+func composeActiveGate(previous func() bool, gate func() bool) func() bool {
+	if previous == nil {
+		return gate
+	}
+
+	return func() bool {
+		return gate() && previous()
+	}
+}
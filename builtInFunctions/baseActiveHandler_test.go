@@ -39,3 +39,26 @@ func TestBaseAlwaysActiveHandler_IsActive(t *testing.T) {
 	assert.False(t, check.IfNil(handler))
 	assert.True(t, handler.IsActive())
 }
+
+func TestBaseActiveHandlers_AddActiveGate(t *testing.T) {
+	t.Parallel()
+
+	gateOpen := false
+	gate := func() bool { return gateOpen }
+
+	alwaysActive := &baseAlwaysActiveHandler{}
+	alwaysActive.addActiveGate(gate)
+	epochActive := &baseActiveHandler{activeHandler: trueHandler}
+	epochActive.addActiveGate(gate)
+	inactive := &baseActiveHandler{activeHandler: falseHandler}
+	inactive.addActiveGate(gate)
+
+	assert.False(t, alwaysActive.IsActive())
+	assert.False(t, epochActive.IsActive())
+	assert.False(t, inactive.IsActive())
+
+	gateOpen = true
+	assert.True(t, alwaysActive.IsActive())
+	assert.True(t, epochActive.IsActive())
+	assert.False(t, inactive.IsActive())
+}
@@ -0,0 +1,160 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+)
+
+// ErrorCategory groups the built-in function errors by their cause
+type ErrorCategory int
+
+const (
+	// ErrorCategoryInternal groups the errors caused by a wrong setup of the components, not by the processed call
+	ErrorCategoryInternal ErrorCategory = iota
+	// ErrorCategoryInvalidArguments groups the errors caused by invalid call arguments or call value
+	ErrorCategoryInvalidArguments
+	// ErrorCategoryWrongSignature groups the errors caused by a wrong number of call arguments
+	ErrorCategoryWrongSignature
+	// ErrorCategoryNotPermitted groups the errors caused by a caller that is not allowed to do the operation
+	ErrorCategoryNotPermitted
+	// ErrorCategoryInvalidState groups the errors caused by accounts or tokens that are not in the state required by the call
+	ErrorCategoryInvalidState
+	// ErrorCategoryOutOfFunds groups the errors caused by an insufficient balance or token quantity
+	ErrorCategoryOutOfFunds
+	// ErrorCategoryOutOfGas groups the errors caused by an insufficient gas limit
+	ErrorCategoryOutOfGas
+	// ErrorCategoryFunctionNotFound groups the errors caused by calling a missing or inactive built-in function
+	ErrorCategoryFunctionNotFound
+)
+
+// String returns the human-readable name of the category
+func (category ErrorCategory) String() string {
+	switch category {
+	case ErrorCategoryInternal:
+		return "internal"
+	case ErrorCategoryInvalidArguments:
+		return "invalid arguments"
+	case ErrorCategoryWrongSignature:
+		return "wrong signature"
+	case ErrorCategoryNotPermitted:
+		return "not permitted"
+	case ErrorCategoryInvalidState:
+		return "invalid state"
+	case ErrorCategoryOutOfFunds:
+		return "out of funds"
+	case ErrorCategoryOutOfGas:
+		return "out of gas"
+	case ErrorCategoryFunctionNotFound:
+		return "function not found"
+	default:
+		return fmt.Sprintf("unknown category: %d", category)
+	}
+}
+
+// ReturnCode returns the VM return code that matches the category
+func (category ErrorCategory) ReturnCode() vmcommon.ReturnCode {
+	switch category {
+	case ErrorCategoryInvalidArguments, ErrorCategoryNotPermitted, ErrorCategoryInvalidState:
+		return vmcommon.UserError
+	case ErrorCategoryWrongSignature:
+		return vmcommon.FunctionWrongSignature
+	case ErrorCategoryOutOfFunds:
+		return vmcommon.OutOfFunds
+	case ErrorCategoryOutOfGas:
+		return vmcommon.OutOfGas
+	case ErrorCategoryFunctionNotFound:
+		return vmcommon.FunctionNotFound
+	default:
+		return vmcommon.ExecutionFailed
+	}
+}
+
+// BuiltInError is the type of all the errors defined by the built-in functions. Besides the message, each error
+// carries a code that never changes between releases and a category that tells what caused it.
+type BuiltInError struct {
+	code     uint32
+	category ErrorCategory
+	message  string
+}
+
+func newBuiltInError(code uint32, category ErrorCategory, message string) error {
+	return &BuiltInError{
+		code:     code,
+		category: category,
+		message:  message,
+	}
+}
+
+// Error returns the error message
+func (e *BuiltInError) Error() string {
+	return e.message
+}
+
+// Code returns the unique code of the error
+func (e *BuiltInError) Code() uint32 {
+	return e.code
+}
+
+// Category returns the category of the error
+func (e *BuiltInError) Category() ErrorCategory {
+	return e.category
+}
+
+// GetBuiltInError returns the first built-in error found in the chain of the provided error
+func GetBuiltInError(err error) (*BuiltInError, bool) {
+	var builtInErr *BuiltInError
+	if !errors.As(err, &builtInErr) {
+		return nil, false
+	}
+
+	return builtInErr, true
+}
+
+// ReturnCodeFromError returns the VM return code for an error returned by a built-in function. The errors that
+// are not defined by the built-in functions are considered user errors.
+func ReturnCodeFromError(err error) vmcommon.ReturnCode {
+	if err == nil {
+		return vmcommon.Ok
+	}
+
+	builtInErr, ok := GetBuiltInError(err)
+	if !ok {
+		return vmcommon.UserError
+	}
+
+	return builtInErr.category.ReturnCode()
+}
+
+// ReturnMessageFromError returns a message for an error returned by a built-in function. For the built-in errors
+// the message does not depend on the context added when the error was wrapped, so it is the same for all the calls
+// that failed for the same reason.
+func ReturnMessageFromError(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	builtInErr, ok := GetBuiltInError(err)
+	if !ok {
+		return err.Error()
+	}
+
+	return builtInErr.message
+}
+
+// CreateVMOutputFromError creates the output of a failed built-in function call. All the provided gas is consumed.
+func CreateVMOutputFromError(err error) *vmcommon.VMOutput {
+	return &vmcommon.VMOutput{
+		ReturnCode:      ReturnCodeFromError(err),
+		ReturnMessage:   ReturnMessageFromError(err),
+		ReturnData:      make([][]byte, 0),
+		GasRemaining:    0,
+		GasRefund:       big.NewInt(0),
+		OutputAccounts:  make(map[string]*vmcommon.OutputAccount),
+		DeletedAccounts: make([][]byte, 0),
+		TouchedAccounts: make([][]byte, 0),
+		Logs:            make([]*vmcommon.LogEntry, 0),
+	}
+}
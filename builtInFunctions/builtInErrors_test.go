@@ -0,0 +1,210 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+)
+
+func TestErrorCategory_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "internal", ErrorCategoryInternal.String())
+	assert.Equal(t, "function not found", ErrorCategoryFunctionNotFound.String())
+	assert.Equal(t, "unknown category: 100", ErrorCategory(100).String())
+}
+
+func TestErrorCategory_ReturnCode(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, vmcommon.ExecutionFailed, ErrorCategoryInternal.ReturnCode())
+	assert.Equal(t, vmcommon.UserError, ErrorCategoryInvalidArguments.ReturnCode())
+	assert.Equal(t, vmcommon.FunctionWrongSignature, ErrorCategoryWrongSignature.ReturnCode())
+	assert.Equal(t, vmcommon.UserError, ErrorCategoryNotPermitted.ReturnCode())
+	assert.Equal(t, vmcommon.UserError, ErrorCategoryInvalidState.ReturnCode())
+	assert.Equal(t, vmcommon.OutOfFunds, ErrorCategoryOutOfFunds.ReturnCode())
+	assert.Equal(t, vmcommon.OutOfGas, ErrorCategoryOutOfGas.ReturnCode())
+	assert.Equal(t, vmcommon.FunctionNotFound, ErrorCategoryFunctionNotFound.ReturnCode())
+	assert.Equal(t, vmcommon.ExecutionFailed, ErrorCategory(100).ReturnCode())
+}
+
+func TestBuiltInErrors_AllErrorsHaveUniqueCodes(t *testing.T) {
+	t.Parallel()
+
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, "errors.go", nil, 0)
+	require.Nil(t, err)
+
+	codes := make(map[uint32]string)
+	numErrors := 0
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			for _, name := range spec.(*ast.ValueSpec).Names {
+				numErrors++
+				builtInErr, isBuiltInErr := GetBuiltInError(errorsByName[name.Name])
+				require.True(t, isBuiltInErr, "%s is not a built-in error", name.Name)
+
+				previous, found := codes[builtInErr.Code()]
+				assert.False(t, found, "%s has the same code as %s", name.Name, previous)
+				codes[builtInErr.Code()] = name.Name
+			}
+		}
+	}
+
+	assert.Equal(t, len(errorsByName), numErrors)
+}
+
+var errorsByName = map[string]error{
+	"ErrNilAccountsAdapter":                    ErrNilAccountsAdapter,
+	"ErrInsufficientFunds":                     ErrInsufficientFunds,
+	"ErrNilValue":                              ErrNilValue,
+	"ErrNilMarshalizer":                        ErrNilMarshalizer,
+	"ErrInvalidRcvAddr":                        ErrInvalidRcvAddr,
+	"ErrNegativeValue":                         ErrNegativeValue,
+	"ErrNilShardCoordinator":                   ErrNilShardCoordinator,
+	"ErrWrongTypeAssertion":                    ErrWrongTypeAssertion,
+	"ErrNilSCDestAccount":                      ErrNilSCDestAccount,
+	"ErrNotEnoughGas":                          ErrNotEnoughGas,
+	"ErrInvalidArguments":                      ErrInvalidArguments,
+	"ErrOperationNotPermitted":                 ErrOperationNotPermitted,
+	"ErrInvalidAddressLength":                  ErrInvalidAddressLength,
+	"ErrNilVmInput":                            ErrNilVmInput,
+	"ErrNilDnsAddresses":                       ErrNilDnsAddresses,
+	"ErrCallerIsNotTheDNSAddress":              ErrCallerIsNotTheDNSAddress,
+	"ErrUserNameChangeIsDisabled":              ErrUserNameChangeIsDisabled,
+	"ErrBuiltInFunctionCalledWithValue":        ErrBuiltInFunctionCalledWithValue,
+	"ErrAccountNotPayable":                     ErrAccountNotPayable,
+	"ErrNilUserAccount":                        ErrNilUserAccount,
+	"ErrAddressIsNotDCTSystemSC":               ErrAddressIsNotDCTSystemSC,
+	"ErrOnlySystemAccountAccepted":             ErrOnlySystemAccountAccepted,
+	"ErrNilGlobalSettingsHandler":              ErrNilGlobalSettingsHandler,
+	"ErrNilRolesHandler":                       ErrNilRolesHandler,
+	"ErrDCTTokenIsPaused":                      ErrDCTTokenIsPaused,
+	"ErrDCTIsFrozenForAccount":                 ErrDCTIsFrozenForAccount,
+	"ErrCannotWipeAccountNotFrozen":            ErrCannotWipeAccountNotFrozen,
+	"ErrNilPayableHandler":                     ErrNilPayableHandler,
+	"ErrActionNotAllowed":                      ErrActionNotAllowed,
+	"ErrOnlyFungibleTokensHaveBalanceTransfer": ErrOnlyFungibleTokensHaveBalanceTransfer,
+	"ErrNFTTokenDoesNotExist":                  ErrNFTTokenDoesNotExist,
+	"ErrNFTDoesNotHaveMetadata":                ErrNFTDoesNotHaveMetadata,
+	"ErrInvalidNFTQuantity":                    ErrInvalidNFTQuantity,
+	"ErrNewNFTDataOnSenderAddress":             ErrNewNFTDataOnSenderAddress,
+	"ErrNilContainerElement":                   ErrNilContainerElement,
+	"ErrInvalidContainerKey":                   ErrInvalidContainerKey,
+	"ErrContainerKeyAlreadyExists":             ErrContainerKeyAlreadyExists,
+	"ErrWrongTypeInContainer":                  ErrWrongTypeInContainer,
+	"ErrEmptyFunctionName":                     ErrEmptyFunctionName,
+	"ErrInsufficientQuantityDCT":               ErrInsufficientQuantityDCT,
+	"ErrNilDCTNFTStorageHandler":               ErrNilDCTNFTStorageHandler,
+	"ErrNilTransactionHandler":                 ErrNilTransactionHandler,
+	"ErrAddressIsNotAllowed":                   ErrAddressIsNotAllowed,
+	"ErrInvalidNumOfArgs":                      ErrInvalidNumOfArgs,
+	"ErrInvalidNonce":                          ErrInvalidNonce,
+	"ErrTokenHasValidMetadata":                 ErrTokenHasValidMetadata,
+	"ErrInvalidTokenID":                        ErrInvalidTokenID,
+	"ErrNilDCTData":                            ErrNilDCTData,
+	"ErrInvalidMetadata":                       ErrInvalidMetadata,
+	"ErrInvalidLiquidityForDCT":                ErrInvalidLiquidityForDCT,
+	"ErrTooManyTransferAddresses":              ErrTooManyTransferAddresses,
+	"ErrInvalidMaxNumAddresses":                ErrInvalidMaxNumAddresses,
+	"ErrNilEnableEpochsHandler":                ErrNilEnableEpochsHandler,
+	"ErrNilActiveHandler":                      ErrNilActiveHandler,
+	"ErrInvalidNumberOfArguments":              ErrInvalidNumberOfArguments,
+	"ErrInvalidAddress":                        ErrInvalidAddress,
+	"ErrCannotSetOwnAddressAsGuardian":         ErrCannotSetOwnAddressAsGuardian,
+	"ErrOwnerAlreadyHasOneGuardianPending":     ErrOwnerAlreadyHasOneGuardianPending,
+	"ErrGuardianAlreadyExists":                 ErrGuardianAlreadyExists,
+	"ErrNoGuardianEnabled":                     ErrNoGuardianEnabled,
+	"ErrSetGuardAccountFlag":                   ErrSetGuardAccountFlag,
+	"ErrSetUnGuardAccount":                     ErrSetUnGuardAccount,
+	"ErrNilAccountHandler":                     ErrNilAccountHandler,
+	"ErrNilGuardedAccountHandler":              ErrNilGuardedAccountHandler,
+	"ErrInvalidServiceUID":                     ErrInvalidServiceUID,
+	"ErrCannotMigrateNilUserName":              ErrCannotMigrateNilUserName,
+	"ErrWrongUserNameSplit":                    ErrWrongUserNameSplit,
+	"ErrUserNamePrefixNotEqual":                ErrUserNamePrefixNotEqual,
+	"ErrNilBuiltInFunctionsContainer":          ErrNilBuiltInFunctionsContainer,
+	"ErrBuiltInFunctionIsNotActive":            ErrBuiltInFunctionIsNotActive,
+	"ErrDryRunNotSupported":                    ErrDryRunNotSupported,
+	"ErrNilGasCost":                            ErrNilGasCost,
+	"ErrGasEstimationNotSupported":             ErrGasEstimationNotSupported,
+	"ErrNilRoundNotifier":                      ErrNilRoundNotifier,
+	"ErrBuiltInFunctionCannotBeGated":          ErrBuiltInFunctionCannotBeGated,
+	"ErrUnknownGasCostField":                   ErrUnknownGasCostField,
+	"ErrNilBuiltInFunctionMetricsHandler":      ErrNilBuiltInFunctionMetricsHandler,
+	"ErrInvalidLatencyBuckets":                 ErrInvalidLatencyBuckets,
+	"ErrNilStorageTracer":                      ErrNilStorageTracer,
+	"ErrReadWriteSetNotSupported":              ErrReadWriteSetNotSupported,
+	"ErrNilBuiltInFunctionsCreator":            ErrNilBuiltInFunctionsCreator,
+}
+
+func TestBuiltInError(t *testing.T) {
+	t.Parallel()
+
+	builtInErr, ok := GetBuiltInError(ErrInsufficientFunds)
+	require.True(t, ok)
+	assert.Equal(t, "insufficient funds", builtInErr.Error())
+	assert.Equal(t, uint32(2), builtInErr.Code())
+	assert.Equal(t, ErrorCategoryOutOfFunds, builtInErr.Category())
+
+	wrappedErr := fmt.Errorf("%w for token %s", ErrInvalidArguments, "TOKEN-abcdef")
+	builtInErr, ok = GetBuiltInError(wrappedErr)
+	require.True(t, ok)
+	assert.True(t, errors.Is(wrappedErr, ErrInvalidArguments))
+	assert.Equal(t, ErrInvalidArguments, builtInErr)
+
+	_, ok = GetBuiltInError(errors.New("other error"))
+	assert.False(t, ok)
+	_, ok = GetBuiltInError(nil)
+	assert.False(t, ok)
+}
+
+func TestReturnCodeFromError(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, vmcommon.Ok, ReturnCodeFromError(nil))
+	assert.Equal(t, vmcommon.UserError, ReturnCodeFromError(errors.New("other error")))
+	assert.Equal(t, vmcommon.OutOfFunds, ReturnCodeFromError(ErrInsufficientFunds))
+	assert.Equal(t, vmcommon.OutOfFunds, ReturnCodeFromError(ErrInsufficientQuantityDCT))
+	assert.Equal(t, vmcommon.OutOfGas, ReturnCodeFromError(ErrNotEnoughGas))
+	assert.Equal(t, vmcommon.UserError, ReturnCodeFromError(fmt.Errorf("%w, wrong number of arguments", ErrInvalidArguments)))
+	assert.Equal(t, vmcommon.FunctionWrongSignature, ReturnCodeFromError(ErrInvalidNumberOfArguments))
+	assert.Equal(t, vmcommon.FunctionNotFound, ReturnCodeFromError(fmt.Errorf("%w in function container for key %v", ErrInvalidContainerKey, "key")))
+	assert.Equal(t, vmcommon.ExecutionFailed, ReturnCodeFromError(ErrNilMarshalizer))
+}
+
+func TestReturnMessageFromError(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "", ReturnMessageFromError(nil))
+	assert.Equal(t, "other error", ReturnMessageFromError(errors.New("other error")))
+	assert.Equal(t, ErrInvalidArguments.Error(), ReturnMessageFromError(fmt.Errorf("%w, invalid quantity", ErrInvalidArguments)))
+	assert.Equal(t, ErrInvalidArguments.Error(), ReturnMessageFromError(fmt.Errorf("%w, invalid max royality value", ErrInvalidArguments)))
+}
+
+func TestCreateVMOutputFromError(t *testing.T) {
+	t.Parallel()
+
+	vmOutput := CreateVMOutputFromError(fmt.Errorf("%w for token %s", ErrDCTTokenIsPaused, "TOKEN-abcdef"))
+	assert.Equal(t, vmcommon.UserError, vmOutput.ReturnCode)
+	assert.Equal(t, ErrDCTTokenIsPaused.Error(), vmOutput.ReturnMessage)
+	assert.Equal(t, uint64(0), vmOutput.GasRemaining)
+	assert.Equal(t, big.NewInt(0), vmOutput.GasRefund)
+	assert.NotNil(t, vmOutput.ReturnData)
+	assert.NotNil(t, vmOutput.OutputAccounts)
+	assert.NotNil(t, vmOutput.DeletedAccounts)
+	assert.NotNil(t, vmOutput.TouchedAccounts)
+	assert.NotNil(t, vmOutput.Logs)
+}
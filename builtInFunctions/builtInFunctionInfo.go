@@ -0,0 +1,146 @@
+package builtInFunctions
+
+import (
+	"fmt"
+
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+)
+
+// ArgumentType is the encoding of a built-in function argument
+type ArgumentType int
+
+const (
+	// ArgumentTypeBytes is an opaque byte slice
+	ArgumentTypeBytes ArgumentType = iota
+	// ArgumentTypeString is a human-readable string, as a function name, a user name or a role
+	ArgumentTypeString
+	// ArgumentTypeTokenIdentifier is a DCT token identifier, as TICKER-abcdef
+	ArgumentTypeTokenIdentifier
+	// ArgumentTypeNonce is a big endian encoded uint64
+	ArgumentTypeNonce
+	// ArgumentTypeBigUint is a big endian encoded unsigned big integer
+	ArgumentTypeBigUint
+	// ArgumentTypeAddress is an account address
+	ArgumentTypeAddress
+	// ArgumentTypeBool is a single byte, 0 or 1
+	ArgumentTypeBool
+)
+
+// String returns the name of the argument type
+func (argType ArgumentType) String() string {
+	switch argType {
+	case ArgumentTypeBytes:
+		return "bytes"
+	case ArgumentTypeString:
+		return "string"
+	case ArgumentTypeTokenIdentifier:
+		return "tokenIdentifier"
+	case ArgumentTypeNonce:
+		return "nonce"
+	case ArgumentTypeBigUint:
+		return "bigUint"
+	case ArgumentTypeAddress:
+		return "address"
+	case ArgumentTypeBool:
+		return "bool"
+	default:
+		return fmt.Sprintf("ArgumentType(%d)", int(argType))
+	}
+}
+
+// ArgumentInfo describes an argument of a built-in function
+type ArgumentInfo struct {
+	Name string
+	Type ArgumentType
+	// MaxLength is the maximum length of the argument, in bytes, or 0 if the length is not checked
+	MaxLength int
+	// Optional arguments can be missing, together with all the arguments after them
+	Optional bool
+	// Repeated arguments can be given any number of times. Consecutive repeated arguments form a group which is
+	// repeated as a whole.
+	Repeated bool
+}
+
+// BuiltInFunctionInfo describes a built-in function: its argument layout, the roles the caller must have, the gas
+// schedule costs it reads, as Section.Key, and how it is activated
+type BuiltInFunctionInfo struct {
+	Name           string
+	Arguments      []ArgumentInfo
+	RequiredRoles  []string
+	GasCostFields  []string
+	ActivationFlag vmcommon.Flag
+	// CrossShard is true if the sender and the destination of the function can be in different shards
+	CrossShard bool
+	// IsActive is only filled by the creator, after the built-in function container was created
+	IsActive bool
+}
+
+var (
+	tokenIdentifierArgument = TokenIdentifierArgument("tokenIdentifier")
+	nonceArgument           = NonceArgument("nonce")
+	valueArgument           = BigUintArgument("value", 0)
+	quantityArgument        = BigUintArgument("quantity", 0)
+	destinationArgument     = AddressArgument("destination")
+	functionArgument        = ArgumentInfo{Name: "function", Type: ArgumentTypeString, Optional: true}
+	functionArgsArgument    = ArgumentInfo{Name: "functionArguments", Type: ArgumentTypeBytes, Optional: true, Repeated: true}
+	rolesArgument           = ArgumentInfo{Name: "role", Type: ArgumentTypeString, Repeated: true}
+	addressesArgument       = ArgumentInfo{Name: "address", Type: ArgumentTypeAddress, Repeated: true}
+)
+
+// BuiltInFunctionNames returns the names of all the built-in functions, on all the shards, in registration order
+func BuiltInFunctionNames() []string {
+	descriptors := builtInFunctionDescriptors()
+	names := make([]string, 0, len(descriptors))
+	for _, descriptor := range descriptors {
+		names = append(names, descriptor.name)
+	}
+
+	return names
+}
+
+// DescribeBuiltInFunctions returns the description of all the built-in functions, on all the shards, in registration
+// order. IsActive is not filled.
+func DescribeBuiltInFunctions() []BuiltInFunctionInfo {
+	descriptors := builtInFunctionDescriptors()
+	infos := make([]BuiltInFunctionInfo, 0, len(descriptors))
+	for _, descriptor := range descriptors {
+		infos = append(infos, descriptor.info())
+	}
+
+	return infos
+}
+
+// DescribeBuiltInFunctions returns the description of the built-in functions added in the container, with IsActive
+// read from the created functions. The list is empty before the container is created.
+func (b *builtInFuncCreator) DescribeBuiltInFunctions() []BuiltInFunctionInfo {
+	infos := make([]BuiltInFunctionInfo, 0, b.builtInFunctions.Len())
+	for _, descriptor := range builtInFunctionDescriptors() {
+		builtInFunc, err := b.builtInFunctions.Get(descriptor.name)
+		if err != nil {
+			continue
+		}
+
+		info := descriptor.info()
+		info.IsActive = builtInFunc.IsActive()
+		infos = append(infos, info)
+	}
+
+	return infos
+}
+
+func (descriptor *builtInFunctionDescriptor) info() BuiltInFunctionInfo {
+	gasCostFields := make([]string, 0, len(descriptor.extraGasCostFields)+1)
+	if len(descriptor.gasCostField) > 0 {
+		gasCostFields = append(gasCostFields, builtInCostField(descriptor.gasCostField))
+	}
+	gasCostFields = append(gasCostFields, descriptor.extraGasCostFields...)
+
+	return BuiltInFunctionInfo{
+		Name:           descriptor.name,
+		Arguments:      append([]ArgumentInfo{}, descriptor.arguments...),
+		RequiredRoles:  append([]string{}, descriptor.requiredRoles...),
+		GasCostFields:  gasCostFields,
+		ActivationFlag: descriptor.activation.flag,
+		CrossShard:     descriptor.crossShard,
+	}
+}
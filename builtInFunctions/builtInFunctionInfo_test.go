@@ -0,0 +1,123 @@
+package builtInFunctions
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+)
+
+func TestArgumentType_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "bytes", ArgumentTypeBytes.String())
+	assert.Equal(t, "string", ArgumentTypeString.String())
+	assert.Equal(t, "tokenIdentifier", ArgumentTypeTokenIdentifier.String())
+	assert.Equal(t, "nonce", ArgumentTypeNonce.String())
+	assert.Equal(t, "bigUint", ArgumentTypeBigUint.String())
+	assert.Equal(t, "address", ArgumentTypeAddress.String())
+	assert.Equal(t, "bool", ArgumentTypeBool.String())
+	assert.Equal(t, "ArgumentType(100)", ArgumentType(100).String())
+}
+
+func TestBuiltInFunctionNames(t *testing.T) {
+	t.Parallel()
+
+	names := BuiltInFunctionNames()
+	assert.Equal(t, 36, len(names))
+	assert.Equal(t, core.BuiltInFunctionClaimDeveloperRewards, names[0])
+	assert.Equal(t, core.BuiltInFunctionMigrateDataTrie, names[len(names)-1])
+}
+
+func TestDescribeBuiltInFunctions(t *testing.T) {
+	t.Parallel()
+
+	gasCostType := reflect.TypeOf(vmcommon.GasCost{})
+	infos := DescribeBuiltInFunctions()
+	require.Equal(t, len(BuiltInFunctionNames()), len(infos))
+	for i, info := range infos {
+		assert.Equal(t, BuiltInFunctionNames()[i], info.Name)
+		assert.False(t, info.IsActive, info.Name)
+
+		for _, gasCostField := range info.GasCostFields {
+			parts := strings.Split(gasCostField, ".")
+			require.Equal(t, 2, len(parts), gasCostField)
+			section, found := gasCostType.FieldByName(parts[0])
+			require.True(t, found, "unknown section of %s in %s", gasCostField, info.Name)
+			_, found = section.Type.FieldByName(parts[1])
+			assert.True(t, found, "unknown gas cost %s in %s", gasCostField, info.Name)
+		}
+
+		isOptional := false
+		for _, argument := range info.Arguments {
+			assert.NotEmpty(t, argument.Name, info.Name)
+			assert.False(t, isOptional && !argument.Optional, "mandatory argument %s after optional in %s", argument.Name, info.Name)
+			isOptional = isOptional || argument.Optional
+		}
+	}
+
+	t.Run("should describe the argument layout and the requirements", func(t *testing.T) {
+		t.Parallel()
+
+		info := findBuiltInFunctionInfo(DescribeBuiltInFunctions(), core.BuiltInFunctionDCTNFTCreate)
+		require.NotNil(t, info)
+		assert.Equal(t, 7, len(info.Arguments))
+		assert.Equal(t, ArgumentTypeTokenIdentifier, info.Arguments[0].Type)
+		assert.True(t, info.Arguments[6].Repeated)
+		assert.Equal(t, []string{core.DCTRoleNFTCreate}, info.RequiredRoles)
+		assert.Equal(t, []string{"BuiltInCost.DCTNFTCreate", "BaseOperationCost.StorePerByte"}, info.GasCostFields)
+		assert.Empty(t, info.ActivationFlag)
+		assert.False(t, info.CrossShard)
+
+		info = findBuiltInFunctionInfo(DescribeBuiltInFunctions(), core.BuiltInFunctionMultiDCTNFTTransfer)
+		require.NotNil(t, info)
+		assert.Equal(t, ArgumentTypeAddress, info.Arguments[0].Type)
+		assert.True(t, info.CrossShard)
+		assert.NotEmpty(t, info.ActivationFlag)
+	})
+	t.Run("returned infos should not share the registry slices", func(t *testing.T) {
+		t.Parallel()
+
+		info := findBuiltInFunctionInfo(DescribeBuiltInFunctions(), core.BuiltInFunctionDCTTransfer)
+		info.Arguments[0].Name = "changed"
+
+		info = findBuiltInFunctionInfo(DescribeBuiltInFunctions(), core.BuiltInFunctionDCTTransfer)
+		assert.Equal(t, tokenIdentifierArgument, info.Arguments[0])
+	})
+}
+
+func TestBuiltInFuncCreator_DescribeBuiltInFunctions(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArguments()
+	args.EnableEpochsHandler = createEnableEpochsHandlerWithAllFlags(false)
+	f, _ := NewBuiltInFunctionsCreator(args)
+	assert.Empty(t, f.DescribeBuiltInFunctions())
+
+	err := f.CreateBuiltInFunctionContainer()
+	require.Nil(t, err)
+
+	infos := f.DescribeBuiltInFunctions()
+	assert.Equal(t, f.BuiltInFunctionContainer().Len(), len(infos))
+	for _, info := range infos {
+		builtInFunc, errGet := f.BuiltInFunctionContainer().Get(info.Name)
+		require.Nil(t, errGet)
+		assert.Equal(t, builtInFunc.IsActive(), info.IsActive, info.Name)
+	}
+	assert.True(t, findBuiltInFunctionInfo(infos, core.BuiltInFunctionDCTTransfer).IsActive)
+	assert.False(t, findBuiltInFunctionInfo(infos, core.BuiltInFunctionDCTBurn).IsActive)
+}
+
+func findBuiltInFunctionInfo(infos []BuiltInFunctionInfo, name string) *BuiltInFunctionInfo {
+	for i := range infos {
+		if infos[i].Name == name {
+			return &infos[i]
+		}
+	}
+
+	return nil
+}
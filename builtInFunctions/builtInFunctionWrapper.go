@@ -0,0 +1,37 @@
+package builtInFunctions
+
+import (
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+)
+
+// builtInFunctionWrapper is embedded by the decorators of the built-in functions. Besides the BuiltinFunction
+// methods, it forwards the optional interfaces checked by type assertion, which the decorator would otherwise hide.
+type builtInFunctionWrapper struct {
+	vmcommon.BuiltinFunction
+}
+
+// CheckIsExecutable forwards the check to the wrapped function, if it supports it
+func (wrapper *builtInFunctionWrapper) CheckIsExecutable(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) error {
+	checker, ok := wrapper.BuiltinFunction.(vmcommon.ExecutableChecker)
+	if !ok {
+		return ErrDryRunNotSupported
+	}
+
+	return checker.CheckIsExecutable(acntSnd, acntDst, vmInput)
+}
+
+// SetPayableChecker forwards the payable checker to the wrapped function, if it accepts it
+func (wrapper *builtInFunctionWrapper) SetPayableChecker(payableCheck vmcommon.PayableChecker) error {
+	payableAcceptor, ok := wrapper.BuiltinFunction.(vmcommon.AcceptPayableChecker)
+	if !ok {
+		return ErrWrongTypeAssertion
+	}
+
+	return payableAcceptor.SetPayableChecker(payableCheck)
+}
+
+// IsStorageTraced forwards the check to the wrapped function, if it supports it
+func (wrapper *builtInFunctionWrapper) IsStorageTraced() bool {
+	checker, ok := wrapper.BuiltinFunction.(storageTracedChecker)
+	return ok && checker.IsStorageTraced()
+}
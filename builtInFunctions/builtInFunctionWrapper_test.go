@@ -0,0 +1,47 @@
+package builtInFunctions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/subrahamanyam341/andes-vm-common-123/mock"
+)
+
+func TestBuiltInFunctionWrapper_CheckIsExecutable(t *testing.T) {
+	t.Parallel()
+
+	wrapper := &builtInFunctionWrapper{BuiltinFunction: &mock.BuiltInFunctionStub{}}
+	assert.Equal(t, ErrDryRunNotSupported, wrapper.CheckIsExecutable(nil, nil, nil))
+
+	claimDeveloperRewards := NewClaimDeveloperRewardsFunc(10)
+	wrapper = &builtInFunctionWrapper{BuiltinFunction: claimDeveloperRewards}
+	err := wrapper.CheckIsExecutable(nil, nil, nil)
+	assert.NotEqual(t, ErrDryRunNotSupported, err)
+	assert.Equal(t, claimDeveloperRewards.CheckIsExecutable(nil, nil, nil), err)
+}
+
+func TestBuiltInFunctionWrapper_SetPayableChecker(t *testing.T) {
+	t.Parallel()
+
+	wrapper := &builtInFunctionWrapper{BuiltinFunction: &mock.BuiltInFunctionStub{}}
+	assert.Equal(t, ErrWrongTypeAssertion, wrapper.SetPayableChecker(&mock.PayableHandlerStub{}))
+
+	transferFunc, _ := NewDCTTransferFunc(10, &mock.MarshalizerMock{}, &mock.GlobalSettingsHandlerStub{}, &mock.ShardCoordinatorStub{}, &mock.DCTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{})
+	wrapper = &builtInFunctionWrapper{BuiltinFunction: transferFunc}
+	payableHandler := &mock.PayableHandlerStub{}
+	assert.Nil(t, wrapper.SetPayableChecker(payableHandler))
+	assert.True(t, transferFunc.payableHandler == payableHandler)
+}
+
+func TestBuiltInFunctionWrapper_IsStorageTraced(t *testing.T) {
+	t.Parallel()
+
+	wrapper := &builtInFunctionWrapper{BuiltinFunction: &mock.BuiltInFunctionStub{}}
+	assert.False(t, wrapper.IsStorageTraced())
+
+	tracer, _ := NewTracingAccountsAdapter(&mock.AccountsStub{})
+	traced, _ := NewTracedBuiltInFunction("function", &mock.BuiltInFunctionStub{}, tracer)
+	assert.True(t, traced.IsStorageTraced())
+	wrapper = &builtInFunctionWrapper{BuiltinFunction: traced}
+	assert.True(t, wrapper.IsStorageTraced())
+}
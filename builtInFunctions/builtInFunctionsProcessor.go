@@ -0,0 +1,220 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+)
+
+var _ vmcommon.BuiltInFunctionsProcessor = (*builtInFunctionsProcessor)(nil)
+
+// ArgsNewBuiltInFunctionsProcessor is the argument structure used to create a new built-in functions processor
+type ArgsNewBuiltInFunctionsProcessor struct {
+	Accounts         vmcommon.AccountsAdapter
+	ShardCoordinator vmcommon.Coordinator
+	BuiltInFunctions vmcommon.BuiltInFunctionContainer
+}
+
+type builtInFunctionsProcessor struct {
+	accounts         vmcommon.AccountsAdapter
+	shardCoordinator vmcommon.Coordinator
+	builtInFunctions vmcommon.BuiltInFunctionContainer
+}
+
+// NewBuiltInFunctionsProcessor creates a component that executes a built-in function as a single operation:
+// it loads the accounts, dispatches the call and saves the accounts back, reverting all changes on error
+func NewBuiltInFunctionsProcessor(args ArgsNewBuiltInFunctionsProcessor) (*builtInFunctionsProcessor, error) {
+	if check.IfNil(args.Accounts) {
+		return nil, ErrNilAccountsAdapter
+	}
+	if check.IfNil(args.ShardCoordinator) {
+		return nil, ErrNilShardCoordinator
+	}
+	if check.IfNil(args.BuiltInFunctions) {
+		return nil, ErrNilBuiltInFunctionsContainer
+	}
+
+	return &builtInFunctionsProcessor{
+		accounts:         args.Accounts,
+		shardCoordinator: args.ShardCoordinator,
+		builtInFunctions: args.BuiltInFunctions,
+	}, nil
+}
+
+// ProcessBuiltInFunction executes the built-in function named in the input. Only the accounts that belong to the
+// self shard are loaded, the function receives a nil account for an address from another shard. If the function
+// or the save of the accounts fails, the accounts are reverted to the state they had before the call.
+func (bfp *builtInFunctionsProcessor) ProcessBuiltInFunction(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	function, err := bfp.getActiveFunction(input)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := bfp.accounts.JournalLen()
+	vmOutput, err := bfp.processBuiltInFunction(function, input)
+	if err != nil {
+		errRevert := bfp.accounts.RevertToSnapshot(snapshot)
+		if errRevert != nil {
+			log.Warn("builtInFunctionsProcessor.ProcessBuiltInFunction: revert to snapshot",
+				"function", input.Function,
+				"error", errRevert.Error())
+		}
+
+		return nil, err
+	}
+
+	return completeVMOutput(vmOutput), nil
+}
+
+// CheckIsExecutable checks if the built-in function named in the input can be executed, without changing the
+// accounts. It errors if the function does not support the check.
+func (bfp *builtInFunctionsProcessor) CheckIsExecutable(input *vmcommon.ContractCallInput) error {
+	function, err := bfp.getActiveFunction(input)
+	if err != nil {
+		return err
+	}
+
+	checker, ok := function.(vmcommon.ExecutableChecker)
+	if !ok {
+		return ErrDryRunNotSupported
+	}
+
+	acntSnd, acntDst, err := bfp.loadAccounts(input)
+	if err != nil {
+		return err
+	}
+
+	return checker.CheckIsExecutable(acntSnd, acntDst, input)
+}
+
+func (bfp *builtInFunctionsProcessor) getActiveFunction(input *vmcommon.ContractCallInput) (vmcommon.BuiltinFunction, error) {
+	if input == nil {
+		return nil, ErrNilVmInput
+	}
+	if input.CallValue == nil {
+		return nil, ErrNilValue
+	}
+
+	function, err := bfp.builtInFunctions.Get(input.Function)
+	if err != nil {
+		return nil, err
+	}
+	if !function.IsActive() {
+		return nil, ErrBuiltInFunctionIsNotActive
+	}
+
+	return function, nil
+}
+
+func (bfp *builtInFunctionsProcessor) processBuiltInFunction(
+	function vmcommon.BuiltinFunction,
+	input *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	acntSnd, acntDst, err := bfp.loadAccounts(input)
+	if err != nil {
+		return nil, err
+	}
+
+	vmOutput, err := function.ProcessBuiltinFunction(acntSnd, acntDst, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if !check.IfNil(acntSnd) {
+		err = bfp.accounts.SaveAccount(acntSnd)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !check.IfNil(acntDst) && !bytes.Equal(input.CallerAddr, input.RecipientAddr) {
+		err = bfp.accounts.SaveAccount(acntDst)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return vmOutput, nil
+}
+
+func (bfp *builtInFunctionsProcessor) loadAccounts(
+	input *vmcommon.ContractCallInput,
+) (vmcommon.UserAccountHandler, vmcommon.UserAccountHandler, error) {
+	acntSnd, err := bfp.loadAccountIfInSelfShard(input.CallerAddr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the same account is passed twice, so that the changes made through both parameters end up in a single save
+	if bytes.Equal(input.CallerAddr, input.RecipientAddr) {
+		return acntSnd, acntSnd, nil
+	}
+
+	acntDst, err := bfp.loadAccountIfInSelfShard(input.RecipientAddr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return acntSnd, acntDst, nil
+}
+
+func (bfp *builtInFunctionsProcessor) loadAccountIfInSelfShard(address []byte) (vmcommon.UserAccountHandler, error) {
+	if len(address) == 0 || bfp.shardCoordinator.ComputeId(address) != bfp.shardCoordinator.SelfId() {
+		return nil, nil
+	}
+
+	account, err := bfp.accounts.LoadAccount(address)
+	if err != nil {
+		return nil, err
+	}
+
+	userAccount, ok := account.(vmcommon.UserAccountHandler)
+	if !ok {
+		return nil, ErrWrongTypeAssertion
+	}
+
+	return userAccount, nil
+}
+
+// completeVMOutput makes sure that none of the collections and big ints of the output are nil, so that the
+// callers can use the output without any further checks
+func completeVMOutput(vmOutput *vmcommon.VMOutput) *vmcommon.VMOutput {
+	if vmOutput == nil {
+		vmOutput = &vmcommon.VMOutput{}
+	}
+	if vmOutput.ReturnData == nil {
+		vmOutput.ReturnData = make([][]byte, 0)
+	}
+	if vmOutput.GasRefund == nil {
+		vmOutput.GasRefund = big.NewInt(0)
+	}
+	if vmOutput.OutputAccounts == nil {
+		vmOutput.OutputAccounts = make(map[string]*vmcommon.OutputAccount)
+	}
+	if vmOutput.DeletedAccounts == nil {
+		vmOutput.DeletedAccounts = make([][]byte, 0)
+	}
+	if vmOutput.TouchedAccounts == nil {
+		vmOutput.TouchedAccounts = make([][]byte, 0)
+	}
+	if vmOutput.Logs == nil {
+		vmOutput.Logs = make([]*vmcommon.LogEntry, 0)
+	}
+
+	for _, outAcc := range vmOutput.OutputAccounts {
+		if outAcc.BalanceDelta == nil {
+			outAcc.BalanceDelta = big.NewInt(0)
+		}
+		if outAcc.StorageUpdates == nil {
+			outAcc.StorageUpdates = make(map[string]*vmcommon.StorageUpdate)
+		}
+	}
+
+	return vmOutput
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (bfp *builtInFunctionsProcessor) IsInterfaceNil() bool {
+	return bfp == nil
+}
@@ -0,0 +1,368 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core"
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	"github.com/subrahamanyam341/andes-core-16/data/dct"
+	"github.com/subrahamanyam341/andes-core-16/hashing/sha256"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+	"github.com/subrahamanyam341/andes-vm-common-123/mock"
+	"github.com/subrahamanyam341/andes-vm-common-123/state"
+)
+
+func createMockBuiltInFunctionsProcessorArgs() ArgsNewBuiltInFunctionsProcessor {
+	accounts, _ := state.NewAccountsAdapter(state.ArgsNewAccountsAdapter{
+		Hasher:              sha256.NewSha256(),
+		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+	})
+
+	return ArgsNewBuiltInFunctionsProcessor{
+		Accounts:         accounts,
+		ShardCoordinator: mock.NewMultiShardsCoordinatorMock(1),
+		BuiltInFunctions: NewBuiltInFunctionContainer(),
+	}
+}
+
+func createBuiltInFunctionsProcessorInput(function string, sender []byte, receiver []byte) *vmcommon.ContractCallInput {
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  sender,
+			CallValue:   big.NewInt(0),
+			GasProvided: 100,
+		},
+		RecipientAddr: receiver,
+		Function:      function,
+	}
+}
+
+func getBalanceFromAccounts(t *testing.T, accounts vmcommon.AccountsAdapter, address []byte) *big.Int {
+	account, err := accounts.LoadAccount(address)
+	require.Nil(t, err)
+
+	return account.(vmcommon.UserAccountHandler).GetBalance()
+}
+
+func TestNewBuiltInFunctionsProcessor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil accounts should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockBuiltInFunctionsProcessorArgs()
+		args.Accounts = nil
+		bfp, err := NewBuiltInFunctionsProcessor(args)
+		assert.True(t, check.IfNil(bfp))
+		assert.Equal(t, ErrNilAccountsAdapter, err)
+	})
+	t.Run("nil shard coordinator should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockBuiltInFunctionsProcessorArgs()
+		args.ShardCoordinator = nil
+		bfp, err := NewBuiltInFunctionsProcessor(args)
+		assert.True(t, check.IfNil(bfp))
+		assert.Equal(t, ErrNilShardCoordinator, err)
+	})
+	t.Run("nil container should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockBuiltInFunctionsProcessorArgs()
+		args.BuiltInFunctions = nil
+		bfp, err := NewBuiltInFunctionsProcessor(args)
+		assert.True(t, check.IfNil(bfp))
+		assert.Equal(t, ErrNilBuiltInFunctionsContainer, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		bfp, err := NewBuiltInFunctionsProcessor(createMockBuiltInFunctionsProcessorArgs())
+		assert.False(t, check.IfNil(bfp))
+		assert.Nil(t, err)
+	})
+}
+
+func TestBuiltInFunctionsProcessor_ProcessBuiltInFunction(t *testing.T) {
+	t.Parallel()
+
+	sender := bytes.Repeat([]byte{1}, 32)
+	receiver := bytes.Repeat([]byte{2}, 32)
+
+	t.Run("invalid input should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockBuiltInFunctionsProcessorArgs()
+		_ = args.BuiltInFunctions.Add("inactive", &mock.BuiltInFunctionStub{
+			IsActiveCalled: func() bool {
+				return false
+			},
+		})
+		bfp, _ := NewBuiltInFunctionsProcessor(args)
+
+		_, err := bfp.ProcessBuiltInFunction(nil)
+		assert.Equal(t, ErrNilVmInput, err)
+
+		input := createBuiltInFunctionsProcessorInput("func", sender, receiver)
+		input.CallValue = nil
+		_, err = bfp.ProcessBuiltInFunction(input)
+		assert.Equal(t, ErrNilValue, err)
+
+		_, err = bfp.ProcessBuiltInFunction(createBuiltInFunctionsProcessorInput("missing", sender, receiver))
+		assert.True(t, errors.Is(err, ErrInvalidContainerKey))
+
+		_, err = bfp.ProcessBuiltInFunction(createBuiltInFunctionsProcessorInput("inactive", sender, receiver))
+		assert.Equal(t, ErrBuiltInFunctionIsNotActive, err)
+	})
+	t.Run("function error should revert all changes", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockBuiltInFunctionsProcessorArgs()
+		expectedErr := errors.New("expected error")
+		_ = args.BuiltInFunctions.Add("func", &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				// a function might save other accounts before failing
+				_ = args.Accounts.SaveAccount(acntDst)
+				return nil, expectedErr
+			},
+		})
+		bfp, _ := NewBuiltInFunctionsProcessor(args)
+
+		vmOutput, err := bfp.ProcessBuiltInFunction(createBuiltInFunctionsProcessorInput("func", sender, receiver))
+		assert.Nil(t, vmOutput)
+		assert.Equal(t, expectedErr, err)
+		assert.Equal(t, 0, args.Accounts.JournalLen())
+	})
+	t.Run("save error should revert all changes", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockBuiltInFunctionsProcessorArgs()
+		accounts := args.Accounts
+		expectedErr := errors.New("expected error")
+		args.Accounts = &mock.AccountsStub{
+			LoadAccountCalled: accounts.LoadAccount,
+			SaveAccountCalled: func(account vmcommon.AccountHandler) error {
+				if bytes.Equal(account.AddressBytes(), receiver) {
+					return expectedErr
+				}
+				return accounts.SaveAccount(account)
+			},
+			JournalLenCalled:       accounts.JournalLen,
+			RevertToSnapshotCalled: accounts.RevertToSnapshot,
+		}
+		_ = args.BuiltInFunctions.Add("func", &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				_ = acntSnd.AddToBalance(big.NewInt(10))
+				return &vmcommon.VMOutput{}, nil
+			},
+		})
+		bfp, _ := NewBuiltInFunctionsProcessor(args)
+
+		_, err := bfp.ProcessBuiltInFunction(createBuiltInFunctionsProcessorInput("func", sender, receiver))
+		assert.Equal(t, expectedErr, err)
+		assert.Equal(t, 0, accounts.JournalLen())
+		assert.Equal(t, big.NewInt(0), getBalanceFromAccounts(t, accounts, sender))
+	})
+	t.Run("should save both accounts and complete the output", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockBuiltInFunctionsProcessorArgs()
+		_ = args.BuiltInFunctions.Add("func", &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				_ = acntSnd.AddToBalance(big.NewInt(10))
+				_ = acntDst.AddToBalance(big.NewInt(20))
+				return &vmcommon.VMOutput{
+					GasRemaining: vmInput.GasProvided - 1,
+					OutputAccounts: map[string]*vmcommon.OutputAccount{
+						string(receiver): {Address: receiver},
+					},
+				}, nil
+			},
+		})
+		bfp, _ := NewBuiltInFunctionsProcessor(args)
+
+		vmOutput, err := bfp.ProcessBuiltInFunction(createBuiltInFunctionsProcessorInput("func", sender, receiver))
+		require.Nil(t, err)
+		assert.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+		assert.Equal(t, uint64(99), vmOutput.GasRemaining)
+		assert.NotNil(t, vmOutput.ReturnData)
+		assert.Equal(t, big.NewInt(0), vmOutput.GasRefund)
+		assert.NotNil(t, vmOutput.DeletedAccounts)
+		assert.NotNil(t, vmOutput.TouchedAccounts)
+		assert.NotNil(t, vmOutput.Logs)
+		outAcc := vmOutput.OutputAccounts[string(receiver)]
+		assert.Equal(t, big.NewInt(0), outAcc.BalanceDelta)
+		assert.NotNil(t, outAcc.StorageUpdates)
+
+		assert.Equal(t, big.NewInt(10), getBalanceFromAccounts(t, args.Accounts, sender))
+		assert.Equal(t, big.NewInt(20), getBalanceFromAccounts(t, args.Accounts, receiver))
+	})
+	t.Run("nil output should be completed", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockBuiltInFunctionsProcessorArgs()
+		_ = args.BuiltInFunctions.Add("func", &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(_, _ vmcommon.UserAccountHandler, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				return nil, nil
+			},
+		})
+		bfp, _ := NewBuiltInFunctionsProcessor(args)
+
+		vmOutput, err := bfp.ProcessBuiltInFunction(createBuiltInFunctionsProcessorInput("func", sender, receiver))
+		require.Nil(t, err)
+		assert.NotNil(t, vmOutput.OutputAccounts)
+		assert.Equal(t, big.NewInt(0), vmOutput.GasRefund)
+	})
+	t.Run("same sender and receiver should use a single account", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockBuiltInFunctionsProcessorArgs()
+		_ = args.BuiltInFunctions.Add("func", &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				_ = acntSnd.AddToBalance(big.NewInt(10))
+				_ = acntDst.AddToBalance(big.NewInt(20))
+				return &vmcommon.VMOutput{}, nil
+			},
+		})
+		bfp, _ := NewBuiltInFunctionsProcessor(args)
+
+		_, err := bfp.ProcessBuiltInFunction(createBuiltInFunctionsProcessorInput("func", sender, sender))
+		require.Nil(t, err)
+		assert.Equal(t, big.NewInt(30), getBalanceFromAccounts(t, args.Accounts, sender))
+		assert.Equal(t, 1, args.Accounts.JournalLen())
+	})
+	t.Run("accounts from other shards should be nil", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockBuiltInFunctionsProcessorArgs()
+		args.ShardCoordinator = &mock.ShardCoordinatorStub{
+			ComputeIdCalled: func(address []byte) uint32 {
+				if bytes.Equal(address, sender) {
+					return 1
+				}
+				return 0
+			},
+		}
+		_ = args.BuiltInFunctions.Add("func", &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				assert.True(t, check.IfNil(acntSnd))
+				assert.False(t, check.IfNil(acntDst))
+				return &vmcommon.VMOutput{}, nil
+			},
+		})
+		bfp, _ := NewBuiltInFunctionsProcessor(args)
+
+		_, err := bfp.ProcessBuiltInFunction(createBuiltInFunctionsProcessorInput("func", sender, receiver))
+		require.Nil(t, err)
+		assert.Equal(t, 1, args.Accounts.JournalLen())
+	})
+}
+
+func TestBuiltInFunctionsProcessor_CheckIsExecutable(t *testing.T) {
+	t.Parallel()
+
+	sender := bytes.Repeat([]byte{1}, 32)
+	receiver := bytes.Repeat([]byte{2}, 32)
+
+	t.Run("invalid input should error", func(t *testing.T) {
+		t.Parallel()
+
+		bfp, _ := NewBuiltInFunctionsProcessor(createMockBuiltInFunctionsProcessorArgs())
+
+		assert.Equal(t, ErrNilVmInput, bfp.CheckIsExecutable(nil))
+		err := bfp.CheckIsExecutable(createBuiltInFunctionsProcessorInput("missing", sender, receiver))
+		assert.True(t, errors.Is(err, ErrInvalidContainerKey))
+	})
+	t.Run("function without dry run should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockBuiltInFunctionsProcessorArgs()
+		_ = args.BuiltInFunctions.Add("func", &mock.BuiltInFunctionStub{})
+		bfp, _ := NewBuiltInFunctionsProcessor(args)
+
+		err := bfp.CheckIsExecutable(createBuiltInFunctionsProcessorInput("func", sender, receiver))
+		assert.Equal(t, ErrDryRunNotSupported, err)
+	})
+	t.Run("should check on the loaded accounts", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockBuiltInFunctionsProcessorArgs()
+		expectedErr := errors.New("expected error")
+		_ = args.BuiltInFunctions.Add("func", &executableCheckerStub{
+			BuiltInFunctionStub: &mock.BuiltInFunctionStub{},
+			checkIsExecutableCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, _ *vmcommon.ContractCallInput) error {
+				assert.Equal(t, sender, acntSnd.AddressBytes())
+				assert.Equal(t, receiver, acntDst.AddressBytes())
+				return expectedErr
+			},
+		})
+		bfp, _ := NewBuiltInFunctionsProcessor(args)
+
+		err := bfp.CheckIsExecutable(createBuiltInFunctionsProcessorInput("func", sender, receiver))
+		assert.Equal(t, expectedErr, err)
+		assert.Equal(t, 0, args.Accounts.JournalLen())
+	})
+}
+
+type executableCheckerStub struct {
+	*mock.BuiltInFunctionStub
+	checkIsExecutableCalled func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) error
+}
+
+func (stub *executableCheckerStub) CheckIsExecutable(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) error {
+	return stub.checkIsExecutableCalled(acntSnd, acntDst, vmInput)
+}
+
+func TestBuiltInFunctionsProcessor_DCTTransferWithInMemoryState(t *testing.T) {
+	t.Parallel()
+
+	marshaller := &mock.MarshalizerMock{}
+	processorArgs := createMockBuiltInFunctionsProcessorArgs()
+	creatorArgs := createMockArguments()
+	creatorArgs.Accounts = processorArgs.Accounts
+	creatorArgs.Marshalizer = marshaller
+	creator, _ := NewBuiltInFunctionsCreator(creatorArgs)
+	require.Nil(t, creator.CreateBuiltInFunctionContainer())
+	require.Nil(t, creator.SetPayableHandler(&mock.PayableHandlerStub{}))
+	processorArgs.BuiltInFunctions = creator.BuiltInFunctionContainer()
+	bfp, _ := NewBuiltInFunctionsProcessor(processorArgs)
+
+	tokenID := []byte("TOKEN-abcdef")
+	tokenKey := append([]byte(baseDCTKeyPrefix), tokenID...)
+	sender := bytes.Repeat([]byte{1}, 32)
+	receiver := bytes.Repeat([]byte{2}, 32)
+
+	account, _ := processorArgs.Accounts.LoadAccount(sender)
+	dctData := &dct.DCToken{Value: big.NewInt(100), Type: uint32(core.Fungible)}
+	require.Nil(t, saveDCTData(account.(vmcommon.UserAccountHandler), dctData, tokenKey, marshaller))
+	require.Nil(t, processorArgs.Accounts.SaveAccount(account))
+	_, _ = processorArgs.Accounts.Commit()
+	rootHash, _ := processorArgs.Accounts.RootHash()
+
+	getTokenBalance := func(address []byte) *big.Int {
+		acnt, _ := processorArgs.Accounts.LoadAccount(address)
+		data, _ := getDCTDataFromKey(acnt.(vmcommon.UserAccountHandler), tokenKey, marshaller)
+		return data.Value
+	}
+	createTransferInput := func(value int64) *vmcommon.ContractCallInput {
+		input := createBuiltInFunctionsProcessorInput(core.BuiltInFunctionDCTTransfer, sender, receiver)
+		input.Arguments = [][]byte{tokenID, big.NewInt(value).Bytes()}
+		return input
+	}
+
+	_, err := bfp.ProcessBuiltInFunction(createTransferInput(101))
+	assert.Equal(t, ErrInsufficientFunds, err)
+	rootHashAfterError, _ := processorArgs.Accounts.RootHash()
+	assert.Equal(t, rootHash, rootHashAfterError)
+
+	vmOutput, err := bfp.ProcessBuiltInFunction(createTransferInput(40))
+	require.Nil(t, err)
+	assert.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	assert.Equal(t, big.NewInt(60), getTokenBalance(sender))
+	assert.Equal(t, big.NewInt(40), getTokenBalance(receiver))
+}
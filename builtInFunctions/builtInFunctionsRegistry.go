@@ -0,0 +1,502 @@
+package builtInFunctions
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/subrahamanyam341/andes-core-16/core"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+	"github.com/subrahamanyam341/andes-vm-common-123/enablers"
+)
+
+// activationFlag is the flag that activates a built-in function
+type activationFlag struct {
+	flag      vmcommon.Flag
+	isEnabled func(handler vmcommon.EnableEpochsHandler) bool
+}
+
+var (
+	alwaysActive               = activationFlag{}
+	globalMintBurnActivation   = activationFlag{flag: enablers.GlobalMintBurnDisableFlag, isEnabled: vmcommon.EnableEpochsHandler.IsGlobalMintBurnFlagEnabled}
+	sendAlwaysActivation       = activationFlag{flag: enablers.SendAlwaysFlag, isEnabled: vmcommon.EnableEpochsHandler.IsSendAlwaysFlagEnabled}
+	nftImprovementV1Activation = activationFlag{flag: enablers.DCTNFTImprovementV1Flag, isEnabled: vmcommon.EnableEpochsHandler.IsDCTNFTImprovementV1FlagEnabled}
+	transferRoleActivation     = activationFlag{flag: enablers.DCTTransferRoleFlag, isEnabled: vmcommon.EnableEpochsHandler.IsDCTTransferRoleFlagEnabled}
+	setGuardianActivation      = activationFlag{flag: enablers.SetGuardianFlag, isEnabled: vmcommon.EnableEpochsHandler.IsSetGuardianEnabled}
+	changeUsernameActivation   = activationFlag{flag: enablers.ChangeUsernameFlag, isEnabled: vmcommon.EnableEpochsHandler.IsChangeUsernameEnabled}
+	migrateDataTrieActivation  = activationFlag{flag: enablers.MigrateDataTrieFlag, isEnabled: vmcommon.EnableEpochsHandler.IsMigrateDataTrieEnabled}
+)
+
+// shardRestriction tells on which shards a built-in function is registered
+type shardRestriction int
+
+const (
+	allShards shardRestriction = iota
+	regularShardsOnly
+	metachainOnly
+)
+
+func (restriction shardRestriction) allows(shardID uint32) bool {
+	switch restriction {
+	case regularShardsOnly:
+		return shardID != core.MetachainShardId
+	case metachainOnly:
+		return shardID == core.MetachainShardId
+	default:
+		return true
+	}
+}
+
+// builtInFunctionDescriptor declares a built-in function: its name, how it is created, the BuiltInCost field it is
+// charged with, the flag that activates it and the shards it is registered on. The other fields are only used to
+// describe the function.
+type builtInFunctionDescriptor struct {
+	name               string
+	gasCostField       string
+	activation         activationFlag
+	shards             shardRestriction
+	create             func(b *builtInFuncCreator, gasCost uint64) (vmcommon.BuiltinFunction, error)
+	arguments          []ArgumentInfo
+	requiredRoles      []string
+	extraGasCostFields []string
+	crossShard         bool
+}
+
+// builtInFunctionDescriptors returns the descriptors of all the built-in functions, in registration order
+func builtInFunctionDescriptors() []*builtInFunctionDescriptor {
+	return []*builtInFunctionDescriptor{
+		{
+			name:         core.BuiltInFunctionClaimDeveloperRewards,
+			gasCostField: "ClaimDeveloperRewards",
+			create: func(_ *builtInFuncCreator, gasCost uint64) (vmcommon.BuiltinFunction, error) {
+				return NewClaimDeveloperRewardsFunc(gasCost), nil
+			},
+		},
+		{
+			name:         core.BuiltInFunctionChangeOwnerAddress,
+			gasCostField: "ChangeOwnerAddress",
+			arguments:    []ArgumentInfo{{Name: "newOwner", Type: ArgumentTypeAddress}},
+			create: func(b *builtInFuncCreator, gasCost uint64) (vmcommon.BuiltinFunction, error) {
+				return NewChangeOwnerAddressFunc(gasCost, b.enableEpochsHandler)
+			},
+		},
+		{
+			name:         core.BuiltInFunctionSetUserName,
+			gasCostField: "SaveUserName",
+			arguments:    []ArgumentInfo{{Name: "userName", Type: ArgumentTypeString}},
+			create: func(b *builtInFuncCreator, gasCost uint64) (vmcommon.BuiltinFunction, error) {
+				return NewSaveUserNameFunc(gasCost, b.mapDNSAddresses, b.mapDNSV2Addresses, b.enableEpochsHandler)
+			},
+		},
+		{
+			name:         deleteUserNameFuncName,
+			gasCostField: "SaveUserName",
+			activation:   changeUsernameActivation,
+			create: func(b *builtInFuncCreator, gasCost uint64) (vmcommon.BuiltinFunction, error) {
+				return NewDeleteUserNameFunc(gasCost, b.mapDNSV2Addresses, b.enableEpochsHandler)
+			},
+		},
+		{
+			name:         core.BuiltInFunctionSaveKeyValue,
+			gasCostField: "SaveKeyValue",
+			arguments: []ArgumentInfo{
+				{Name: "key", Type: ArgumentTypeBytes, Repeated: true},
+				{Name: "value", Type: ArgumentTypeBytes, Repeated: true},
+			},
+			extraGasCostFields: []string{baseOperationCostField("PersistPerByte"), baseOperationCostField("StorePerByte")},
+			create: func(b *builtInFuncCreator, gasCost uint64) (vmcommon.BuiltinFunction, error) {
+				return NewSaveKeyValueStorageFunc(b.gasConfig.BaseOperationCost, gasCost, b.enableEpochsHandler)
+			},
+		},
+		{
+			name:       core.BuiltInFunctionDCTPause,
+			arguments:  []ArgumentInfo{tokenIdentifierArgument},
+			crossShard: true,
+			create: func(b *builtInFuncCreator, _ uint64) (vmcommon.BuiltinFunction, error) {
+				return b.pauseFunc, nil
+			},
+		},
+		{
+			name:       core.BuiltInFunctionSetDCTRole,
+			arguments:  []ArgumentInfo{tokenIdentifierArgument, rolesArgument},
+			crossShard: true,
+			create: func(b *builtInFuncCreator, _ uint64) (vmcommon.BuiltinFunction, error) {
+				return b.setRoleFunc, nil
+			},
+		},
+		{
+			name:         core.BuiltInFunctionDCTTransfer,
+			gasCostField: "DCTTransfer",
+			arguments:    []ArgumentInfo{tokenIdentifierArgument, valueArgument, functionArgument, functionArgsArgument},
+			crossShard:   true,
+			create: func(b *builtInFuncCreator, gasCost uint64) (vmcommon.BuiltinFunction, error) {
+				return NewDCTTransferFunc(gasCost, b.marshaller, b.pauseFunc, b.shardCoordinator, b.setRoleFunc, b.enableEpochsHandler)
+			},
+		},
+		{
+			name:         core.BuiltInFunctionDCTBurn,
+			gasCostField: "DCTBurn",
+			activation:   globalMintBurnActivation,
+			arguments:    []ArgumentInfo{tokenIdentifierArgument, valueArgument},
+			create: func(b *builtInFuncCreator, gasCost uint64) (vmcommon.BuiltinFunction, error) {
+				return NewDCTBurnFunc(gasCost, b.marshaller, b.pauseFunc, b.enableEpochsHandler)
+			},
+		},
+		{
+			name:       core.BuiltInFunctionDCTUnPause,
+			arguments:  []ArgumentInfo{tokenIdentifierArgument},
+			crossShard: true,
+			create: func(b *builtInFuncCreator, _ uint64) (vmcommon.BuiltinFunction, error) {
+				return NewDCTGlobalSettingsFunc(b.accounts, b.marshaller, false, core.BuiltInFunctionDCTUnPause, trueHandler)
+			},
+		},
+		{
+			name:       core.BuiltInFunctionUnSetDCTRole,
+			arguments:  []ArgumentInfo{tokenIdentifierArgument, rolesArgument},
+			crossShard: true,
+			create: func(b *builtInFuncCreator, _ uint64) (vmcommon.BuiltinFunction, error) {
+				return NewDCTRolesFunc(b.marshaller, false)
+			},
+		},
+		{
+			name:          core.BuiltInFunctionDCTLocalBurn,
+			gasCostField:  "DCTLocalBurn",
+			arguments:     []ArgumentInfo{tokenIdentifierArgument, valueArgument},
+			requiredRoles: []string{core.DCTRoleLocalBurn},
+			create: func(b *builtInFuncCreator, gasCost uint64) (vmcommon.BuiltinFunction, error) {
+				return NewDCTLocalBurnFunc(gasCost, b.marshaller, b.pauseFunc, b.setRoleFunc, b.enableEpochsHandler)
+			},
+		},
+		{
+			name:          core.BuiltInFunctionDCTLocalMint,
+			gasCostField:  "DCTLocalMint",
+			arguments:     []ArgumentInfo{tokenIdentifierArgument, valueArgument},
+			requiredRoles: []string{core.DCTRoleLocalMint},
+			create: func(b *builtInFuncCreator, gasCost uint64) (vmcommon.BuiltinFunction, error) {
+				return NewDCTLocalMintFunc(gasCost, b.marshaller, b.pauseFunc, b.setRoleFunc, b.enableEpochsHandler)
+			},
+		},
+		{
+			name:          core.BuiltInFunctionDCTNFTAddQuantity,
+			gasCostField:  "DCTNFTAddQuantity",
+			arguments:     []ArgumentInfo{tokenIdentifierArgument, nonceArgument, quantityArgument},
+			requiredRoles: []string{core.DCTRoleNFTAddQuantity},
+			create: func(b *builtInFuncCreator, gasCost uint64) (vmcommon.BuiltinFunction, error) {
+				return NewDCTNFTAddQuantityFunc(gasCost, b.dctStorageHandler, b.pauseFunc, b.setRoleFunc, b.enableEpochsHandler)
+			},
+		},
+		{
+			name:          core.BuiltInFunctionDCTNFTBurn,
+			gasCostField:  "DCTNFTBurn",
+			arguments:     []ArgumentInfo{tokenIdentifierArgument, nonceArgument, quantityArgument},
+			requiredRoles: []string{core.DCTRoleNFTBurn},
+			create: func(b *builtInFuncCreator, gasCost uint64) (vmcommon.BuiltinFunction, error) {
+				return NewDCTNFTBurnFunc(gasCost, b.dctStorageHandler, b.pauseFunc, b.setRoleFunc)
+			},
+		},
+		{
+			name:         core.BuiltInFunctionDCTNFTCreate,
+			gasCostField: "DCTNFTCreate",
+			arguments: []ArgumentInfo{
+				tokenIdentifierArgument,
+				quantityArgument,
+				{Name: "name", Type: ArgumentTypeString},
+				{Name: "royalties", Type: ArgumentTypeBigUint},
+				{Name: "hash", Type: ArgumentTypeBytes},
+				{Name: "attributes", Type: ArgumentTypeBytes},
+				{Name: "uri", Type: ArgumentTypeString, Repeated: true},
+			},
+			requiredRoles:      []string{core.DCTRoleNFTCreate},
+			extraGasCostFields: []string{baseOperationCostField("StorePerByte")},
+			create: func(b *builtInFuncCreator, gasCost uint64) (vmcommon.BuiltinFunction, error) {
+				return NewDCTNFTCreateFunc(gasCost, b.gasConfig.BaseOperationCost, b.marshaller, b.pauseFunc, b.setRoleFunc, b.dctStorageHandler, b.accounts, b.enableEpochsHandler)
+			},
+		},
+		{
+			name:       core.BuiltInFunctionDCTFreeze,
+			arguments:  []ArgumentInfo{tokenIdentifierArgument},
+			crossShard: true,
+			create: func(b *builtInFuncCreator, _ uint64) (vmcommon.BuiltinFunction, error) {
+				return NewDCTFreezeWipeFunc(b.dctStorageHandler, b.enableEpochsHandler, b.marshaller, true, false)
+			},
+		},
+		{
+			name:       core.BuiltInFunctionDCTUnFreeze,
+			arguments:  []ArgumentInfo{tokenIdentifierArgument},
+			crossShard: true,
+			create: func(b *builtInFuncCreator, _ uint64) (vmcommon.BuiltinFunction, error) {
+				return NewDCTFreezeWipeFunc(b.dctStorageHandler, b.enableEpochsHandler, b.marshaller, false, false)
+			},
+		},
+		{
+			name:       core.BuiltInFunctionDCTWipe,
+			arguments:  []ArgumentInfo{{Name: "tokenIdentifierWithNonce", Type: ArgumentTypeBytes}},
+			crossShard: true,
+			create: func(b *builtInFuncCreator, _ uint64) (vmcommon.BuiltinFunction, error) {
+				return NewDCTFreezeWipeFunc(b.dctStorageHandler, b.enableEpochsHandler, b.marshaller, false, true)
+			},
+		},
+		{
+			name:               core.BuiltInFunctionDCTNFTTransfer,
+			gasCostField:       "DCTNFTTransfer",
+			arguments:          []ArgumentInfo{tokenIdentifierArgument, nonceArgument, quantityArgument, destinationArgument, functionArgument, functionArgsArgument},
+			extraGasCostFields: []string{baseOperationCostField("DataCopyPerByte")},
+			crossShard:         true,
+			create: func(b *builtInFuncCreator, gasCost uint64) (vmcommon.BuiltinFunction, error) {
+				return NewDCTNFTTransferFunc(
+					gasCost,
+					b.marshaller,
+					b.pauseFunc,
+					b.accounts,
+					b.shardCoordinator,
+					b.gasConfig.BaseOperationCost,
+					b.setRoleFunc,
+					b.dctStorageHandler,
+					b.enableEpochsHandler)
+			},
+		},
+		{
+			name:       core.BuiltInFunctionDCTNFTCreateRoleTransfer,
+			arguments:  []ArgumentInfo{tokenIdentifierArgument, {Name: "destinationOrNonce", Type: ArgumentTypeBytes}},
+			crossShard: true,
+			create: func(b *builtInFuncCreator, _ uint64) (vmcommon.BuiltinFunction, error) {
+				return NewDCTNFTCreateRoleTransfer(b.marshaller, b.accounts, b.shardCoordinator)
+			},
+		},
+		{
+			name:               core.BuiltInFunctionDCTNFTUpdateAttributes,
+			gasCostField:       "DCTNFTUpdateAttributes",
+			activation:         nftImprovementV1Activation,
+			arguments:          []ArgumentInfo{tokenIdentifierArgument, nonceArgument, {Name: "attributes", Type: ArgumentTypeBytes}},
+			requiredRoles:      []string{core.DCTRoleNFTUpdateAttributes},
+			extraGasCostFields: []string{baseOperationCostField("StorePerByte")},
+			create: func(b *builtInFuncCreator, gasCost uint64) (vmcommon.BuiltinFunction, error) {
+				return NewDCTNFTUpdateAttributesFunc(gasCost, b.gasConfig.BaseOperationCost, b.dctStorageHandler, b.pauseFunc, b.setRoleFunc, b.enableEpochsHandler)
+			},
+		},
+		{
+			name:               core.BuiltInFunctionDCTNFTAddURI,
+			gasCostField:       "DCTNFTAddURI",
+			activation:         nftImprovementV1Activation,
+			arguments:          []ArgumentInfo{tokenIdentifierArgument, nonceArgument, {Name: "uri", Type: ArgumentTypeString, Repeated: true}},
+			requiredRoles:      []string{core.DCTRoleNFTAddURI},
+			extraGasCostFields: []string{baseOperationCostField("StorePerByte")},
+			create: func(b *builtInFuncCreator, gasCost uint64) (vmcommon.BuiltinFunction, error) {
+				return NewDCTNFTAddUriFunc(gasCost, b.gasConfig.BaseOperationCost, b.dctStorageHandler, b.pauseFunc, b.setRoleFunc, b.enableEpochsHandler)
+			},
+		},
+		{
+			name:         core.BuiltInFunctionMultiDCTNFTTransfer,
+			gasCostField: "DCTNFTMultiTransfer",
+			activation:   nftImprovementV1Activation,
+			arguments: []ArgumentInfo{
+				destinationArgument,
+				{Name: "numTransfers", Type: ArgumentTypeBigUint},
+				{Name: "tokenIdentifier", Type: ArgumentTypeTokenIdentifier, Repeated: true},
+				{Name: "nonce", Type: ArgumentTypeNonce, Repeated: true},
+				{Name: "quantity", Type: ArgumentTypeBigUint, Repeated: true},
+				functionArgument,
+				functionArgsArgument,
+			},
+			extraGasCostFields: []string{baseOperationCostField("DataCopyPerByte")},
+			crossShard:         true,
+			create: func(b *builtInFuncCreator, gasCost uint64) (vmcommon.BuiltinFunction, error) {
+				return NewDCTNFTMultiTransferFunc(
+					gasCost,
+					b.marshaller,
+					b.pauseFunc,
+					b.accounts,
+					b.shardCoordinator,
+					b.gasConfig.BaseOperationCost,
+					b.enableEpochsHandler,
+					b.setRoleFunc,
+					b.dctStorageHandler)
+			},
+		},
+		{
+			name:       core.BuiltInFunctionDCTSetLimitedTransfer,
+			activation: transferRoleActivation,
+			arguments:  []ArgumentInfo{tokenIdentifierArgument},
+			crossShard: true,
+			create: func(b *builtInFuncCreator, _ uint64) (vmcommon.BuiltinFunction, error) {
+				return NewDCTGlobalSettingsFunc(b.accounts, b.marshaller, true, core.BuiltInFunctionDCTSetLimitedTransfer, trueHandler)
+			},
+		},
+		{
+			name:       core.BuiltInFunctionDCTUnSetLimitedTransfer,
+			activation: transferRoleActivation,
+			arguments:  []ArgumentInfo{tokenIdentifierArgument},
+			crossShard: true,
+			create: func(b *builtInFuncCreator, _ uint64) (vmcommon.BuiltinFunction, error) {
+				return NewDCTGlobalSettingsFunc(b.accounts, b.marshaller, false, core.BuiltInFunctionDCTUnSetLimitedTransfer, trueHandler)
+			},
+		},
+		{
+			name:         vmcommon.DCTDeleteMetadata,
+			gasCostField: "DCTNFTBurn",
+			activation:   sendAlwaysActivation,
+			arguments: []ArgumentInfo{
+				{Name: "tokenIdentifier", Type: ArgumentTypeTokenIdentifier, Repeated: true},
+				{Name: "numIntervals", Type: ArgumentTypeBigUint, Repeated: true},
+				{Name: "intervalStart", Type: ArgumentTypeNonce, Repeated: true},
+				{Name: "intervalEnd", Type: ArgumentTypeNonce, Repeated: true},
+			},
+			create: func(b *builtInFuncCreator, gasCost uint64) (vmcommon.BuiltinFunction, error) {
+				return NewDCTDeleteMetadataFunc(b.createDeleteMetadataArgs(gasCost, true))
+			},
+		},
+		{
+			name:         vmcommon.DCTAddMetadata,
+			gasCostField: "DCTNFTBurn",
+			activation:   sendAlwaysActivation,
+			arguments: []ArgumentInfo{
+				{Name: "tokenIdentifier", Type: ArgumentTypeTokenIdentifier, Repeated: true},
+				{Name: "nonce", Type: ArgumentTypeNonce, Repeated: true},
+				{Name: "metadata", Type: ArgumentTypeBytes, Repeated: true},
+			},
+			create: func(b *builtInFuncCreator, gasCost uint64) (vmcommon.BuiltinFunction, error) {
+				return NewDCTDeleteMetadataFunc(b.createDeleteMetadataArgs(gasCost, false))
+			},
+		},
+		{
+			name:       vmcommon.BuiltInFunctionDCTSetBurnRoleForAll,
+			activation: sendAlwaysActivation,
+			arguments:  []ArgumentInfo{tokenIdentifierArgument},
+			crossShard: true,
+			create: func(b *builtInFuncCreator, _ uint64) (vmcommon.BuiltinFunction, error) {
+				return NewDCTGlobalSettingsFunc(b.accounts, b.marshaller, true, vmcommon.BuiltInFunctionDCTSetBurnRoleForAll, trueHandler)
+			},
+		},
+		{
+			name:       vmcommon.BuiltInFunctionDCTUnSetBurnRoleForAll,
+			activation: sendAlwaysActivation,
+			arguments:  []ArgumentInfo{tokenIdentifierArgument},
+			crossShard: true,
+			create: func(b *builtInFuncCreator, _ uint64) (vmcommon.BuiltinFunction, error) {
+				return NewDCTGlobalSettingsFunc(b.accounts, b.marshaller, false, vmcommon.BuiltInFunctionDCTUnSetBurnRoleForAll, trueHandler)
+			},
+		},
+		{
+			name:       vmcommon.BuiltInFunctionDCTTransferRoleDeleteAddress,
+			activation: sendAlwaysActivation,
+			arguments:  []ArgumentInfo{tokenIdentifierArgument, addressesArgument},
+			crossShard: true,
+			create: func(b *builtInFuncCreator, _ uint64) (vmcommon.BuiltinFunction, error) {
+				return NewDCTTransferRoleAddressFunc(b.accounts, b.marshaller, b.maxNumOfAddressesForTransferRole, false, b.enableEpochsHandler)
+			},
+		},
+		{
+			name:       vmcommon.BuiltInFunctionDCTTransferRoleAddAddress,
+			activation: sendAlwaysActivation,
+			arguments:  []ArgumentInfo{tokenIdentifierArgument, addressesArgument},
+			crossShard: true,
+			create: func(b *builtInFuncCreator, _ uint64) (vmcommon.BuiltinFunction, error) {
+				return NewDCTTransferRoleAddressFunc(b.accounts, b.marshaller, b.maxNumOfAddressesForTransferRole, true, b.enableEpochsHandler)
+			},
+		},
+		{
+			name:         core.BuiltInFunctionSetGuardian,
+			gasCostField: "SetGuardian",
+			activation:   setGuardianActivation,
+			arguments:    []ArgumentInfo{{Name: "guardian", Type: ArgumentTypeAddress}, {Name: "serviceUID", Type: ArgumentTypeBytes}},
+			create: func(b *builtInFuncCreator, gasCost uint64) (vmcommon.BuiltinFunction, error) {
+				setGuardianFunc, err := NewSetGuardianFunc(SetGuardianArgs{BaseAccountGuarderArgs: b.createBaseAccountGuarderArgs(gasCost)})
+				if err != nil {
+					return nil, err
+				}
+
+				return NewSetGuardianExecutableChecker(setGuardianFunc)
+			},
+		},
+		{
+			name:         core.BuiltInFunctionGuardAccount,
+			gasCostField: "GuardAccount",
+			activation:   setGuardianActivation,
+			create: func(b *builtInFuncCreator, gasCost uint64) (vmcommon.BuiltinFunction, error) {
+				return NewGuardAccountFunc(GuardAccountArgs{BaseAccountGuarderArgs: b.createBaseAccountGuarderArgs(gasCost)})
+			},
+		},
+		{
+			name:         core.BuiltInFunctionUnGuardAccount,
+			gasCostField: "GuardAccount",
+			activation:   setGuardianActivation,
+			create: func(b *builtInFuncCreator, gasCost uint64) (vmcommon.BuiltinFunction, error) {
+				return NewUnGuardAccountFunc(GuardAccountArgs{BaseAccountGuarderArgs: b.createBaseAccountGuarderArgs(gasCost)})
+			},
+		},
+		{
+			name:               core.BuiltInFunctionMigrateDataTrie,
+			activation:         migrateDataTrieActivation,
+			extraGasCostFields: []string{builtInCostField("TrieLoadPerNode"), builtInCostField("TrieStorePerNode")},
+			create: func(b *builtInFuncCreator, _ uint64) (vmcommon.BuiltinFunction, error) {
+				return NewMigrateDataTrieFunc(b.gasConfig.BuiltInCost, b.enableEpochsHandler, b.accounts)
+			},
+		},
+	}
+}
+
+// addBuiltInFunctions creates the described functions allowed on the current shard, gates them with their
+// activation flags and adds them to the container
+func (b *builtInFuncCreator) addBuiltInFunctions(descriptors []*builtInFunctionDescriptor) error {
+	for _, descriptor := range descriptors {
+		if !descriptor.shards.allows(b.shardCoordinator.SelfId()) {
+			continue
+		}
+
+		gasCost, err := b.builtInGasCost(descriptor.gasCostField)
+		if err != nil {
+			return err
+		}
+		newFunc, err := descriptor.create(b, gasCost)
+		if err != nil {
+			return err
+		}
+		err = b.applyActivationFlag(descriptor, newFunc)
+		if err != nil {
+			return err
+		}
+		err = b.builtInFunctions.Add(descriptor.name, newFunc)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *builtInFuncCreator) builtInGasCost(gasCostField string) (uint64, error) {
+	if len(gasCostField) == 0 {
+		return 0, nil
+	}
+
+	field := reflect.ValueOf(b.gasConfig.BuiltInCost).FieldByName(gasCostField)
+	if !field.IsValid() {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownGasCostField, gasCostField)
+	}
+
+	return field.Uint(), nil
+}
+
+func (b *builtInFuncCreator) applyActivationFlag(descriptor *builtInFunctionDescriptor, builtInFunc vmcommon.BuiltinFunction) error {
+	isEnabled := descriptor.activation.isEnabled
+	if isEnabled == nil {
+		return nil
+	}
+
+	gatedFunc, ok := builtInFunc.(activeGateHandler)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrBuiltInFunctionCannotBeGated, descriptor.name)
+	}
+	gatedFunc.addActiveGate(func() bool {
+		return isEnabled(b.enableEpochsHandler)
+	})
+
+	return nil
+}
+
+func builtInCostField(field string) string {
+	return core.BuiltInCostString + "." + field
+}
+
+func baseOperationCostField(field string) string {
+	return core.BaseOperationCostString + "." + field
+}
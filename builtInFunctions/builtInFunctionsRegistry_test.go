@@ -0,0 +1,151 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+	"github.com/subrahamanyam341/andes-vm-common-123/mock"
+)
+
+func createEnableEpochsHandlerWithAllFlags(enabled bool) *mock.EnableEpochsHandlerStub {
+	handler := &mock.EnableEpochsHandlerStub{}
+	handlerValue := reflect.ValueOf(handler).Elem()
+	for i := 0; i < handlerValue.NumField(); i++ {
+		if handlerValue.Field(i).Kind() == reflect.Bool {
+			handlerValue.Field(i).SetBool(enabled)
+		}
+	}
+
+	return handler
+}
+
+func TestBuiltInFunctionDescriptors(t *testing.T) {
+	t.Parallel()
+
+	names := make(map[string]struct{})
+	for _, descriptor := range builtInFunctionDescriptors() {
+		_, found := names[descriptor.name]
+		assert.False(t, found, "duplicated descriptor %s", descriptor.name)
+		names[descriptor.name] = struct{}{}
+
+		if len(descriptor.gasCostField) > 0 {
+			_, found = reflect.TypeOf(vmcommon.BuiltInCost{}).FieldByName(descriptor.gasCostField)
+			assert.True(t, found, "unknown gas cost field %s of %s", descriptor.gasCostField, descriptor.name)
+		}
+		assert.Equal(t, descriptor.activation.isEnabled == nil, len(descriptor.activation.flag) == 0, descriptor.name)
+	}
+}
+
+func TestBuiltInFunctionDescriptors_ActivationFlags(t *testing.T) {
+	t.Parallel()
+
+	enableEpochsHandler := createEnableEpochsHandlerWithAllFlags(false)
+	args := createMockArguments()
+	args.EnableEpochsHandler = enableEpochsHandler
+	f, _ := NewBuiltInFunctionsCreator(args)
+	err := f.CreateBuiltInFunctionContainer()
+	require.Nil(t, err)
+
+	for _, descriptor := range builtInFunctionDescriptors() {
+		builtInFunc, errGet := f.BuiltInFunctionContainer().Get(descriptor.name)
+		require.Nil(t, errGet)
+		assert.Equal(t, descriptor.activation.isEnabled == nil, builtInFunc.IsActive(), descriptor.name)
+	}
+
+	*enableEpochsHandler = *createEnableEpochsHandlerWithAllFlags(true)
+	for _, descriptor := range builtInFunctionDescriptors() {
+		builtInFunc, _ := f.BuiltInFunctionContainer().Get(descriptor.name)
+		assert.True(t, builtInFunc.IsActive(), descriptor.name)
+	}
+}
+
+func TestBuiltInFuncCreator_AddBuiltInFunctions(t *testing.T) {
+	t.Parallel()
+
+	createStub := func(_ *builtInFuncCreator, _ uint64) (vmcommon.BuiltinFunction, error) {
+		return &mock.BuiltInFunctionStub{}, nil
+	}
+
+	t.Run("shard restrictions should be applied", func(t *testing.T) {
+		t.Parallel()
+
+		descriptors := []*builtInFunctionDescriptor{
+			{name: "all", create: createStub},
+			{name: "shards", shards: regularShardsOnly, create: createStub},
+			{name: "meta", shards: metachainOnly, create: createStub},
+		}
+
+		args := createMockArguments()
+		f, _ := NewBuiltInFunctionsCreator(args)
+		f.builtInFunctions = NewBuiltInFunctionContainer()
+		err := f.addBuiltInFunctions(descriptors)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]struct{}{"all": {}, "shards": {}}, f.builtInFunctions.Keys())
+
+		args.ShardCoordinator = &mock.ShardCoordinatorStub{
+			SelfIdCalled: func() uint32 {
+				return core.MetachainShardId
+			},
+		}
+		f, _ = NewBuiltInFunctionsCreator(args)
+		f.builtInFunctions = NewBuiltInFunctionContainer()
+		err = f.addBuiltInFunctions(descriptors)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]struct{}{"all": {}, "meta": {}}, f.builtInFunctions.Keys())
+	})
+	t.Run("gas cost should be read from the descriptor field", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArguments()
+		args.GasMap[core.BuiltInCostString]["DCTTransfer"] = 37
+		f, _ := NewBuiltInFunctionsCreator(args)
+		f.builtInFunctions = NewBuiltInFunctionContainer()
+
+		var receivedGasCost uint64
+		err := f.addBuiltInFunctions([]*builtInFunctionDescriptor{{
+			name:         "function",
+			gasCostField: "DCTTransfer",
+			create: func(_ *builtInFuncCreator, gasCost uint64) (vmcommon.BuiltinFunction, error) {
+				receivedGasCost = gasCost
+				return &mock.BuiltInFunctionStub{}, nil
+			},
+		}})
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(37), receivedGasCost)
+
+		err = f.addBuiltInFunctions([]*builtInFunctionDescriptor{{name: "unknown", gasCostField: "Unknown", create: createStub}})
+		assert.True(t, errors.Is(err, ErrUnknownGasCostField))
+	})
+	t.Run("function that can not be gated should error", func(t *testing.T) {
+		t.Parallel()
+
+		f, _ := NewBuiltInFunctionsCreator(createMockArguments())
+		f.builtInFunctions = NewBuiltInFunctionContainer()
+		err := f.addBuiltInFunctions([]*builtInFunctionDescriptor{{name: "stub", activation: sendAlwaysActivation, create: createStub}})
+		assert.True(t, errors.Is(err, ErrBuiltInFunctionCannotBeGated))
+	})
+}
+
+func TestBuiltInFuncCreator_KeysOnlyForActiveFunctions(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArguments()
+	args.KeysOnlyForActiveFunctions = true
+	f, _ := NewBuiltInFunctionsCreator(args)
+	err := f.CreateBuiltInFunctionContainer()
+	require.Nil(t, err)
+
+	numAlwaysActive := 0
+	for _, descriptor := range builtInFunctionDescriptors() {
+		if descriptor.activation.isEnabled == nil {
+			numAlwaysActive++
+		}
+	}
+	assert.Equal(t, numAlwaysActive, len(f.BuiltInFunctionContainer().Keys()))
+	assert.Equal(t, 36, f.BuiltInFunctionContainer().Len())
+}
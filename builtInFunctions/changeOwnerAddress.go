@@ -127,6 +127,11 @@ func computeGasRemaining(snd vmcommon.UserAccountHandler, gasProvided uint64, ga
 	return gasProvided - gasToUse
 }
 
+// CheckIsExecutable checks if the change owner address can be executed, without changing the accounts
+func (c *changeOwnerAddress) CheckIsExecutable(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) error {
+	return checkIsExecutableOnDryRunAccounts(c, acntSnd, acntDst, vmInput)
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (c *changeOwnerAddress) IsInterfaceNil() bool {
 	return c == nil
@@ -104,6 +104,11 @@ func (c *claimDeveloperRewards) ProcessBuiltinFunction(
 	return vmOutput, nil
 }
 
+// CheckIsExecutable checks if the claim developer rewards can be executed, without changing the accounts
+func (c *claimDeveloperRewards) CheckIsExecutable(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) error {
+	return checkIsExecutableOnDryRunAccounts(c, acntSnd, acntDst, vmInput)
+}
+
 // IsInterfaceNil returns true if underlying object is nil
 func (c *claimDeveloperRewards) IsInterfaceNil() bool {
 	return c == nil
@@ -0,0 +1,86 @@
+package builtInFunctions
+
+import (
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+)
+
+// ScheduleConflictFreeBatches groups the built-in function calls into batches meant to be executed one after the
+// other, the calls of the same batch being free of conflicts, so they can be executed in parallel. Each call is
+// placed in the first batch after the last one holding a conflicting call, so the conflicting calls keep their
+// order. A call without a read/write set, as for the unsupported functions, is placed alone in a new batch, which
+// also holds back all the calls that follow it. The same goes for the calls of the storage traced functions, as
+// the traces of a container created with TraceStorage are not kept per call.
+func ScheduleConflictFreeBatches(
+	inputs []*vmcommon.ContractCallInput,
+	container vmcommon.BuiltInFunctionContainer,
+) ([][]*vmcommon.ContractCallInput, error) {
+	if check.IfNil(container) {
+		return nil, ErrNilBuiltInFunctionsContainer
+	}
+
+	batches := make([][]*vmcommon.ContractCallInput, 0)
+	lastReadBatch := make(map[string]int)
+	lastWriteBatch := make(map[string]int)
+	firstAllowedBatch := 0
+
+	for _, input := range inputs {
+		set, err := ComputeReadWriteSet(input)
+		if err != nil || isStorageTraced(container, input) {
+			batches = append(batches, []*vmcommon.ContractCallInput{input})
+			firstAllowedBatch = len(batches)
+			continue
+		}
+
+		batchIndex := firstAllowedBatch
+		for _, location := range set.Reads {
+			batchIndex = afterBatch(batchIndex, lastWriteBatch, location)
+		}
+		for _, location := range set.Writes {
+			batchIndex = afterBatch(batchIndex, lastWriteBatch, location)
+			batchIndex = afterBatch(batchIndex, lastReadBatch, location)
+		}
+
+		if batchIndex == len(batches) {
+			batches = append(batches, make([]*vmcommon.ContractCallInput, 0))
+		}
+		batches[batchIndex] = append(batches[batchIndex], input)
+		for _, location := range set.Reads {
+			updateLastBatch(lastReadBatch, location, batchIndex)
+		}
+		for _, location := range set.Writes {
+			updateLastBatch(lastWriteBatch, location, batchIndex)
+		}
+	}
+
+	return batches, nil
+}
+
+func isStorageTraced(container vmcommon.BuiltInFunctionContainer, input *vmcommon.ContractCallInput) bool {
+	if input == nil {
+		return false
+	}
+	function, err := container.Get(input.Function)
+	if err != nil {
+		return false
+	}
+	checker, ok := function.(storageTracedChecker)
+
+	return ok && checker.IsStorageTraced()
+}
+
+func afterBatch(batchIndex int, lastBatch map[string]int, location StorageLocation) int {
+	index, found := lastBatch[location.id()]
+	if found && index >= batchIndex {
+		return index + 1
+	}
+
+	return batchIndex
+}
+
+func updateLastBatch(lastBatch map[string]int, location StorageLocation, batchIndex int) {
+	index, found := lastBatch[location.id()]
+	if !found || index < batchIndex {
+		lastBatch[location.id()] = batchIndex
+	}
+}
@@ -0,0 +1,129 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+	"github.com/subrahamanyam341/andes-vm-common-123/mock"
+)
+
+func TestScheduleConflictFreeBatches(t *testing.T) {
+	t.Parallel()
+
+	alice := bytes.Repeat([]byte{1}, 32)
+	bob := bytes.Repeat([]byte{2}, 32)
+	carol := bytes.Repeat([]byte{3}, 32)
+	dave := bytes.Repeat([]byte{4}, 32)
+	transfer := func(sender []byte, receiver []byte, tokenID string) *vmcommon.ContractCallInput {
+		return createReadWriteSetInput(core.BuiltInFunctionDCTTransfer, sender, receiver, []byte(tokenID), []byte{1})
+	}
+	nftTransfer := func(sender []byte, receiver []byte, nonce byte) *vmcommon.ContractCallInput {
+		return createReadWriteSetInput(core.BuiltInFunctionDCTNFTTransfer, sender, sender, []byte("NFT-abcdef"), []byte{nonce}, []byte{1}, receiver)
+	}
+
+	t.Run("nil container should error", func(t *testing.T) {
+		t.Parallel()
+
+		batches, err := ScheduleConflictFreeBatches(nil, nil)
+		assert.Nil(t, batches)
+		assert.Equal(t, ErrNilBuiltInFunctionsContainer, err)
+	})
+	t.Run("empty input", func(t *testing.T) {
+		t.Parallel()
+
+		batches, err := ScheduleConflictFreeBatches(nil, NewBuiltInFunctionContainer())
+		assert.Nil(t, err)
+		assert.Empty(t, batches)
+	})
+	t.Run("independent transfers should share a batch", func(t *testing.T) {
+		t.Parallel()
+
+		inputs := []*vmcommon.ContractCallInput{
+			transfer(alice, bob, "TOKEN-abcdef"),
+			transfer(carol, dave, "TOKEN-abcdef"),
+			transfer(alice, carol, "OTHER-abcdef"),
+		}
+		batches, err := ScheduleConflictFreeBatches(inputs, NewBuiltInFunctionContainer())
+		require.Nil(t, err)
+		assert.Equal(t, [][]*vmcommon.ContractCallInput{inputs}, batches)
+	})
+	t.Run("conflicting transfers should keep their order", func(t *testing.T) {
+		t.Parallel()
+
+		inputs := []*vmcommon.ContractCallInput{
+			transfer(alice, bob, "TOKEN-abcdef"),
+			transfer(bob, carol, "TOKEN-abcdef"),
+			transfer(carol, dave, "OTHER-abcdef"),
+			transfer(carol, dave, "TOKEN-abcdef"),
+		}
+		batches, err := ScheduleConflictFreeBatches(inputs, NewBuiltInFunctionContainer())
+		require.Nil(t, err)
+		expected := [][]*vmcommon.ContractCallInput{
+			{inputs[0], inputs[2]},
+			{inputs[1]},
+			{inputs[3]},
+		}
+		assert.Equal(t, expected, batches)
+	})
+	t.Run("NFT transfers should conflict on the metadata and on the collection", func(t *testing.T) {
+		t.Parallel()
+
+		inputs := []*vmcommon.ContractCallInput{
+			nftTransfer(alice, bob, 1),
+			nftTransfer(carol, dave, 1),
+			nftTransfer(carol, dave, 2),
+			transfer(alice, bob, "NFT-abcdef"),
+		}
+		batches, err := ScheduleConflictFreeBatches(inputs, NewBuiltInFunctionContainer())
+		require.Nil(t, err)
+		expected := [][]*vmcommon.ContractCallInput{
+			{inputs[0], inputs[2]},
+			{inputs[1], inputs[3]},
+		}
+		assert.Equal(t, expected, batches)
+	})
+	t.Run("unsupported calls should be barriers", func(t *testing.T) {
+		t.Parallel()
+
+		unsupported := createReadWriteSetInput(core.BuiltInFunctionDCTLocalMint, alice, alice)
+		inputs := []*vmcommon.ContractCallInput{
+			transfer(alice, bob, "TOKEN-abcdef"),
+			transfer(bob, carol, "TOKEN-abcdef"),
+			unsupported,
+			transfer(carol, dave, "OTHER-abcdef"),
+			nil,
+		}
+		batches, err := ScheduleConflictFreeBatches(inputs, NewBuiltInFunctionContainer())
+		require.Nil(t, err)
+		require.Equal(t, 5, len(batches))
+		expected := [][]*vmcommon.ContractCallInput{
+			{inputs[0]},
+			{inputs[1]},
+			{unsupported},
+			{inputs[3]},
+			{nil},
+		}
+		assert.Equal(t, expected, batches)
+	})
+	t.Run("storage traced calls should be barriers", func(t *testing.T) {
+		t.Parallel()
+
+		tracer, _ := NewTracingAccountsAdapter(&mock.AccountsStub{})
+		traced, _ := NewTracedBuiltInFunction(core.BuiltInFunctionDCTTransfer, &mock.BuiltInFunctionStub{}, tracer)
+		instrumented, _ := NewInstrumentedBuiltInFunction(core.BuiltInFunctionDCTTransfer, traced, &builtInFunctionMetricsHandlerStub{})
+		container := NewBuiltInFunctionContainer()
+		_ = container.Add(core.BuiltInFunctionDCTTransfer, instrumented)
+
+		inputs := []*vmcommon.ContractCallInput{
+			transfer(alice, bob, "TOKEN-abcdef"),
+			transfer(carol, dave, "OTHER-abcdef"),
+		}
+		batches, err := ScheduleConflictFreeBatches(inputs, container)
+		require.Nil(t, err)
+		assert.Equal(t, [][]*vmcommon.ContractCallInput{{inputs[0]}, {inputs[1]}}, batches)
+	})
+}
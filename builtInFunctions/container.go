@@ -12,7 +12,8 @@ var _ vmcommon.BuiltInFunctionContainer = (*functionContainer)(nil)
 
 // functionContainer is an interceptors holder organized by type
 type functionContainer struct {
-	objects *container.MutexMap
+	objects           *container.MutexMap
+	keysOnlyForActive bool
 }
 
 // NewBuiltInFunctionContainer will create a new instance of a container
@@ -22,6 +23,15 @@ func NewBuiltInFunctionContainer() *functionContainer {
 	}
 }
 
+// NewActiveKeysBuiltInFunctionContainer will create a new instance of a container whose Keys method lists only the
+// functions that are currently active. The inactive functions can still be fetched with Get.
+func NewActiveKeysBuiltInFunctionContainer() *functionContainer {
+	return &functionContainer{
+		objects:           container.NewMutexMap(),
+		keysOnlyForActive: true,
+	}
+}
+
 // Get returns the object stored at a certain key.
 // Returns an error if the element does not exist
 func (f *functionContainer) Get(key string) (vmcommon.BuiltinFunction, error) {
@@ -79,8 +89,26 @@ func (f *functionContainer) Len() int {
 	return f.objects.Len()
 }
 
-// Keys returns all the keys in the containers
+// Keys returns all the keys in the containers, or only the keys of the active functions if the container was
+// created with NewActiveKeysBuiltInFunctionContainer
 func (f *functionContainer) Keys() map[string]struct{} {
+	if !f.keysOnlyForActive {
+		return f.AllKeys()
+	}
+
+	keys := f.AllKeys()
+	for key := range keys {
+		function, err := f.Get(key)
+		if err != nil || !function.IsActive() {
+			delete(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// AllKeys returns all the keys in the containers, of both active and inactive functions
+func (f *functionContainer) AllKeys() map[string]struct{} {
 	keys := make(map[string]struct{}, f.Len())
 
 	for _, key := range f.objects.Keys() {
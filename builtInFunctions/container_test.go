@@ -156,3 +156,30 @@ func TestBuiltInFunctionContainer_LenShouldWork(t *testing.T) {
 	c.Remove("key1")
 	assert.Equal(t, 1, c.Len())
 }
+
+//------- Keys
+
+func TestBuiltInFunctionContainer_KeysOnlyForActive(t *testing.T) {
+	t.Parallel()
+
+	isActive := false
+	active := &mock.BuiltInFunctionStub{IsActiveCalled: func() bool { return true }}
+	gated := &mock.BuiltInFunctionStub{IsActiveCalled: func() bool { return isActive }}
+
+	c := NewBuiltInFunctionContainer()
+	activeKeysContainer := NewActiveKeysBuiltInFunctionContainer()
+	for _, fc := range []*functionContainer{c, activeKeysContainer} {
+		_ = fc.Add("active", active)
+		_ = fc.Add("gated", gated)
+	}
+
+	allKeys := map[string]struct{}{"active": {}, "gated": {}}
+	assert.Equal(t, allKeys, c.Keys())
+	assert.Equal(t, allKeys, activeKeysContainer.AllKeys())
+	assert.Equal(t, map[string]struct{}{"active": {}}, activeKeysContainer.Keys())
+	_, err := activeKeysContainer.Get("gated")
+	assert.Nil(t, err)
+
+	isActive = true
+	assert.Equal(t, allKeys, activeKeysContainer.Keys())
+}
@@ -1,10 +1,12 @@
 package builtInFunctions
 
 import (
-	"github.com/mitchellh/mapstructure"
+	"fmt"
+
 	"github.com/subrahamanyam341/andes-core-16/core"
 	"github.com/subrahamanyam341/andes-core-16/core/check"
 	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+	"github.com/subrahamanyam341/andes-vm-common-123/gasSchedule"
 )
 
 var _ vmcommon.BuiltInFunctionFactory = (*builtInFuncCreator)(nil)
@@ -27,6 +29,17 @@ type ArgsCreateBuiltInFunctionContainer struct {
 	GuardedAccountHandler            vmcommon.GuardedAccountHandler
 	MaxNumOfAddressesForTransferRole uint32
 	ConfigAddress                    []byte
+	// RoundActivations gates the named built-in functions until the activation round. RoundNotifier is needed only
+	// when RoundActivations is not empty.
+	RoundActivations map[string]uint64
+	RoundNotifier    vmcommon.RoundNotifier
+	// KeysOnlyForActiveFunctions makes the Keys method of the container list only the active functions
+	KeysOnlyForActiveFunctions bool
+	// MetricsHandler, if set, receives the measurements of all the built-in function calls
+	MetricsHandler BuiltInFunctionMetricsHandler
+	// TraceStorage attaches to the output of each built-in function call the storage accesses it made. The calls must
+	// then be processed sequentially, as the traces are shared by all of them.
+	TraceStorage bool
 }
 
 type builtInFuncCreator struct {
@@ -44,6 +57,12 @@ type builtInFuncCreator struct {
 	guardedAccountHandler            vmcommon.GuardedAccountHandler
 	maxNumOfAddressesForTransferRole uint32
 	configAddress                    []byte
+	roundActivationHandlers          map[string]*roundActivationHandler
+	keysOnlyForActiveFunctions       bool
+	metricsHandler                   BuiltInFunctionMetricsHandler
+	storageTracer                    StorageTracer
+	pauseFunc                        *dctGlobalSettings
+	setRoleFunc                      *dctRoles
 }
 
 // NewBuiltInFunctionsCreator creates a component which will instantiate the built in functions contracts
@@ -81,34 +100,73 @@ func NewBuiltInFunctionsCreator(args ArgsCreateBuiltInFunctionContainer) (*built
 		guardedAccountHandler:            args.GuardedAccountHandler,
 		maxNumOfAddressesForTransferRole: args.MaxNumOfAddressesForTransferRole,
 		configAddress:                    args.ConfigAddress,
+		keysOnlyForActiveFunctions:       args.KeysOnlyForActiveFunctions,
+		metricsHandler:                   args.MetricsHandler,
 	}
 
 	var err error
+	if args.TraceStorage {
+		tracingAccounts, errTracing := NewTracingAccountsAdapter(args.Accounts)
+		if errTracing != nil {
+			return nil, errTracing
+		}
+		b.accounts = tracingAccounts
+		b.storageTracer = tracingAccounts
+	}
 	b.gasConfig, err = createGasConfig(args.GasMap)
 	if err != nil {
 		return nil, err
 	}
+	b.roundActivationHandlers, err = createRoundActivationHandlers(args.RoundActivations, args.RoundNotifier)
+	if err != nil {
+		return nil, err
+	}
 	b.builtInFunctions = NewBuiltInFunctionContainer()
 
 	return b, nil
 }
 
-// GasScheduleChange is called when gas schedule is changed, thus all contracts must be updated
+// GasScheduleChange is called when gas schedule is changed, thus all contracts must be updated. An invalid gas
+// schedule is not applied; the subscriber created by NewGasScheduleSubscriber also returns the error.
 func (b *builtInFuncCreator) GasScheduleChange(gasSchedule map[string]map[string]uint64) {
+	err := b.applyGasSchedule(gasSchedule)
+	if err != nil {
+		log.Warn("builtInFuncCreator.GasScheduleChange: gas schedule not applied", "error", err.Error())
+	}
+}
+
+// applyGasSchedule validates the gas schedule before any function is updated
+func (b *builtInFuncCreator) applyGasSchedule(gasSchedule map[string]map[string]uint64) error {
 	newGasConfig, err := createGasConfig(gasSchedule)
 	if err != nil {
-		return
+		return err
 	}
 
-	b.gasConfig = newGasConfig
-	for key := range b.builtInFunctions.Keys() {
+	functions := make([]vmcommon.BuiltinFunction, 0, b.builtInFunctions.Len())
+	for key := range allKeys(b.builtInFunctions) {
 		builtInFunc, errGet := b.builtInFunctions.Get(key)
 		if errGet != nil {
-			return
+			return errGet
 		}
+		functions = append(functions, builtInFunc)
+	}
 
+	b.gasConfig = newGasConfig
+	for _, builtInFunc := range functions {
 		builtInFunc.SetNewGasConfig(b.gasConfig)
 	}
+
+	return nil
+}
+
+// allKeys returns the keys of all the functions in the container, including the inactive ones
+func allKeys(builtInFunctions vmcommon.BuiltInFunctionContainer) map[string]struct{} {
+	allKeysContainer, ok := builtInFunctions.(interface{ AllKeys() map[string]struct{} })
+	if ok {
+		return allKeysContainer.AllKeys()
+	}
+
+	return builtInFunctions.Keys()
 }
 
 // NFTStorageHandler will return the dct storage handler from the built in functions factory
@@ -128,377 +186,133 @@ func (b *builtInFuncCreator) BuiltInFunctionContainer() vmcommon.BuiltInFunction
 
 // CreateBuiltInFunctionContainer will create the list of built-in functions
 func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
-
-	b.builtInFunctions = NewBuiltInFunctionContainer()
-	var newFunc vmcommon.BuiltinFunction
-	newFunc = NewClaimDeveloperRewardsFunc(b.gasConfig.BuiltInCost.ClaimDeveloperRewards)
-	err := b.builtInFunctions.Add(core.BuiltInFunctionClaimDeveloperRewards, newFunc)
-	if err != nil {
-		return err
-	}
-
-	newFunc, err = NewChangeOwnerAddressFunc(b.gasConfig.BuiltInCost.ChangeOwnerAddress, b.enableEpochsHandler)
-	if err != nil {
-		return err
-	}
-
-	err = b.builtInFunctions.Add(core.BuiltInFunctionChangeOwnerAddress, newFunc)
-	if err != nil {
-		return err
-	}
-
-	newFunc, err = NewSaveUserNameFunc(b.gasConfig.BuiltInCost.SaveUserName, b.mapDNSAddresses, b.mapDNSV2Addresses, b.enableEpochsHandler)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionSetUserName, newFunc)
-	if err != nil {
-		return err
-	}
-
-	newFunc, err = NewDeleteUserNameFunc(b.gasConfig.BuiltInCost.SaveUserName, b.mapDNSV2Addresses, b.enableEpochsHandler)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(deleteUserNameFuncName, newFunc)
-	if err != nil {
-		return err
-	}
-
-	newFunc, err = NewSaveKeyValueStorageFunc(b.gasConfig.BaseOperationCost, b.gasConfig.BuiltInCost.SaveKeyValue, b.enableEpochsHandler)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionSaveKeyValue, newFunc)
-	if err != nil {
-		return err
-	}
-
-	globalSettingsFunc, err := NewDCTGlobalSettingsFunc(b.accounts, b.marshaller, true, core.BuiltInFunctionDCTPause, trueHandler)
+	err := b.createSharedComponents()
 	if err != nil {
 		return err
 	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTPause, globalSettingsFunc)
-	if err != nil {
-		return err
-	}
-	b.dctGlobalSettingsHandler = globalSettingsFunc
 
-	setRoleFunc, err := NewDCTRolesFunc(b.marshaller, true)
+	b.builtInFunctions = b.newBuiltInFunctionContainer()
+	err = b.addBuiltInFunctions(builtInFunctionDescriptors())
 	if err != nil {
 		return err
 	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionSetDCTRole, setRoleFunc)
+	err = b.gateFunctionsByRound()
 	if err != nil {
 		return err
 	}
-
-	newFunc, err = NewDCTTransferFunc(
-		b.gasConfig.BuiltInCost.DCTTransfer,
-		b.marshaller,
-		globalSettingsFunc,
-		b.shardCoordinator,
-		setRoleFunc,
-		b.enableEpochsHandler)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTTransfer, newFunc)
+	err = b.traceFunctions()
 	if err != nil {
 		return err
 	}
 
-	newFunc, err = NewDCTBurnFunc(b.gasConfig.BuiltInCost.DCTBurn, b.marshaller, globalSettingsFunc, b.enableEpochsHandler)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTBurn, newFunc)
-	if err != nil {
-		return err
-	}
+	return b.instrumentFunctions()
+}
 
-	newFunc, err = NewDCTGlobalSettingsFunc(b.accounts, b.marshaller, false, core.BuiltInFunctionDCTUnPause, trueHandler)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTUnPause, newFunc)
-	if err != nil {
-		return err
+func (b *builtInFuncCreator) newBuiltInFunctionContainer() vmcommon.BuiltInFunctionContainer {
+	if b.keysOnlyForActiveFunctions {
+		return NewActiveKeysBuiltInFunctionContainer()
 	}
 
-	newFunc, err = NewDCTRolesFunc(b.marshaller, false)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionUnSetDCTRole, newFunc)
-	if err != nil {
-		return err
-	}
+	return NewBuiltInFunctionContainer()
+}
 
-	newFunc, err = NewDCTLocalBurnFunc(b.gasConfig.BuiltInCost.DCTLocalBurn, b.marshaller, globalSettingsFunc, setRoleFunc, b.enableEpochsHandler)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTLocalBurn, newFunc)
+// createSharedComponents creates the components used by more built-in functions: the pause function, which is the
+// global settings handler, the set role function, which is the roles handler, and the DCT storage handler
+func (b *builtInFuncCreator) createSharedComponents() error {
+	var err error
+	b.pauseFunc, err = NewDCTGlobalSettingsFunc(b.accounts, b.marshaller, true, core.BuiltInFunctionDCTPause, trueHandler)
 	if err != nil {
 		return err
 	}
+	b.dctGlobalSettingsHandler = b.pauseFunc
 
-	newFunc, err = NewDCTLocalMintFunc(b.gasConfig.BuiltInCost.DCTLocalMint, b.marshaller, globalSettingsFunc, setRoleFunc, b.enableEpochsHandler)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTLocalMint, newFunc)
+	b.setRoleFunc, err = NewDCTRolesFunc(b.marshaller, true)
 	if err != nil {
 		return err
 	}
 
 	args := ArgsNewDCTDataStorage{
 		Accounts:              b.accounts,
-		GlobalSettingsHandler: globalSettingsFunc,
+		GlobalSettingsHandler: b.pauseFunc,
 		Marshalizer:           b.marshaller,
 		EnableEpochsHandler:   b.enableEpochsHandler,
 		ShardCoordinator:      b.shardCoordinator,
 	}
 	b.dctStorageHandler, err = NewDCTDataStorage(args)
-	if err != nil {
-		return err
-	}
-
-	newFunc, err = NewDCTNFTAddQuantityFunc(b.gasConfig.BuiltInCost.DCTNFTAddQuantity, b.dctStorageHandler, globalSettingsFunc, setRoleFunc, b.enableEpochsHandler)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTNFTAddQuantity, newFunc)
-	if err != nil {
-		return err
-	}
-
-	newFunc, err = NewDCTNFTBurnFunc(b.gasConfig.BuiltInCost.DCTNFTBurn, b.dctStorageHandler, globalSettingsFunc, setRoleFunc)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTNFTBurn, newFunc)
-	if err != nil {
-		return err
-	}
-
-	newFunc, err = NewDCTNFTCreateFunc(b.gasConfig.BuiltInCost.DCTNFTCreate, b.gasConfig.BaseOperationCost, b.marshaller, globalSettingsFunc, setRoleFunc, b.dctStorageHandler, b.accounts, b.enableEpochsHandler)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTNFTCreate, newFunc)
-	if err != nil {
-		return err
-	}
-
-	newFunc, err = NewDCTFreezeWipeFunc(b.dctStorageHandler, b.enableEpochsHandler, b.marshaller, true, false)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTFreeze, newFunc)
-	if err != nil {
-		return err
-	}
-
-	newFunc, err = NewDCTFreezeWipeFunc(b.dctStorageHandler, b.enableEpochsHandler, b.marshaller, false, false)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTUnFreeze, newFunc)
-	if err != nil {
-		return err
-	}
 
-	newFunc, err = NewDCTFreezeWipeFunc(b.dctStorageHandler, b.enableEpochsHandler, b.marshaller, false, true)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTWipe, newFunc)
-	if err != nil {
-		return err
-	}
-
-	newFunc, err = NewDCTNFTTransferFunc(b.gasConfig.BuiltInCost.DCTNFTTransfer,
-		b.marshaller,
-		globalSettingsFunc,
-		b.accounts,
-		b.shardCoordinator,
-		b.gasConfig.BaseOperationCost,
-		setRoleFunc,
-		b.dctStorageHandler,
-		b.enableEpochsHandler)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTNFTTransfer, newFunc)
-	if err != nil {
-		return err
-	}
-
-	newFunc, err = NewDCTNFTCreateRoleTransfer(b.marshaller, b.accounts, b.shardCoordinator)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTNFTCreateRoleTransfer, newFunc)
-	if err != nil {
-		return err
-	}
-
-	newFunc, err = NewDCTNFTUpdateAttributesFunc(b.gasConfig.BuiltInCost.DCTNFTUpdateAttributes, b.gasConfig.BaseOperationCost, b.dctStorageHandler, globalSettingsFunc, setRoleFunc, b.enableEpochsHandler)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTNFTUpdateAttributes, newFunc)
-	if err != nil {
-		return err
-	}
-
-	newFunc, err = NewDCTNFTAddUriFunc(b.gasConfig.BuiltInCost.DCTNFTAddURI, b.gasConfig.BaseOperationCost, b.dctStorageHandler, globalSettingsFunc, setRoleFunc, b.enableEpochsHandler)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTNFTAddURI, newFunc)
-	if err != nil {
-		return err
-	}
-
-	newFunc, err = NewDCTNFTMultiTransferFunc(b.gasConfig.BuiltInCost.DCTNFTMultiTransfer,
-		b.marshaller,
-		globalSettingsFunc,
-		b.accounts,
-		b.shardCoordinator,
-		b.gasConfig.BaseOperationCost,
-		b.enableEpochsHandler,
-		setRoleFunc,
-		b.dctStorageHandler)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionMultiDCTNFTTransfer, newFunc)
-	if err != nil {
-		return err
-	}
-
-	newFunc, err = NewDCTGlobalSettingsFunc(b.accounts, b.marshaller, true, core.BuiltInFunctionDCTSetLimitedTransfer, b.enableEpochsHandler.IsDCTTransferRoleFlagEnabled)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTSetLimitedTransfer, newFunc)
-	if err != nil {
-		return err
-	}
-
-	newFunc, err = NewDCTGlobalSettingsFunc(b.accounts, b.marshaller, false, core.BuiltInFunctionDCTUnSetLimitedTransfer, b.enableEpochsHandler.IsDCTTransferRoleFlagEnabled)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTUnSetLimitedTransfer, newFunc)
-	if err != nil {
-		return err
-	}
+	return err
+}
 
-	argsNewDeleteFunc := ArgsNewDCTDeleteMetadata{
-		FuncGasCost:         b.gasConfig.BuiltInCost.DCTNFTBurn,
-		Marshalizer:         b.marshaller,
-		Accounts:            b.accounts,
-		AllowedAddress:      b.configAddress,
-		Delete:              true,
-		EnableEpochsHandler: b.enableEpochsHandler,
-	}
-	newFunc, err = NewDCTDeleteMetadataFunc(argsNewDeleteFunc)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(vmcommon.DCTDeleteMetadata, newFunc)
-	if err != nil {
-		return err
+func createRoundActivationHandlers(roundActivations map[string]uint64, roundNotifier vmcommon.RoundNotifier) (map[string]*roundActivationHandler, error) {
+	handlers := make(map[string]*roundActivationHandler, len(roundActivations))
+	for name, activationRound := range roundActivations {
+		handler, err := NewRoundActivationHandler(activationRound, roundNotifier)
+		if err != nil {
+			return nil, err
+		}
+		handlers[name] = handler
 	}
 
-	argsNewDeleteFunc.Delete = false
-	newFunc, err = NewDCTDeleteMetadataFunc(argsNewDeleteFunc)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(vmcommon.DCTAddMetadata, newFunc)
-	if err != nil {
-		return err
-	}
+	return handlers, nil
+}
 
-	newFunc, err = NewDCTGlobalSettingsFunc(b.accounts, b.marshaller, true, vmcommon.BuiltInFunctionDCTSetBurnRoleForAll, b.enableEpochsHandler.IsSendAlwaysFlagEnabled)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTSetBurnRoleForAll, newFunc)
-	if err != nil {
-		return err
-	}
+func (b *builtInFuncCreator) gateFunctionsByRound() error {
+	for name, handler := range b.roundActivationHandlers {
+		builtInFunc, err := b.builtInFunctions.Get(name)
+		if err != nil {
+			return err
+		}
 
-	newFunc, err = NewDCTGlobalSettingsFunc(b.accounts, b.marshaller, false, vmcommon.BuiltInFunctionDCTUnSetBurnRoleForAll, b.enableEpochsHandler.IsSendAlwaysFlagEnabled)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTUnSetBurnRoleForAll, newFunc)
-	if err != nil {
-		return err
+		gatedFunc, ok := builtInFunc.(activeGateHandler)
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrBuiltInFunctionCannotBeGated, name)
+		}
+		gatedFunc.addActiveGate(handler.IsActive)
 	}
 
-	newFunc, err = NewDCTTransferRoleAddressFunc(b.accounts, b.marshaller, b.maxNumOfAddressesForTransferRole, false, b.enableEpochsHandler)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTTransferRoleDeleteAddress, newFunc)
-	if err != nil {
-		return err
-	}
+	return nil
+}
 
-	newFunc, err = NewDCTTransferRoleAddressFunc(b.accounts, b.marshaller, b.maxNumOfAddressesForTransferRole, true, b.enableEpochsHandler)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTTransferRoleAddAddress, newFunc)
-	if err != nil {
-		return err
+// traceFunctions wraps all the functions in the container, if the storage tracing was enabled. It must be called
+// after the functions are gated, as the wrapped functions can not be gated anymore.
+func (b *builtInFuncCreator) traceFunctions() error {
+	if check.IfNil(b.storageTracer) {
+		return nil
 	}
 
-	argsSetGuardian := SetGuardianArgs{
-		BaseAccountGuarderArgs: b.createBaseAccountGuarderArgs(b.gasConfig.BuiltInCost.SetGuardian),
-	}
-	newFunc, err = NewSetGuardianFunc(argsSetGuardian)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionSetGuardian, newFunc)
-	if err != nil {
-		return err
-	}
+	return b.wrapFunctions(func(name string, builtInFunc vmcommon.BuiltinFunction) (vmcommon.BuiltinFunction, error) {
+		return NewTracedBuiltInFunction(name, builtInFunc, b.storageTracer)
+	})
+}
 
-	argsGuardAccount := b.createGuardAccountArgs()
-	newFunc, err = NewGuardAccountFunc(argsGuardAccount)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionGuardAccount, newFunc)
-	if err != nil {
-		return err
+// instrumentFunctions wraps all the functions in the container, if a metrics handler was provided. It must be called
+// last, so that the measurements include the other wrappers.
+func (b *builtInFuncCreator) instrumentFunctions() error {
+	if check.IfNil(b.metricsHandler) {
+		return nil
 	}
 
-	newFunc, err = NewUnGuardAccountFunc(argsGuardAccount)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionUnGuardAccount, newFunc)
-	if err != nil {
-		return err
-	}
+	return b.wrapFunctions(func(name string, builtInFunc vmcommon.BuiltinFunction) (vmcommon.BuiltinFunction, error) {
+		return NewInstrumentedBuiltInFunction(name, builtInFunc, b.metricsHandler)
+	})
+}
 
-	newFunc, err = NewMigrateDataTrieFunc(b.gasConfig.BuiltInCost, b.enableEpochsHandler, b.accounts)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionMigrateDataTrie, newFunc)
-	if err != nil {
-		return err
+func (b *builtInFuncCreator) wrapFunctions(
+	wrap func(name string, builtInFunc vmcommon.BuiltinFunction) (vmcommon.BuiltinFunction, error),
+) error {
+	for name := range allKeys(b.builtInFunctions) {
+		builtInFunc, err := b.builtInFunctions.Get(name)
+		if err != nil {
+			return err
+		}
+		wrappedFunc, err := wrap(name, builtInFunc)
+		if err != nil {
+			return err
+		}
+		err = b.builtInFunctions.Replace(name, wrappedFunc)
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -513,41 +327,19 @@ func (b *builtInFuncCreator) createBaseAccountGuarderArgs(funcGasCost uint64) Ba
 	}
 }
 
-func (b *builtInFuncCreator) createGuardAccountArgs() GuardAccountArgs {
-	return GuardAccountArgs{
-		BaseAccountGuarderArgs: b.createBaseAccountGuarderArgs(b.gasConfig.BuiltInCost.GuardAccount),
+func (b *builtInFuncCreator) createDeleteMetadataArgs(funcGasCost uint64, isDelete bool) ArgsNewDCTDeleteMetadata {
+	return ArgsNewDCTDeleteMetadata{
+		FuncGasCost:         funcGasCost,
+		Marshalizer:         b.marshaller,
+		Accounts:            b.accounts,
+		AllowedAddress:      b.configAddress,
+		Delete:              isDelete,
+		EnableEpochsHandler: b.enableEpochsHandler,
 	}
 }
 
 func createGasConfig(gasMap map[string]map[string]uint64) (*vmcommon.GasCost, error) {
-	baseOps := &vmcommon.BaseOperationCost{}
-	err := mapstructure.Decode(gasMap[core.BaseOperationCostString], baseOps)
-	if err != nil {
-		return nil, err
-	}
-
-	err = check.ForZeroUintFields(*baseOps)
-	if err != nil {
-		return nil, err
-	}
-
-	builtInOps := &vmcommon.BuiltInCost{}
-	err = mapstructure.Decode(gasMap[core.BuiltInCostString], builtInOps)
-	if err != nil {
-		return nil, err
-	}
-
-	err = check.ForZeroUintFields(*builtInOps)
-	if err != nil {
-		return nil, err
-	}
-
-	gasCost := vmcommon.GasCost{
-		BaseOperationCost: *baseOps,
-		BuiltInCost:       *builtInOps,
-	}
-
-	return &gasCost, nil
+	return gasSchedule.ValidateGasScheduleLenient(gasMap)
 }
 
 // SetPayableHandler sets the payableCheck interface to the needed functions
@@ -1,12 +1,14 @@
 package builtInFunctions
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/subrahamanyam341/andes-core-16/core"
 	"github.com/subrahamanyam341/andes-core-16/core/check"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
 	"github.com/subrahamanyam341/andes-vm-common-123/mock"
 )
 
@@ -166,3 +168,111 @@ func TestCreateBuiltInContainer_Create(t *testing.T) {
 	nftStorageHandler := f.NFTStorageHandler()
 	assert.False(t, check.IfNil(nftStorageHandler))
 }
+
+func TestCreateBuiltInContainer_RoundActivations(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArguments()
+	args.RoundActivations = map[string]uint64{core.BuiltInFunctionDCTTransfer: 10}
+	f, err := NewBuiltInFunctionsCreator(args)
+	assert.Nil(t, f)
+	assert.Equal(t, ErrNilRoundNotifier, err)
+
+	var roundSubscribers []vmcommon.RoundSubscriberHandler
+	args.RoundNotifier = &mock.RoundNotifierStub{
+		RegisterNotifyHandlerCalled: func(handler vmcommon.RoundSubscriberHandler) {
+			roundSubscribers = append(roundSubscribers, handler)
+		},
+	}
+	args.RoundActivations[core.BuiltInFunctionMigrateDataTrie] = 20
+	args.EnableEpochsHandler = &mock.EnableEpochsHandlerStub{IsMigrateDataTrieEnabledField: true}
+	f, err = NewBuiltInFunctionsCreator(args)
+	require.Nil(t, err)
+	err = f.CreateBuiltInFunctionContainer()
+	require.Nil(t, err)
+	require.Len(t, roundSubscribers, 2)
+
+	transferFunc, _ := f.BuiltInFunctionContainer().Get(core.BuiltInFunctionDCTTransfer)
+	migrateFunc, _ := f.BuiltInFunctionContainer().Get(core.BuiltInFunctionMigrateDataTrie)
+	claimFunc, _ := f.BuiltInFunctionContainer().Get(core.BuiltInFunctionClaimDeveloperRewards)
+	assert.False(t, transferFunc.IsActive())
+	assert.False(t, migrateFunc.IsActive())
+	assert.True(t, claimFunc.IsActive())
+
+	for _, subscriber := range roundSubscribers {
+		subscriber.RoundConfirmed(10, 0)
+	}
+	assert.True(t, transferFunc.IsActive())
+	assert.False(t, migrateFunc.IsActive())
+
+	for _, subscriber := range roundSubscribers {
+		subscriber.RoundConfirmed(20, 0)
+	}
+	assert.True(t, migrateFunc.IsActive())
+
+	args.RoundActivations = map[string]uint64{"unknownFunction": 10}
+	f, _ = NewBuiltInFunctionsCreator(args)
+	err = f.CreateBuiltInFunctionContainer()
+	assert.True(t, errors.Is(err, ErrInvalidContainerKey))
+}
+
+func TestCreateBuiltInContainer_MetricsHandler(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArguments()
+	metricsHandler := &builtInFunctionMetricsHandlerStub{}
+	args.MetricsHandler = metricsHandler
+	f, _ := NewBuiltInFunctionsCreator(args)
+
+	err := f.CreateBuiltInFunctionContainer()
+	require.Nil(t, err)
+	assert.Equal(t, 36, f.BuiltInFunctionContainer().Len())
+	for name := range f.BuiltInFunctionContainer().Keys() {
+		builtInFunc, _ := f.BuiltInFunctionContainer().Get(name)
+		_, ok := builtInFunc.(*instrumentedBuiltInFunction)
+		assert.True(t, ok, name)
+	}
+
+	err = f.SetPayableHandler(&mock.PayableHandlerStub{})
+	assert.Nil(t, err)
+
+	fillGasMapInternal(args.GasMap, 5)
+	f.GasScheduleChange(args.GasMap)
+
+	claimFunc, _ := f.BuiltInFunctionContainer().Get(core.BuiltInFunctionClaimDeveloperRewards)
+	_, _ = claimFunc.ProcessBuiltinFunction(nil, nil, nil)
+	require.Equal(t, 1, len(metricsHandler.recorded))
+	assert.Equal(t, core.BuiltInFunctionClaimDeveloperRewards, metricsHandler.recorded[0].Function)
+	assert.NotNil(t, metricsHandler.recorded[0].Err)
+}
+
+func TestCreateBuiltInContainer_TraceStorage(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArguments()
+	args.TraceStorage = true
+	f, _ := NewBuiltInFunctionsCreator(args)
+	_, ok := f.accounts.(*tracingAccountsAdapter)
+	assert.True(t, ok)
+
+	err := f.CreateBuiltInFunctionContainer()
+	require.Nil(t, err)
+	for name := range f.BuiltInFunctionContainer().Keys() {
+		builtInFunc, _ := f.BuiltInFunctionContainer().Get(name)
+		_, ok = builtInFunc.(*tracedBuiltInFunction)
+		assert.True(t, ok, name)
+	}
+
+	err = f.SetPayableHandler(&mock.PayableHandlerStub{})
+	assert.Nil(t, err)
+
+	args.MetricsHandler = &builtInFunctionMetricsHandlerStub{}
+	f, _ = NewBuiltInFunctionsCreator(args)
+	err = f.CreateBuiltInFunctionContainer()
+	require.Nil(t, err)
+	transferFunc, _ := f.BuiltInFunctionContainer().Get(core.BuiltInFunctionDCTTransfer)
+	instrumentedFunc, ok := transferFunc.(*instrumentedBuiltInFunction)
+	require.True(t, ok)
+	_, ok = instrumentedFunc.BuiltinFunction.(*tracedBuiltInFunction)
+	assert.True(t, ok)
+}
@@ -115,6 +115,11 @@ func (e *dctBurn) ProcessBuiltinFunction(
 	return vmOutput, nil
 }
 
+// CheckIsExecutable checks if the DCT burn can be executed, without changing the accounts
+func (e *dctBurn) CheckIsExecutable(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) error {
+	return checkIsExecutableOnDryRunAccounts(e, acntSnd, acntDst, vmInput)
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (e *dctBurn) IsInterfaceNil() bool {
 	return e == nil
@@ -308,6 +308,25 @@ func (e *dctDeleteMetaData) marshalAndSaveData(
 	return nil
 }
 
+// CheckIsExecutable checks if the metadata add or delete can be executed, without changing the accounts
+func (e *dctDeleteMetaData) CheckIsExecutable(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) error {
+	ctx := newDryRunContext()
+	return ctx.checkIsExecutable(e.dryRunCopy(ctx), acntSnd, acntDst, vmInput)
+}
+
+func (e *dctDeleteMetaData) dryRunCopy(ctx *dryRunContext) *dctDeleteMetaData {
+	return &dctDeleteMetaData{
+		baseActiveHandler: e.baseActiveHandler,
+		allowedAddress:    e.allowedAddress,
+		delete:            e.delete,
+		accounts:          ctx.accountsAdapter(e.accounts),
+		keyPrefix:         e.keyPrefix,
+		marshaller:        e.marshaller,
+		funcGasCost:       e.funcGasCost,
+		function:          e.function,
+	}
+}
+
 // IsInterfaceNil returns true if underlying object is nil
 func (e *dctDeleteMetaData) IsInterfaceNil() bool {
 	return e == nil
@@ -75,7 +75,8 @@ func (e *dctFreezeWipe) ProcessBuiltinFunction(
 	}
 
 	dctTokenKey := append(e.keyPrefix, vmInput.Arguments[0]...)
-	identifier, nonce := extractTokenIdentifierAndNonceDCTWipe(vmInput.Arguments[0])
+	tokenIdentifier := vmcommon.ParseTokenIdentifierWithLegacySplit(vmInput.Arguments[0])
+	identifier, nonce := tokenIdentifier.TokenID, tokenIdentifier.Nonce
 
 	var amount *big.Int
 	var err error
@@ -152,6 +153,39 @@ func (e *dctFreezeWipe) toggleFreeze(acntDst vmcommon.UserAccountHandler, tokenK
 	return frozenAmount, nil
 }
 
+// CheckIsExecutable checks if the freeze, unfreeze or wipe can be executed, without changing the accounts
+func (e *dctFreezeWipe) CheckIsExecutable(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) error {
+	ctx := newDryRunContext()
+	dryRun, err := e.dryRunCopy(ctx)
+	if err != nil {
+		return err
+	}
+
+	return ctx.checkIsExecutable(dryRun, acntSnd, acntDst, vmInput)
+}
+
+func (e *dctFreezeWipe) dryRunCopy(ctx *dryRunContext) (*dctFreezeWipe, error) {
+	// only the wipe changes the state through the storage handler, as it decreases the token liquidity
+	storageHandler := e.dctStorageHandler
+	if e.wipe {
+		var err error
+		storageHandler, err = ctx.storageHandler(e.dctStorageHandler)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &dctFreezeWipe{
+		baseAlwaysActiveHandler: e.baseAlwaysActiveHandler,
+		dctStorageHandler:       storageHandler,
+		enableEpochsHandler:     e.enableEpochsHandler,
+		marshaller:              e.marshaller,
+		keyPrefix:               e.keyPrefix,
+		wipe:                    e.wipe,
+		freeze:                  e.freeze,
+	}, nil
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (e *dctFreezeWipe) IsInterfaceNil() bool {
 	return e == nil
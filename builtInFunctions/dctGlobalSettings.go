@@ -211,6 +211,23 @@ func (e *dctGlobalSettings) getGlobalMetadata(dctTokenKey []byte) (*DCTGlobalMet
 	return &dctMetaData, nil
 }
 
+// CheckIsExecutable checks if the global settings change can be executed, without changing the accounts
+func (e *dctGlobalSettings) CheckIsExecutable(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) error {
+	ctx := newDryRunContext()
+	return ctx.checkIsExecutable(e.dryRunCopy(ctx), acntSnd, acntDst, vmInput)
+}
+
+func (e *dctGlobalSettings) dryRunCopy(ctx *dryRunContext) *dctGlobalSettings {
+	return &dctGlobalSettings{
+		baseActiveHandler: e.baseActiveHandler,
+		keyPrefix:         e.keyPrefix,
+		set:               e.set,
+		accounts:          ctx.accountsAdapter(e.accounts),
+		marshaller:        e.marshaller,
+		function:          e.function,
+	}
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (e *dctGlobalSettings) IsInterfaceNil() bool {
 	return e == nil
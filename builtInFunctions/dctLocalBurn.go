@@ -11,6 +11,9 @@ import (
 	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
 )
 
+var basicDCTArgumentsSchema = NewArgumentsSchema().
+	WithVariadic(core.MinLenArgumentsDCTTransfer, BytesArgument("argument"))
+
 type dctLocalBurn struct {
 	baseAlwaysActiveHandler
 	keyPrefix             []byte
@@ -116,6 +119,11 @@ func (e *dctLocalBurn) isAllowedToBurn(acntSnd vmcommon.UserAccountHandler, toke
 	return e.rolesHandler.CheckAllowedToExecute(acntSnd, tokenID, []byte(core.DCTRoleLocalBurn))
 }
 
+// CheckIsExecutable checks if the DCT local burn can be executed, without changing the accounts
+func (e *dctLocalBurn) CheckIsExecutable(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) error {
+	return checkIsExecutableOnDryRunAccounts(e, acntSnd, acntDst, vmInput)
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (e *dctLocalBurn) IsInterfaceNil() bool {
 	return e == nil
@@ -131,10 +139,8 @@ func checkBasicDCTArguments(vmInput *vmcommon.ContractCallInput) error {
 	if vmInput.CallValue.Cmp(zero) != 0 {
 		return ErrBuiltInFunctionCalledWithValue
 	}
-	if len(vmInput.Arguments) < core.MinLenArgumentsDCTTransfer {
-		return ErrInvalidArguments
-	}
-	return nil
+
+	return basicDCTArgumentsSchema.Validate(vmInput)
 }
 
 func checkInputArgumentsForLocalAction(
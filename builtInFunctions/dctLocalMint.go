@@ -107,6 +107,11 @@ func (e *dctLocalMint) ProcessBuiltinFunction(
 	return vmOutput, nil
 }
 
+// CheckIsExecutable checks if the DCT local mint can be executed, without changing the accounts
+func (e *dctLocalMint) CheckIsExecutable(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) error {
+	return checkIsExecutableOnDryRunAccounts(e, acntSnd, acntDst, vmInput)
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (e *dctLocalMint) IsInterfaceNil() bool {
 	return e == nil
@@ -12,6 +12,12 @@ import (
 
 const maxLenForAddNFTQuantity = 32
 
+var nftAddQuantityArgumentsSchema = NewArgumentsSchema(
+	BytesArgument("tokenIdentifier"),
+	BytesArgument("nonce"),
+	BytesArgument("quantity"),
+).WithVariadic(0, BytesArgument("argument"))
+
 type dctNFTAddQuantity struct {
 	baseAlwaysActiveHandler
 	keyPrefix             []byte
@@ -84,8 +90,9 @@ func (e *dctNFTAddQuantity) ProcessBuiltinFunction(
 	if err != nil {
 		return nil, err
 	}
-	if len(vmInput.Arguments) < 3 {
-		return nil, ErrInvalidArguments
+	err = nftAddQuantityArgumentsSchema.Validate(vmInput)
+	if err != nil {
+		return nil, err
 	}
 
 	err = e.rolesHandler.CheckAllowedToExecute(acntSnd, vmInput.Arguments[0], []byte(core.DCTRoleNFTAddQuantity))
@@ -130,6 +137,37 @@ func (e *dctNFTAddQuantity) ProcessBuiltinFunction(
 	return vmOutput, nil
 }
 
+// CheckIsExecutable checks if the NFT add quantity can be executed, without changing the accounts
+func (e *dctNFTAddQuantity) CheckIsExecutable(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) error {
+	ctx := newDryRunContext()
+	dryRun, err := e.dryRunCopy(ctx)
+	if err != nil {
+		return err
+	}
+
+	return ctx.checkIsExecutable(dryRun, acntSnd, acntDst, vmInput)
+}
+
+func (e *dctNFTAddQuantity) dryRunCopy(ctx *dryRunContext) (*dctNFTAddQuantity, error) {
+	storageHandler, err := ctx.storageHandler(e.dctStorageHandler)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	return &dctNFTAddQuantity{
+		baseAlwaysActiveHandler: e.baseAlwaysActiveHandler,
+		keyPrefix:               e.keyPrefix,
+		globalSettingsHandler:   e.globalSettingsHandler,
+		rolesHandler:            e.rolesHandler,
+		dctStorageHandler:       storageHandler,
+		enableEpochsHandler:     e.enableEpochsHandler,
+		funcGasCost:             e.funcGasCost,
+	}, nil
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (e *dctNFTAddQuantity) IsInterfaceNil() bool {
 	return e == nil
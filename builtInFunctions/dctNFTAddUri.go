@@ -9,6 +9,11 @@ import (
 	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
 )
 
+var nftAddURIArgumentsSchema = NewArgumentsSchema(
+	BytesArgument("tokenIdentifier"),
+	BytesArgument("nonce"),
+).WithVariadic(1, StringArgument("uri"))
+
 type dctNFTAddUri struct {
 	baseActiveHandler
 	keyPrefix             []byte
@@ -85,8 +90,9 @@ func (e *dctNFTAddUri) ProcessBuiltinFunction(
 	if err != nil {
 		return nil, err
 	}
-	if len(vmInput.Arguments) < 3 {
-		return nil, ErrInvalidArguments
+	err = nftAddURIArgumentsSchema.Validate(vmInput)
+	if err != nil {
+		return nil, err
 	}
 
 	err = e.rolesHandler.CheckAllowedToExecute(acntSnd, vmInput.Arguments[0], []byte(core.DCTRoleNFTAddURI))
@@ -135,6 +141,37 @@ func (e *dctNFTAddUri) getGasCostForURIStore(vmInput *vmcommon.ContractCallInput
 	return uint64(lenURIs) * e.gasConfig.StorePerByte
 }
 
+// CheckIsExecutable checks if the NFT add URI can be executed, without changing the accounts
+func (e *dctNFTAddUri) CheckIsExecutable(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) error {
+	ctx := newDryRunContext()
+	dryRun, err := e.dryRunCopy(ctx)
+	if err != nil {
+		return err
+	}
+
+	return ctx.checkIsExecutable(dryRun, acntSnd, acntDst, vmInput)
+}
+
+func (e *dctNFTAddUri) dryRunCopy(ctx *dryRunContext) (*dctNFTAddUri, error) {
+	storageHandler, err := ctx.storageHandler(e.dctStorageHandler)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	return &dctNFTAddUri{
+		baseActiveHandler:     e.baseActiveHandler,
+		keyPrefix:             e.keyPrefix,
+		dctStorageHandler:     storageHandler,
+		globalSettingsHandler: e.globalSettingsHandler,
+		rolesHandler:          e.rolesHandler,
+		gasConfig:             e.gasConfig,
+		funcGasCost:           e.funcGasCost,
+	}, nil
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (e *dctNFTAddUri) IsInterfaceNil() bool {
 	return e == nil
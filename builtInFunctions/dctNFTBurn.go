@@ -9,6 +9,12 @@ import (
 	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
 )
 
+var nftBurnArgumentsSchema = NewArgumentsSchema(
+	BytesArgument("tokenIdentifier"),
+	BytesArgument("nonce"),
+	BytesArgument("quantity"),
+).WithVariadic(0, BytesArgument("argument"))
+
 type dctNFTBurn struct {
 	baseAlwaysActiveHandler
 	keyPrefix             []byte
@@ -75,8 +81,9 @@ func (e *dctNFTBurn) ProcessBuiltinFunction(
 	if err != nil {
 		return nil, err
 	}
-	if len(vmInput.Arguments) < 3 {
-		return nil, ErrInvalidArguments
+	err = nftBurnArgumentsSchema.Validate(vmInput)
+	if err != nil {
+		return nil, err
 	}
 
 	dctTokenKey := append(e.keyPrefix, vmInput.Arguments[0]...)
@@ -131,6 +138,36 @@ func (e *dctNFTBurn) isAllowedToBurn(acntSnd vmcommon.UserAccountHandler, tokenI
 	return e.rolesHandler.CheckAllowedToExecute(acntSnd, tokenID, []byte(core.DCTRoleNFTBurn))
 }
 
+// CheckIsExecutable checks if the NFT burn can be executed, without changing the accounts
+func (e *dctNFTBurn) CheckIsExecutable(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) error {
+	ctx := newDryRunContext()
+	dryRun, err := e.dryRunCopy(ctx)
+	if err != nil {
+		return err
+	}
+
+	return ctx.checkIsExecutable(dryRun, acntSnd, acntDst, vmInput)
+}
+
+func (e *dctNFTBurn) dryRunCopy(ctx *dryRunContext) (*dctNFTBurn, error) {
+	storageHandler, err := ctx.storageHandler(e.dctStorageHandler)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	return &dctNFTBurn{
+		baseAlwaysActiveHandler: e.baseAlwaysActiveHandler,
+		keyPrefix:               e.keyPrefix,
+		dctStorageHandler:       storageHandler,
+		globalSettingsHandler:   e.globalSettingsHandler,
+		rolesHandler:            e.rolesHandler,
+		funcGasCost:             e.funcGasCost,
+	}, nil
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (e *dctNFTBurn) IsInterfaceNil() bool {
 	return e == nil
@@ -19,6 +19,19 @@ var (
 	noncePrefix = []byte(core.ProtectedKeyPrefix + core.DCTNFTLatestNonceIdentifier)
 )
 
+var (
+	nftCreateArgumentsSchema = NewArgumentsSchema(
+		BytesArgument("tokenIdentifier"),
+		BytesArgument("quantity"),
+		StringArgument("name"),
+		BytesArgument("royalties"),
+		BytesArgument("hash"),
+		BytesArgument("attributes"),
+	).WithVariadic(1, StringArgument("uri")).
+		WithError(fmt.Errorf("%w, wrong number of arguments", ErrInvalidArguments))
+	nftCreateOnDestByCallerArgumentsSchema = nftCreateArgumentsSchema.WithTrailing(AddressArgument("scAddressWithRoles"))
+)
+
 type dctNFTCreate struct {
 	baseAlwaysActiveHandler
 	keyPrefix             []byte
@@ -112,24 +125,22 @@ func (e *dctNFTCreate) ProcessBuiltinFunction(
 		return nil, err
 	}
 
-	minNumOfArgs := 7
+	argumentsSchema := nftCreateArgumentsSchema
 	if vmInput.CallType == vm.ExecOnDestByCaller {
-		minNumOfArgs = 8
+		argumentsSchema = nftCreateOnDestByCallerArgumentsSchema
 	}
-	lenArgs := len(vmInput.Arguments)
-	if lenArgs < minNumOfArgs {
-		return nil, fmt.Errorf("%w, wrong number of arguments", ErrInvalidArguments)
+	err = argumentsSchema.Validate(vmInput)
+	if err != nil {
+		return nil, err
 	}
 
+	lenArgs := len(vmInput.Arguments)
 	accountWithRoles := acntSnd
 	uris := vmInput.Arguments[6:]
 	if vmInput.CallType == vm.ExecOnDestByCaller {
 		scAddressWithRoles := vmInput.Arguments[lenArgs-1]
 		uris = vmInput.Arguments[6 : lenArgs-1]
 
-		if len(scAddressWithRoles) != len(vmInput.CallerAddr) {
-			return nil, ErrInvalidAddressLength
-		}
 		if bytes.Equal(scAddressWithRoles, vmInput.CallerAddr) {
 			return nil, ErrInvalidRcvAddr
 		}
@@ -295,6 +306,40 @@ func getNonceKey(tokenID []byte) []byte {
 	return append(noncePrefix, tokenID...)
 }
 
+// CheckIsExecutable checks if the NFT create can be executed, without changing the accounts
+func (e *dctNFTCreate) CheckIsExecutable(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) error {
+	ctx := newDryRunContext()
+	dryRun, err := e.dryRunCopy(ctx)
+	if err != nil {
+		return err
+	}
+
+	return ctx.checkIsExecutable(dryRun, acntSnd, acntDst, vmInput)
+}
+
+func (e *dctNFTCreate) dryRunCopy(ctx *dryRunContext) (*dctNFTCreate, error) {
+	storageHandler, err := ctx.storageHandler(e.dctStorageHandler)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	return &dctNFTCreate{
+		baseAlwaysActiveHandler: e.baseAlwaysActiveHandler,
+		keyPrefix:               e.keyPrefix,
+		accounts:                ctx.accountsAdapter(e.accounts),
+		marshaller:              e.marshaller,
+		globalSettingsHandler:   e.globalSettingsHandler,
+		rolesHandler:            e.rolesHandler,
+		funcGasCost:             e.funcGasCost,
+		gasConfig:               e.gasConfig,
+		dctStorageHandler:       storageHandler,
+		enableEpochsHandler:     e.enableEpochsHandler,
+	}, nil
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (e *dctNFTCreate) IsInterfaceNil() bool {
 	return e == nil
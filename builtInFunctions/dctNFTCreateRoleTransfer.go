@@ -243,6 +243,22 @@ func (e *dctNFTCreateRoleTransfer) executeTransferNFTCreateChangeAtNextOwner(
 	return nil
 }
 
+// CheckIsExecutable checks if the NFT create role transfer can be executed, without changing the accounts
+func (e *dctNFTCreateRoleTransfer) CheckIsExecutable(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) error {
+	ctx := newDryRunContext()
+	return ctx.checkIsExecutable(e.dryRunCopy(ctx), acntSnd, acntDst, vmInput)
+}
+
+func (e *dctNFTCreateRoleTransfer) dryRunCopy(ctx *dryRunContext) *dctNFTCreateRoleTransfer {
+	return &dctNFTCreateRoleTransfer{
+		baseAlwaysActiveHandler: e.baseAlwaysActiveHandler,
+		keyPrefix:               e.keyPrefix,
+		marshaller:              e.marshaller,
+		accounts:                ctx.accountsAdapter(e.accounts),
+		shardCoordinator:        e.shardCoordinator,
+	}
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (e *dctNFTCreateRoleTransfer) IsInterfaceNil() bool {
 	return e == nil
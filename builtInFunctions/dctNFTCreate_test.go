@@ -12,8 +12,10 @@ import (
 	"github.com/subrahamanyam341/andes-core-16/core/check"
 	"github.com/subrahamanyam341/andes-core-16/data/dct"
 	"github.com/subrahamanyam341/andes-core-16/data/vm"
+	"github.com/subrahamanyam341/andes-core-16/hashing/sha256"
 	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
 	"github.com/subrahamanyam341/andes-vm-common-123/mock"
+	"github.com/subrahamanyam341/andes-vm-common-123/state"
 )
 
 func createNftCreateWithStubArguments() *dctNFTCreate {
@@ -451,3 +453,87 @@ func readNFTData(t *testing.T, account vmcommon.UserAccountHandler, marshaller v
 
 	return dctData, latestNonce
 }
+
+func TestDctNFTCreate_ProcessBuiltinFunctionWithInMemoryState(t *testing.T) {
+	t.Parallel()
+
+	marshaller := &mock.MarshalizerMock{}
+	args := createMockArguments()
+	args.EnableEpochsHandler = &mock.EnableEpochsHandlerStub{
+		IsSaveToSystemAccountFlagEnabledField: true,
+		IsValueLengthCheckFlagEnabledField:    true,
+	}
+	accounts, _ := state.NewAccountsAdapter(state.ArgsNewAccountsAdapter{
+		Hasher:              sha256.NewSha256(),
+		EnableEpochsHandler: args.EnableEpochsHandler,
+	})
+	args.Accounts = accounts
+	args.Marshalizer = marshaller
+	creator, _ := NewBuiltInFunctionsCreator(args)
+	require.Nil(t, creator.CreateBuiltInFunctionContainer())
+	nftCreateFunc, _ := creator.BuiltInFunctionContainer().Get(core.BuiltInFunctionDCTNFTCreate)
+
+	tokenID := []byte("NFT-abcdef")
+	address := bytes.Repeat([]byte{1}, 32)
+	account, _ := accounts.LoadAccount(address)
+	roles := &dct.DCTRoles{Roles: [][]byte{[]byte(core.DCTRoleNFTCreate)}}
+	marshaledRoles, _ := marshaller.Marshal(roles)
+	_ = account.(vmcommon.UserAccountHandler).AccountDataHandler().SaveKeyValue(append(roleKeyPrefix, tokenID...), marshaledRoles)
+	require.Nil(t, accounts.SaveAccount(account))
+	_, _ = accounts.Commit()
+
+	createNFT := func(name string) *vmcommon.VMOutput {
+		acnt, _ := accounts.LoadAccount(address)
+		vmInput := &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr:  address,
+				CallValue:   big.NewInt(0),
+				GasProvided: 1000,
+				Arguments: [][]byte{
+					tokenID,
+					big.NewInt(1).Bytes(),
+					[]byte(name),
+					big.NewInt(100).Bytes(),
+					[]byte("hash"),
+					[]byte("attributes"),
+					[]byte("uri"),
+				},
+			},
+			RecipientAddr: address,
+		}
+		vmOutput, err := nftCreateFunc.ProcessBuiltinFunction(acnt.(vmcommon.UserAccountHandler), nil, vmInput)
+		require.Nil(t, err)
+		require.Nil(t, accounts.SaveAccount(acnt))
+
+		return vmOutput
+	}
+
+	rootHashBefore, _ := accounts.RootHash()
+	vmOutput := createNFT("first")
+	assert.Equal(t, [][]byte{big.NewInt(1).Bytes()}, vmOutput.ReturnData)
+	snapshot := accounts.JournalLen()
+	vmOutput = createNFT("second")
+	assert.Equal(t, [][]byte{big.NewInt(2).Bytes()}, vmOutput.ReturnData)
+
+	acnt, _ := accounts.LoadAccount(address)
+	createdDct, latestNonce := readNFTData(t, acnt.(vmcommon.UserAccountHandler), marshaller, tokenID, 2, nil)
+	assert.Equal(t, uint64(2), latestNonce)
+	assert.Equal(t, big.NewInt(1), createdDct.Value)
+
+	nftTokenKey := computeDCTNFTTokenKey(append([]byte(baseDCTKeyPrefix), tokenID...), 2)
+	systemAccount, err := accounts.GetExistingAccount(vmcommon.SystemAccountAddress)
+	require.Nil(t, err)
+	marshaledMetaData, _, _ := systemAccount.(vmcommon.UserAccountHandler).AccountDataHandler().RetrieveValue(nftTokenKey)
+	metaData := &dct.DCToken{}
+	require.Nil(t, marshaller.Unmarshal(metaData, marshaledMetaData))
+	assert.Equal(t, []byte("second"), metaData.TokenMetaData.Name)
+
+	require.Nil(t, accounts.RevertToSnapshot(snapshot))
+	acnt, _ = accounts.LoadAccount(address)
+	_, latestNonce = readNFTData(t, acnt.(vmcommon.UserAccountHandler), marshaller, tokenID, 1, nil)
+	assert.Equal(t, uint64(1), latestNonce)
+
+	require.Nil(t, accounts.RevertToSnapshot(0))
+	rootHashAfterRevert, _ := accounts.RootHash()
+	assert.Equal(t, rootHashBefore, rootHashAfterRevert)
+}
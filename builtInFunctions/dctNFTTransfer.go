@@ -466,6 +466,42 @@ func addNFTTransferToVMOutput(
 	}
 }
 
+// CheckIsExecutable checks if the NFT transfer can be executed, without changing the accounts
+func (e *dctNFTTransfer) CheckIsExecutable(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) error {
+	ctx := newDryRunContext()
+	dryRun, err := e.dryRunCopy(ctx)
+	if err != nil {
+		return err
+	}
+
+	return ctx.checkIsExecutable(dryRun, acntSnd, acntDst, vmInput)
+}
+
+func (e *dctNFTTransfer) dryRunCopy(ctx *dryRunContext) (*dctNFTTransfer, error) {
+	storageHandler, err := ctx.storageHandler(e.dctStorageHandler)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	return &dctNFTTransfer{
+		baseAlwaysActiveHandler: e.baseAlwaysActiveHandler,
+		keyPrefix:               e.keyPrefix,
+		marshaller:              e.marshaller,
+		globalSettingsHandler:   e.globalSettingsHandler,
+		payableHandler:          e.payableHandler,
+		funcGasCost:             e.funcGasCost,
+		accounts:                ctx.accountsAdapter(e.accounts),
+		shardCoordinator:        e.shardCoordinator,
+		gasConfig:               e.gasConfig,
+		rolesHandler:            e.rolesHandler,
+		dctStorageHandler:       storageHandler,
+		enableEpochsHandler:     e.enableEpochsHandler,
+	}, nil
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (e *dctNFTTransfer) IsInterfaceNil() bool {
 	return e == nil
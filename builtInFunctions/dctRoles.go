@@ -173,6 +173,11 @@ func (e *dctRoles) CheckAllowedToExecute(account vmcommon.UserAccountHandler, to
 	return nil
 }
 
+// CheckIsExecutable checks if the DCT roles change can be executed, without changing the accounts
+func (e *dctRoles) CheckIsExecutable(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) error {
+	return checkIsExecutableOnDryRunAccounts(e, acntSnd, acntDst, vmInput)
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (e *dctRoles) IsInterfaceNil() bool {
 	return e == nil
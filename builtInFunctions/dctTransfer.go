@@ -405,6 +405,11 @@ func (e *dctTransfer) SetPayableChecker(payableHandler vmcommon.PayableChecker)
 	return nil
 }
 
+// CheckIsExecutable checks if the DCT transfer can be executed, without changing the accounts
+func (e *dctTransfer) CheckIsExecutable(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) error {
+	return checkIsExecutableOnDryRunAccounts(e, acntSnd, acntDst, vmInput)
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (e *dctTransfer) IsInterfaceNil() bool {
 	return e == nil
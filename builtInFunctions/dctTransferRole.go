@@ -149,6 +149,22 @@ func (e *dctTransferAddress) getSystemAccount() (vmcommon.UserAccountHandler, er
 	return userAcc, nil
 }
 
+// CheckIsExecutable checks if the transfer role addresses change can be executed, without changing the accounts
+func (e *dctTransferAddress) CheckIsExecutable(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) error {
+	ctx := newDryRunContext()
+	return ctx.checkIsExecutable(e.dryRunCopy(ctx), acntSnd, acntDst, vmInput)
+}
+
+func (e *dctTransferAddress) dryRunCopy(ctx *dryRunContext) *dctTransferAddress {
+	return &dctTransferAddress{
+		baseActiveHandler: e.baseActiveHandler,
+		set:               e.set,
+		marshaller:        e.marshaller,
+		accounts:          ctx.accountsAdapter(e.accounts),
+		maxNumAddresses:   e.maxNumAddresses,
+	}
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (e *dctTransferAddress) IsInterfaceNil() bool {
 	return e == nil
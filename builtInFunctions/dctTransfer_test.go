@@ -7,12 +7,15 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/subrahamanyam341/andes-core-16/core"
 	"github.com/subrahamanyam341/andes-core-16/core/check"
 	"github.com/subrahamanyam341/andes-core-16/data/dct"
 	"github.com/subrahamanyam341/andes-core-16/data/vm"
+	"github.com/subrahamanyam341/andes-core-16/hashing/sha256"
 	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
 	"github.com/subrahamanyam341/andes-vm-common-123/mock"
+	"github.com/subrahamanyam341/andes-vm-common-123/state"
 )
 
 func TestNewDCTTransferFunc(t *testing.T) {
@@ -466,3 +469,76 @@ func TestDCTTransfer_ProcessBuiltInFunctionOnAsyncCallBack(t *testing.T) {
 	_ = marshaller.Unmarshal(dctToken, marshaledData)
 	assert.True(t, dctToken.Value.Cmp(big.NewInt(90)) == 0)
 }
+
+func TestDCTTransfer_ProcessBuiltInFunctionWithInMemoryState(t *testing.T) {
+	t.Parallel()
+
+	marshaller := &mock.MarshalizerMock{}
+	args := createMockArguments()
+	accounts, _ := state.NewAccountsAdapter(state.ArgsNewAccountsAdapter{
+		Hasher:              sha256.NewSha256(),
+		EnableEpochsHandler: args.EnableEpochsHandler,
+	})
+	args.Accounts = accounts
+	args.Marshalizer = marshaller
+	creator, _ := NewBuiltInFunctionsCreator(args)
+	require.Nil(t, creator.CreateBuiltInFunctionContainer())
+	require.Nil(t, creator.SetPayableHandler(&mock.PayableHandlerStub{}))
+	transferFunc, _ := creator.BuiltInFunctionContainer().Get(core.BuiltInFunctionDCTTransfer)
+
+	tokenID := []byte("TOKEN-abcdef")
+	tokenKey := append([]byte(baseDCTKeyPrefix), tokenID...)
+	senderAddr := bytes.Repeat([]byte{1}, 32)
+	receiverAddr := bytes.Repeat([]byte{2}, 32)
+
+	sender, _ := accounts.LoadAccount(senderAddr)
+	userSender := sender.(vmcommon.UserAccountHandler)
+	dctData := &dct.DCToken{Value: big.NewInt(100), Type: uint32(core.Fungible)}
+	require.Nil(t, saveDCTData(userSender, dctData, tokenKey, marshaller))
+	require.Nil(t, accounts.SaveAccount(userSender))
+	_, _ = accounts.Commit()
+
+	runTransfer := func(value int64) error {
+		snapshot := accounts.JournalLen()
+		acntSnd, _ := accounts.LoadAccount(senderAddr)
+		acntDst, _ := accounts.LoadAccount(receiverAddr)
+		input := &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr:  senderAddr,
+				CallValue:   big.NewInt(0),
+				GasProvided: 10,
+				Arguments:   [][]byte{tokenID, big.NewInt(value).Bytes()},
+			},
+			RecipientAddr: receiverAddr,
+		}
+		_, err := transferFunc.ProcessBuiltinFunction(acntSnd.(vmcommon.UserAccountHandler), acntDst.(vmcommon.UserAccountHandler), input)
+		if err != nil {
+			_ = accounts.RevertToSnapshot(snapshot)
+			return err
+		}
+
+		require.Nil(t, accounts.SaveAccount(acntSnd))
+		require.Nil(t, accounts.SaveAccount(acntDst))
+		return nil
+	}
+	getBalance := func(address []byte) *big.Int {
+		account, _ := accounts.LoadAccount(address)
+		data, _ := getDCTDataFromKey(account.(vmcommon.UserAccountHandler), tokenKey, marshaller)
+		return data.Value
+	}
+
+	rootHashBefore, _ := accounts.RootHash()
+	require.Nil(t, runTransfer(40))
+	assert.Equal(t, big.NewInt(60), getBalance(senderAddr))
+	assert.Equal(t, big.NewInt(40), getBalance(receiverAddr))
+
+	err := runTransfer(61)
+	assert.Equal(t, ErrInsufficientFunds, err)
+	assert.Equal(t, big.NewInt(60), getBalance(senderAddr))
+
+	require.Nil(t, accounts.RevertToSnapshot(0))
+	rootHashAfterRevert, _ := accounts.RootHash()
+	assert.Equal(t, rootHashBefore, rootHashAfterRevert)
+	assert.Equal(t, big.NewInt(100), getBalance(senderAddr))
+	assert.Equal(t, big.NewInt(0), getBalance(receiverAddr))
+}
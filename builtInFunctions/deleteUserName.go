@@ -99,6 +99,11 @@ func addLogEntryForUserNameChange(
 	return logEntry
 }
 
+// CheckIsExecutable checks if the delete user name can be executed, without changing the accounts
+func (d *deleteUserName) CheckIsExecutable(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) error {
+	return checkIsExecutableOnDryRunAccounts(d, acntSnd, acntDst, vmInput)
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (d *deleteUserName) IsInterfaceNil() bool {
 	return d == nil
@@ -0,0 +1,365 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+)
+
+// dryRunContext holds the copy-on-write views of the accounts used while checking if a built-in function call is
+// executable. The built-in function runs as usual, but all its writes end up in these views and are dropped
+// together with the context, so the real accounts are never changed.
+type dryRunContext struct {
+	accounts map[string]*dryRunAccount
+}
+
+func newDryRunContext() *dryRunContext {
+	return &dryRunContext{
+		accounts: make(map[string]*dryRunAccount),
+	}
+}
+
+// userAccount returns the view of the provided account. The same view is returned for all the accounts
+// with the same address, so that the changes made through one of them are visible through the others.
+func (ctx *dryRunContext) userAccount(account vmcommon.UserAccountHandler) vmcommon.UserAccountHandler {
+	if check.IfNil(account) {
+		return nil
+	}
+
+	return ctx.getOrCreateView(account)
+}
+
+func (ctx *dryRunContext) getOrCreateView(account vmcommon.UserAccountHandler) *dryRunAccount {
+	view, found := ctx.accounts[string(account.AddressBytes())]
+	if found {
+		return view
+	}
+
+	view = newDryRunAccount(account)
+	ctx.accounts[string(account.AddressBytes())] = view
+
+	return view
+}
+
+// accountsAdapter returns an accounts adapter that reads through the provided one and keeps the writes in the context
+func (ctx *dryRunContext) accountsAdapter(accounts vmcommon.AccountsAdapter) vmcommon.AccountsAdapter {
+	return &dryRunAccountsAdapter{
+		accounts: accounts,
+		ctx:      ctx,
+	}
+}
+
+// storageHandler returns a copy of the provided storage handler that works on the context's accounts. Only the
+// storage handler defined in this package can be copied, as its writes have to be redirected.
+func (ctx *dryRunContext) storageHandler(handler vmcommon.DCTNFTStorageHandler) (vmcommon.DCTNFTStorageHandler, error) {
+	dataStorage, ok := handler.(*dctDataStorage)
+	if !ok {
+		return nil, ErrDryRunNotSupported
+	}
+
+	return &dctDataStorage{
+		accounts:              ctx.accountsAdapter(dataStorage.accounts),
+		globalSettingsHandler: dataStorage.globalSettingsHandler,
+		marshaller:            dataStorage.marshaller,
+		keyPrefix:             dataStorage.keyPrefix,
+		shardCoordinator:      dataStorage.shardCoordinator,
+		txDataParser:          dataStorage.txDataParser,
+		enableEpochsHandler:   dataStorage.enableEpochsHandler,
+	}, nil
+}
+
+// checkIsExecutable runs the built-in function on the context's views of the provided accounts
+func (ctx *dryRunContext) checkIsExecutable(
+	function vmcommon.BuiltinFunction,
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) error {
+	_, err := function.ProcessBuiltinFunction(ctx.userAccount(acntSnd), ctx.userAccount(acntDst), vmInput)
+
+	return err
+}
+
+// checkIsExecutableOnDryRunAccounts runs the built-in function on views of the provided accounts. It can be used
+// only by the built-in functions that do not change any state besides the one of the accounts they receive.
+func checkIsExecutableOnDryRunAccounts(
+	function vmcommon.BuiltinFunction,
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) error {
+	return newDryRunContext().checkIsExecutable(function, acntSnd, acntDst, vmInput)
+}
+
+type dryRunAccountsAdapter struct {
+	accounts vmcommon.AccountsAdapter
+	ctx      *dryRunContext
+}
+
+// GetExistingAccount returns the view of the existing account
+func (adapter *dryRunAccountsAdapter) GetExistingAccount(address []byte) (vmcommon.AccountHandler, error) {
+	view, found := adapter.ctx.accounts[string(address)]
+	if found {
+		return view, nil
+	}
+
+	account, err := adapter.accounts.GetExistingAccount(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return adapter.toView(account), nil
+}
+
+// LoadAccount returns the view of the account
+func (adapter *dryRunAccountsAdapter) LoadAccount(address []byte) (vmcommon.AccountHandler, error) {
+	view, found := adapter.ctx.accounts[string(address)]
+	if found {
+		return view, nil
+	}
+
+	account, err := adapter.accounts.LoadAccount(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return adapter.toView(account), nil
+}
+
+func (adapter *dryRunAccountsAdapter) toView(account vmcommon.AccountHandler) vmcommon.AccountHandler {
+	userAccount, ok := account.(vmcommon.UserAccountHandler)
+	if !ok {
+		return account
+	}
+
+	return adapter.ctx.getOrCreateView(userAccount)
+}
+
+// SaveAccount does nothing, the changes are already kept by the account view
+func (adapter *dryRunAccountsAdapter) SaveAccount(account vmcommon.AccountHandler) error {
+	if check.IfNil(account) {
+		return ErrNilAccountHandler
+	}
+
+	return nil
+}
+
+// RemoveAccount does nothing
+func (adapter *dryRunAccountsAdapter) RemoveAccount(_ []byte) error {
+	return nil
+}
+
+// Commit is not permitted during a dry run
+func (adapter *dryRunAccountsAdapter) Commit() ([]byte, error) {
+	return nil, ErrOperationNotPermitted
+}
+
+// JournalLen returns 0 as nothing is journaled during a dry run
+func (adapter *dryRunAccountsAdapter) JournalLen() int {
+	return 0
+}
+
+// RevertToSnapshot does nothing
+func (adapter *dryRunAccountsAdapter) RevertToSnapshot(_ int) error {
+	return nil
+}
+
+// GetCode returns the code from the underlying accounts adapter
+func (adapter *dryRunAccountsAdapter) GetCode(codeHash []byte) []byte {
+	return adapter.accounts.GetCode(codeHash)
+}
+
+// RootHash returns the root hash of the underlying accounts adapter
+func (adapter *dryRunAccountsAdapter) RootHash() ([]byte, error) {
+	return adapter.accounts.RootHash()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (adapter *dryRunAccountsAdapter) IsInterfaceNil() bool {
+	return adapter == nil
+}
+
+// dryRunAccount is a copy-on-write view of a user account. The fields are copied when the view is created and
+// the data trie writes are kept apart, the wrapped account is only read.
+type dryRunAccount struct {
+	account         vmcommon.UserAccountHandler
+	nonce           uint64
+	balance         *big.Int
+	developerReward *big.Int
+	codeMetadata    []byte
+	ownerAddress    []byte
+	userName        []byte
+	dataHandler     *dryRunDataHandler
+}
+
+func newDryRunAccount(account vmcommon.UserAccountHandler) *dryRunAccount {
+	return &dryRunAccount{
+		account:         account,
+		nonce:           account.GetNonce(),
+		balance:         big.NewInt(0).Set(vmcommon.ZeroValueIfNil(account.GetBalance())),
+		developerReward: big.NewInt(0).Set(vmcommon.ZeroValueIfNil(account.GetDeveloperReward())),
+		codeMetadata:    cloneBytes(account.GetCodeMetadata()),
+		ownerAddress:    cloneBytes(account.GetOwnerAddress()),
+		userName:        cloneBytes(account.GetUserName()),
+		dataHandler: &dryRunDataHandler{
+			dataHandler: account.AccountDataHandler(),
+			dirtyData:   make(map[string][]byte),
+		},
+	}
+}
+
+// AddressBytes returns the address of the account
+func (view *dryRunAccount) AddressBytes() []byte {
+	return view.account.AddressBytes()
+}
+
+// IncreaseNonce increases the nonce of the view
+func (view *dryRunAccount) IncreaseNonce(nonce uint64) {
+	view.nonce += nonce
+}
+
+// GetNonce returns the nonce of the view
+func (view *dryRunAccount) GetNonce() uint64 {
+	return view.nonce
+}
+
+// GetCodeMetadata returns the code metadata of the view
+func (view *dryRunAccount) GetCodeMetadata() []byte {
+	return view.codeMetadata
+}
+
+// SetCodeMetadata sets the code metadata of the view
+func (view *dryRunAccount) SetCodeMetadata(codeMetadata []byte) {
+	view.codeMetadata = cloneBytes(codeMetadata)
+}
+
+// GetCodeHash returns the code hash of the wrapped account
+func (view *dryRunAccount) GetCodeHash() []byte {
+	return view.account.GetCodeHash()
+}
+
+// GetRootHash returns the root hash of the wrapped account
+func (view *dryRunAccount) GetRootHash() []byte {
+	return view.account.GetRootHash()
+}
+
+// AccountDataHandler returns the copy-on-write view of the account's data
+func (view *dryRunAccount) AccountDataHandler() vmcommon.AccountDataHandler {
+	return view.dataHandler
+}
+
+// AddToBalance adds the value to the balance of the view. It errors if the resulting balance is negative.
+func (view *dryRunAccount) AddToBalance(value *big.Int) error {
+	if value == nil {
+		return ErrNilValue
+	}
+
+	newBalance := big.NewInt(0).Add(view.balance, value)
+	if newBalance.Sign() < 0 {
+		return ErrInsufficientFunds
+	}
+
+	view.balance = newBalance
+	return nil
+}
+
+// GetBalance returns the balance of the view
+func (view *dryRunAccount) GetBalance() *big.Int {
+	return big.NewInt(0).Set(view.balance)
+}
+
+// ClaimDeveloperRewards resets the developer reward of the view. Only the owner can claim the rewards.
+func (view *dryRunAccount) ClaimDeveloperRewards(sender []byte) (*big.Int, error) {
+	if !bytes.Equal(sender, view.ownerAddress) {
+		return nil, ErrOperationNotPermitted
+	}
+
+	oldValue := view.developerReward
+	view.developerReward = big.NewInt(0)
+
+	return oldValue, nil
+}
+
+// GetDeveloperReward returns the developer reward of the view
+func (view *dryRunAccount) GetDeveloperReward() *big.Int {
+	return big.NewInt(0).Set(view.developerReward)
+}
+
+// ChangeOwnerAddress sets the owner address of the view. Only the owner can change it.
+func (view *dryRunAccount) ChangeOwnerAddress(sender []byte, newAddress []byte) error {
+	if !bytes.Equal(sender, view.ownerAddress) {
+		return ErrOperationNotPermitted
+	}
+	if len(newAddress) != len(view.AddressBytes()) {
+		return ErrInvalidAddressLength
+	}
+
+	view.ownerAddress = cloneBytes(newAddress)
+
+	return nil
+}
+
+// SetOwnerAddress sets the owner address of the view
+func (view *dryRunAccount) SetOwnerAddress(address []byte) {
+	view.ownerAddress = cloneBytes(address)
+}
+
+// GetOwnerAddress returns the owner address of the view
+func (view *dryRunAccount) GetOwnerAddress() []byte {
+	return view.ownerAddress
+}
+
+// SetUserName sets the user name of the view
+func (view *dryRunAccount) SetUserName(userName []byte) {
+	view.userName = cloneBytes(userName)
+}
+
+// GetUserName returns the user name of the view
+func (view *dryRunAccount) GetUserName() []byte {
+	return view.userName
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (view *dryRunAccount) IsInterfaceNil() bool {
+	return view == nil
+}
+
+type dryRunDataHandler struct {
+	dataHandler vmcommon.AccountDataHandler
+	dirtyData   map[string][]byte
+}
+
+// RetrieveValue returns the value written during the dry run or, if none, the value of the wrapped data handler
+func (handler *dryRunDataHandler) RetrieveValue(key []byte) ([]byte, uint32, error) {
+	value, found := handler.dirtyData[string(key)]
+	if found {
+		return cloneBytes(value), 0, nil
+	}
+
+	return handler.dataHandler.RetrieveValue(key)
+}
+
+// SaveKeyValue keeps the value in the view
+func (handler *dryRunDataHandler) SaveKeyValue(key []byte, value []byte) error {
+	handler.dirtyData[string(key)] = cloneBytes(value)
+
+	return nil
+}
+
+// MigrateDataTrieLeaves does nothing, as the migration can not be done without changing the data trie
+func (handler *dryRunDataHandler) MigrateDataTrieLeaves(_ vmcommon.ArgsMigrateDataTrieLeaves) error {
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (handler *dryRunDataHandler) IsInterfaceNil() bool {
+	return handler == nil
+}
+
+func cloneBytes(buff []byte) []byte {
+	if buff == nil {
+		return nil
+	}
+
+	return append(make([]byte, 0, len(buff)), buff...)
+}
@@ -0,0 +1,266 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"math/big"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core"
+	"github.com/subrahamanyam341/andes-core-16/data/dct"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+	"github.com/subrahamanyam341/andes-vm-common-123/mock"
+)
+
+type userAccountWithDeveloperReward interface {
+	vmcommon.UserAccountHandler
+	AddToDeveloperReward(value *big.Int)
+}
+
+func TestDryRunAccount(t *testing.T) {
+	t.Parallel()
+
+	owner := bytes.Repeat([]byte{1}, 32)
+	newOwner := bytes.Repeat([]byte{2}, 32)
+	accounts := createMockBuiltInFunctionsProcessorArgs().Accounts
+	loaded, _ := accounts.LoadAccount(bytes.Repeat([]byte{3}, 32))
+	account := loaded.(userAccountWithDeveloperReward)
+	_ = account.AddToBalance(big.NewInt(10))
+	account.AddToDeveloperReward(big.NewInt(5))
+	account.SetOwnerAddress(owner)
+	_ = account.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("value"))
+
+	view := newDryRunContext().userAccount(account)
+
+	assert.Equal(t, ErrInsufficientFunds, view.AddToBalance(big.NewInt(-11)))
+	assert.Nil(t, view.AddToBalance(big.NewInt(-4)))
+	assert.Equal(t, big.NewInt(6), view.GetBalance())
+
+	_, err := view.ClaimDeveloperRewards(newOwner)
+	assert.Equal(t, ErrOperationNotPermitted, err)
+	rewards, err := view.ClaimDeveloperRewards(owner)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(5), rewards)
+
+	assert.Equal(t, ErrInvalidAddressLength, view.ChangeOwnerAddress(owner, []byte("short")))
+	assert.Nil(t, view.ChangeOwnerAddress(owner, newOwner))
+	assert.Equal(t, newOwner, view.GetOwnerAddress())
+
+	view.IncreaseNonce(1)
+	view.SetUserName([]byte("user"))
+	view.SetCodeMetadata([]byte{1, 2})
+	_ = view.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("new value"))
+	value, _, _ := view.AccountDataHandler().RetrieveValue([]byte("key"))
+	assert.Equal(t, []byte("new value"), value)
+
+	assert.Equal(t, big.NewInt(10), account.GetBalance())
+	assert.Equal(t, big.NewInt(5), account.GetDeveloperReward())
+	assert.Equal(t, owner, account.GetOwnerAddress())
+	assert.Equal(t, uint64(0), account.GetNonce())
+	assert.Nil(t, account.GetUserName())
+	assert.Nil(t, account.GetCodeMetadata())
+	value, _, _ = account.AccountDataHandler().RetrieveValue([]byte("key"))
+	assert.Equal(t, []byte("value"), value)
+}
+
+func TestDryRunContext(t *testing.T) {
+	t.Parallel()
+
+	t.Run("same address should return the same view", func(t *testing.T) {
+		t.Parallel()
+
+		address := []byte("address")
+		accounts := &mock.AccountsStub{
+			LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+				return mock.NewUserAccount(address), nil
+			},
+			SaveAccountCalled: func(_ vmcommon.AccountHandler) error {
+				assert.Fail(t, "should have not saved the account")
+				return nil
+			},
+		}
+
+		ctx := newDryRunContext()
+		view := ctx.userAccount(mock.NewUserAccount(address))
+		adapter := ctx.accountsAdapter(accounts)
+		loaded, err := adapter.LoadAccount(address)
+		assert.Nil(t, err)
+		assert.True(t, view == loaded)
+		assert.Nil(t, adapter.SaveAccount(loaded))
+
+		other, err := adapter.LoadAccount([]byte("other"))
+		assert.Nil(t, err)
+		assert.True(t, other == ctx.userAccount(mock.NewUserAccount([]byte("other"))))
+	})
+	t.Run("nil account should return nil", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Nil(t, newDryRunContext().userAccount(nil))
+	})
+	t.Run("unknown storage handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		handler, err := newDryRunContext().storageHandler(&mock.DCTNFTStorageHandlerStub{})
+		assert.Nil(t, handler)
+		assert.Equal(t, ErrDryRunNotSupported, err)
+	})
+}
+
+func TestCheckIsExecutable_AllBuiltInFunctionsShouldImplementIt(t *testing.T) {
+	t.Parallel()
+
+	creator, _ := NewBuiltInFunctionsCreator(createMockArguments())
+	require.Nil(t, creator.CreateBuiltInFunctionContainer())
+
+	container := creator.BuiltInFunctionContainer()
+	for key := range container.Keys() {
+		function, _ := container.Get(key)
+		_, ok := function.(vmcommon.ExecutableChecker)
+		assert.True(t, ok, "%s does not implement ExecutableChecker", key)
+	}
+}
+
+func TestDryRunCopy_ShouldKeepAllTheFields(t *testing.T) {
+	t.Parallel()
+
+	creator, _ := NewBuiltInFunctionsCreator(createMockArguments())
+	require.Nil(t, creator.CreateBuiltInFunctionContainer())
+	require.Nil(t, creator.SetPayableHandler(&mock.PayableHandlerStub{}))
+
+	dryRunCopy := func(function vmcommon.BuiltinFunction, ctx *dryRunContext) (interface{}, error) {
+		switch fn := function.(type) {
+		case *dctFreezeWipe:
+			return fn.dryRunCopy(ctx)
+		case *dctTransferAddress:
+			return fn.dryRunCopy(ctx), nil
+		case *dctNFTAddQuantity:
+			return fn.dryRunCopy(ctx)
+		case *dctNFTupdate:
+			return fn.dryRunCopy(ctx)
+		case *dctNFTTransfer:
+			return fn.dryRunCopy(ctx)
+		case *dctNFTCreate:
+			return fn.dryRunCopy(ctx)
+		case *dctNFTAddUri:
+			return fn.dryRunCopy(ctx)
+		case *dctNFTCreateRoleTransfer:
+			return fn.dryRunCopy(ctx), nil
+		case *dctNFTMultiTransfer:
+			return fn.dryRunCopy(ctx)
+		case *dctDeleteMetaData:
+			return fn.dryRunCopy(ctx), nil
+		case *dctNFTBurn:
+			return fn.dryRunCopy(ctx)
+		case *migrateDataTrie:
+			return fn.dryRunCopy(ctx), nil
+		case *dctGlobalSettings:
+			return fn.dryRunCopy(ctx), nil
+		default:
+			return nil, nil
+		}
+	}
+
+	numCopied := 0
+	container := creator.BuiltInFunctionContainer()
+	for key := range container.Keys() {
+		function, _ := container.Get(key)
+		copied, err := dryRunCopy(function, newDryRunContext())
+		require.Nil(t, err, key)
+		if copied == nil {
+			continue
+		}
+
+		numCopied++
+		original := reflect.ValueOf(function).Elem()
+		copiedValue := reflect.ValueOf(copied).Elem()
+		for i := 0; i < original.NumField(); i++ {
+			field := original.Type().Field(i)
+			if field.Type == reflect.TypeOf(sync.RWMutex{}) {
+				continue
+			}
+			// the fields set by the constructor should also be set on the copy
+			if !original.Field(i).IsZero() {
+				assert.False(t, copiedValue.Field(i).IsZero(), "%s: field %s not copied", key, field.Name)
+			}
+		}
+	}
+	assert.Greater(t, numCopied, 0)
+}
+
+func TestCheckIsExecutable_ShouldReturnTheExecutionErrorWithoutChangingTheState(t *testing.T) {
+	t.Parallel()
+
+	marshaller := &mock.MarshalizerMock{}
+	processorArgs := createMockBuiltInFunctionsProcessorArgs()
+	creatorArgs := createMockArguments()
+	creatorArgs.Accounts = processorArgs.Accounts
+	creatorArgs.Marshalizer = marshaller
+	creatorArgs.EnableEpochsHandler = &mock.EnableEpochsHandlerStub{
+		IsWipeSingleNFTLiquidityDecreaseEnabledField: true,
+	}
+	creatorArgs.MapDNSAddresses = map[string]struct{}{string(bytes.Repeat([]byte{3}, 32)): {}}
+	creator, _ := NewBuiltInFunctionsCreator(creatorArgs)
+	require.Nil(t, creator.CreateBuiltInFunctionContainer())
+	require.Nil(t, creator.SetPayableHandler(&mock.PayableHandlerStub{}))
+	processorArgs.BuiltInFunctions = creator.BuiltInFunctionContainer()
+	bfp, _ := NewBuiltInFunctionsProcessor(processorArgs)
+
+	tokenID := []byte("TOKEN-abcdef")
+	nftID := []byte("NFT-abcdef")
+	sender := bytes.Repeat([]byte{1}, 32)
+	receiver := bytes.Repeat([]byte{2}, 32)
+	dnsAddress := bytes.Repeat([]byte{3}, 32)
+
+	account, _ := processorArgs.Accounts.LoadAccount(sender)
+	dctData := &dct.DCToken{Value: big.NewInt(100), Type: uint32(core.Fungible)}
+	require.Nil(t, saveDCTData(account.(vmcommon.UserAccountHandler), dctData, append([]byte(baseDCTKeyPrefix), tokenID...), marshaller))
+	require.Nil(t, processorArgs.Accounts.SaveAccount(account))
+	_, _ = processorArgs.Accounts.Commit()
+
+	createInput := func(function string, caller []byte, recipient []byte, args ...[]byte) *vmcommon.ContractCallInput {
+		input := createBuiltInFunctionsProcessorInput(function, caller, recipient)
+		input.GasProvided = 100000
+		input.Arguments = args
+		return input
+	}
+	nftCreateArgs := [][]byte{nftID, big.NewInt(1).Bytes(), []byte("name"), big.NewInt(10).Bytes(), []byte("hash"), []byte("attributes"), []byte("uri")}
+
+	// the inputs are executed in order, each one on the state left by the previous ones
+	inputs := []struct {
+		name        string
+		input       *vmcommon.ContractCallInput
+		shouldError bool
+	}{
+		{"transfer", createInput(core.BuiltInFunctionDCTTransfer, sender, receiver, tokenID, big.NewInt(40).Bytes()), false},
+		{"transfer more than the balance", createInput(core.BuiltInFunctionDCTTransfer, sender, receiver, tokenID, big.NewInt(61).Bytes()), true},
+		{"transfer with wrong arguments", createInput(core.BuiltInFunctionDCTTransfer, sender, receiver, tokenID), true},
+		{"NFT create without role", createInput(core.BuiltInFunctionDCTNFTCreate, sender, sender, nftCreateArgs...), true},
+		{"set NFT create role", createInput(core.BuiltInFunctionSetDCTRole, core.DCTSCAddress, sender, nftID, []byte(core.DCTRoleNFTCreate)), false},
+		{"set role not from the system SC", createInput(core.BuiltInFunctionSetDCTRole, receiver, sender, nftID, []byte(core.DCTRoleNFTCreate)), true},
+		{"NFT create", createInput(core.BuiltInFunctionDCTNFTCreate, sender, sender, nftCreateArgs...), false},
+		{"wipe not frozen", createInput(core.BuiltInFunctionDCTWipe, core.DCTSCAddress, receiver, tokenID), true},
+		{"freeze", createInput(core.BuiltInFunctionDCTFreeze, core.DCTSCAddress, receiver, tokenID), false},
+		{"transfer from frozen", createInput(core.BuiltInFunctionDCTTransfer, receiver, sender, tokenID, big.NewInt(1).Bytes()), true},
+		{"wipe", createInput(core.BuiltInFunctionDCTWipe, core.DCTSCAddress, receiver, tokenID), false},
+		{"set user name not from DNS", createInput(core.BuiltInFunctionSetUserName, receiver, sender, []byte("name")), true},
+		{"set user name", createInput(core.BuiltInFunctionSetUserName, dnsAddress, sender, []byte("name")), false},
+		{"set user name twice", createInput(core.BuiltInFunctionSetUserName, dnsAddress, sender, []byte("other")), true},
+	}
+
+	for _, tt := range inputs {
+		rootHashBefore, _ := processorArgs.Accounts.RootHash()
+
+		errCheck := bfp.CheckIsExecutable(tt.input)
+		rootHashAfterCheck, _ := processorArgs.Accounts.RootHash()
+		assert.Equal(t, rootHashBefore, rootHashAfterCheck, tt.name)
+		assert.Equal(t, 0, processorArgs.Accounts.JournalLen(), tt.name)
+
+		_, errProcess := bfp.ProcessBuiltInFunction(tt.input)
+		assert.Equal(t, errProcess, errCheck, tt.name)
+		assert.Equal(t, tt.shouldError, errCheck != nil, tt.name)
+		_, _ = processorArgs.Accounts.Commit()
+	}
+}
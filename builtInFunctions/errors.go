@@ -1,210 +1,245 @@
 package builtInFunctions
 
-import (
-	"errors"
-)
-
 // ErrNilAccountsAdapter defines the error when trying to use a nil AccountsAddapter
-var ErrNilAccountsAdapter = errors.New("nil AccountsAdapter")
+var ErrNilAccountsAdapter = newBuiltInError(1, ErrorCategoryInternal, "nil AccountsAdapter")
 
 // ErrInsufficientFunds signals the funds are insufficient for the move balance operation but the
 // transaction fee is covered by the current balance
-var ErrInsufficientFunds = errors.New("insufficient funds")
+var ErrInsufficientFunds = newBuiltInError(2, ErrorCategoryOutOfFunds, "insufficient funds")
 
 // ErrNilValue signals the value is nil
-var ErrNilValue = errors.New("nil value")
+var ErrNilValue = newBuiltInError(3, ErrorCategoryInvalidArguments, "nil value")
 
 // ErrNilMarshalizer signals that an operation has been attempted to or with a nil Marshalizer implementation
-var ErrNilMarshalizer = errors.New("nil Marshalizer")
+var ErrNilMarshalizer = newBuiltInError(4, ErrorCategoryInternal, "nil Marshalizer")
 
 // ErrInvalidRcvAddr signals that an invalid receiver address was provided
-var ErrInvalidRcvAddr = errors.New("invalid receiver address")
+var ErrInvalidRcvAddr = newBuiltInError(5, ErrorCategoryInvalidArguments, "invalid receiver address")
 
 // ErrNegativeValue signals that a negative value has been detected and it is not allowed
-var ErrNegativeValue = errors.New("negative value")
+var ErrNegativeValue = newBuiltInError(6, ErrorCategoryInvalidArguments, "negative value")
 
 // ErrNilShardCoordinator signals that an operation has been attempted to or with a nil shard coordinator
-var ErrNilShardCoordinator = errors.New("nil shard coordinator")
+var ErrNilShardCoordinator = newBuiltInError(7, ErrorCategoryInternal, "nil shard coordinator")
 
 // ErrWrongTypeAssertion signals that an type assertion failed
-var ErrWrongTypeAssertion = errors.New("wrong type assertion")
+var ErrWrongTypeAssertion = newBuiltInError(8, ErrorCategoryInternal, "wrong type assertion")
 
 // ErrNilSCDestAccount signals that destination account is nil
-var ErrNilSCDestAccount = errors.New("nil destination SC account")
+var ErrNilSCDestAccount = newBuiltInError(9, ErrorCategoryInvalidState, "nil destination SC account")
 
 // ErrNotEnoughGas signals that not enough gas has been provided
-var ErrNotEnoughGas = errors.New("not enough gas was sent in the transaction")
+var ErrNotEnoughGas = newBuiltInError(10, ErrorCategoryOutOfGas, "not enough gas was sent in the transaction")
 
 // ErrInvalidArguments signals that invalid arguments were given to process built-in function
-var ErrInvalidArguments = errors.New("invalid arguments to process built-in function")
+var ErrInvalidArguments = newBuiltInError(11, ErrorCategoryInvalidArguments, "invalid arguments to process built-in function")
 
 // ErrOperationNotPermitted signals that operation is not permitted
-var ErrOperationNotPermitted = errors.New("operation in account not permitted")
+var ErrOperationNotPermitted = newBuiltInError(12, ErrorCategoryNotPermitted, "operation in account not permitted")
 
 // ErrInvalidAddressLength signals that address length is invalid
-var ErrInvalidAddressLength = errors.New("invalid address length")
+var ErrInvalidAddressLength = newBuiltInError(13, ErrorCategoryInvalidArguments, "invalid address length")
 
 // ErrNilVmInput signals that provided vm input is nil
-var ErrNilVmInput = errors.New("nil vm input")
+var ErrNilVmInput = newBuiltInError(14, ErrorCategoryInternal, "nil vm input")
 
 // ErrNilDnsAddresses signals that nil dns addresses map was provided
-var ErrNilDnsAddresses = errors.New("nil dns addresses map")
+var ErrNilDnsAddresses = newBuiltInError(15, ErrorCategoryInternal, "nil dns addresses map")
 
 // ErrCallerIsNotTheDNSAddress signals that called address is not the DNS address
-var ErrCallerIsNotTheDNSAddress = errors.New("not a dns address")
+var ErrCallerIsNotTheDNSAddress = newBuiltInError(16, ErrorCategoryNotPermitted, "not a dns address")
 
 // ErrUserNameChangeIsDisabled signals the user name change is not allowed
-var ErrUserNameChangeIsDisabled = errors.New("user name change is disabled")
+var ErrUserNameChangeIsDisabled = newBuiltInError(17, ErrorCategoryNotPermitted, "user name change is disabled")
 
 // ErrBuiltInFunctionCalledWithValue signals that builtin function was called with value that is not allowed
-var ErrBuiltInFunctionCalledWithValue = errors.New("built in function called with tx value is not allowed")
+var ErrBuiltInFunctionCalledWithValue = newBuiltInError(18, ErrorCategoryNotPermitted, "built in function called with tx value is not allowed")
 
 // ErrAccountNotPayable will be sent when trying to send tokens to a non-payableCheck account
-var ErrAccountNotPayable = errors.New("sending value to non payable contract")
+var ErrAccountNotPayable = newBuiltInError(19, ErrorCategoryNotPermitted, "sending value to non payable contract")
 
 // ErrNilUserAccount signals that nil user account was provided
-var ErrNilUserAccount = errors.New("nil user account")
+var ErrNilUserAccount = newBuiltInError(20, ErrorCategoryInvalidState, "nil user account")
 
 // ErrAddressIsNotDCTSystemSC signals that destination is not a system sc address
-var ErrAddressIsNotDCTSystemSC = errors.New("destination is not system sc address")
+var ErrAddressIsNotDCTSystemSC = newBuiltInError(21, ErrorCategoryNotPermitted, "destination is not system sc address")
 
 // ErrOnlySystemAccountAccepted signals that only system account is accepted
-var ErrOnlySystemAccountAccepted = errors.New("only system account is accepted")
+var ErrOnlySystemAccountAccepted = newBuiltInError(22, ErrorCategoryNotPermitted, "only system account is accepted")
 
 // ErrNilGlobalSettingsHandler signals that nil pause handler has been provided
-var ErrNilGlobalSettingsHandler = errors.New("nil pause handler")
+var ErrNilGlobalSettingsHandler = newBuiltInError(23, ErrorCategoryInternal, "nil pause handler")
 
 // ErrNilRolesHandler signals that nil roles handler has been provided
-var ErrNilRolesHandler = errors.New("nil roles handler")
+var ErrNilRolesHandler = newBuiltInError(24, ErrorCategoryInternal, "nil roles handler")
 
 // ErrDCTTokenIsPaused signals that dct token is paused
-var ErrDCTTokenIsPaused = errors.New("dct token is paused")
+var ErrDCTTokenIsPaused = newBuiltInError(25, ErrorCategoryNotPermitted, "dct token is paused")
 
 // ErrDCTIsFrozenForAccount signals that account is frozen for given dct token
-var ErrDCTIsFrozenForAccount = errors.New("account is frozen for this dct token")
+var ErrDCTIsFrozenForAccount = newBuiltInError(26, ErrorCategoryNotPermitted, "account is frozen for this dct token")
 
 // ErrCannotWipeAccountNotFrozen signals that account isn't frozen so the wipe is not possible
-var ErrCannotWipeAccountNotFrozen = errors.New("cannot wipe because the account is not frozen for this dct token")
+var ErrCannotWipeAccountNotFrozen = newBuiltInError(27, ErrorCategoryInvalidState, "cannot wipe because the account is not frozen for this dct token")
 
 // ErrNilPayableHandler signals that nil payableHandler was provided
-var ErrNilPayableHandler = errors.New("nil payableHandler was provided")
+var ErrNilPayableHandler = newBuiltInError(28, ErrorCategoryInternal, "nil payableHandler was provided")
 
 // ErrActionNotAllowed signals that action is not allowed
-var ErrActionNotAllowed = errors.New("action is not allowed")
+var ErrActionNotAllowed = newBuiltInError(29, ErrorCategoryNotPermitted, "action is not allowed")
 
 // ErrOnlyFungibleTokensHaveBalanceTransfer signals that only fungible tokens have balance transfer
-var ErrOnlyFungibleTokensHaveBalanceTransfer = errors.New("only fungible tokens have balance transfer")
+var ErrOnlyFungibleTokensHaveBalanceTransfer = newBuiltInError(30, ErrorCategoryInvalidArguments, "only fungible tokens have balance transfer")
 
 // ErrNFTTokenDoesNotExist signals that NFT token does not exist
-var ErrNFTTokenDoesNotExist = errors.New("NFT token does not exist")
+var ErrNFTTokenDoesNotExist = newBuiltInError(31, ErrorCategoryInvalidState, "NFT token does not exist")
 
 // ErrNFTDoesNotHaveMetadata signals that NFT does not have metadata
-var ErrNFTDoesNotHaveMetadata = errors.New("NFT does not have metadata")
+var ErrNFTDoesNotHaveMetadata = newBuiltInError(32, ErrorCategoryInvalidState, "NFT does not have metadata")
 
 // ErrInvalidNFTQuantity signals that invalid NFT quantity was provided
-var ErrInvalidNFTQuantity = errors.New("invalid NFT quantity")
+var ErrInvalidNFTQuantity = newBuiltInError(33, ErrorCategoryInvalidArguments, "invalid NFT quantity")
 
 // ErrNewNFTDataOnSenderAddress signals that a new NFT data was found on the sender address
-var ErrNewNFTDataOnSenderAddress = errors.New("new NFT data on sender")
+var ErrNewNFTDataOnSenderAddress = newBuiltInError(34, ErrorCategoryInvalidState, "new NFT data on sender")
 
 // ErrNilContainerElement signals when trying to add a nil element in the container
-var ErrNilContainerElement = errors.New("element cannot be nil")
+var ErrNilContainerElement = newBuiltInError(35, ErrorCategoryInternal, "element cannot be nil")
 
 // ErrInvalidContainerKey signals that an element does not exist in the container's map
-var ErrInvalidContainerKey = errors.New("element does not exist in container")
+var ErrInvalidContainerKey = newBuiltInError(36, ErrorCategoryFunctionNotFound, "element does not exist in container")
 
 // ErrContainerKeyAlreadyExists signals that an element was already set in the container's map
-var ErrContainerKeyAlreadyExists = errors.New("provided key already exists in container")
+var ErrContainerKeyAlreadyExists = newBuiltInError(37, ErrorCategoryInternal, "provided key already exists in container")
 
 // ErrWrongTypeInContainer signals that a wrong type of object was found in container
-var ErrWrongTypeInContainer = errors.New("wrong type of object inside container")
+var ErrWrongTypeInContainer = newBuiltInError(38, ErrorCategoryInternal, "wrong type of object inside container")
 
 // ErrEmptyFunctionName signals that an empty function name has been provided
-var ErrEmptyFunctionName = errors.New("empty function name")
+var ErrEmptyFunctionName = newBuiltInError(39, ErrorCategoryInternal, "empty function name")
 
 // ErrInsufficientQuantityDCT signals the funds are insufficient for the DCT transfer
-var ErrInsufficientQuantityDCT = errors.New("insufficient quantity")
+var ErrInsufficientQuantityDCT = newBuiltInError(40, ErrorCategoryOutOfFunds, "insufficient quantity")
 
 // ErrNilDCTNFTStorageHandler signals that a nil nft storage handler has been provided
-var ErrNilDCTNFTStorageHandler = errors.New("nil dct nft storage handler")
+var ErrNilDCTNFTStorageHandler = newBuiltInError(41, ErrorCategoryInternal, "nil dct nft storage handler")
 
 // ErrNilTransactionHandler signals that a nil transaction handler has been provided
-var ErrNilTransactionHandler = errors.New("nil transaction handler")
+var ErrNilTransactionHandler = newBuiltInError(42, ErrorCategoryInternal, "nil transaction handler")
 
 // ErrAddressIsNotAllowed signals that sender is not allowed to do the action
-var ErrAddressIsNotAllowed = errors.New("address is not allowed to do the action")
+var ErrAddressIsNotAllowed = newBuiltInError(43, ErrorCategoryNotPermitted, "address is not allowed to do the action")
 
 // ErrInvalidNumOfArgs signals that the number of arguments is invalid
-var ErrInvalidNumOfArgs = errors.New("invalid number of arguments")
+var ErrInvalidNumOfArgs = newBuiltInError(44, ErrorCategoryWrongSignature, "invalid number of arguments")
 
 // ErrInvalidNonce signals that invalid nonce for dct
-var ErrInvalidNonce = errors.New("invalid nonce for dct")
+var ErrInvalidNonce = newBuiltInError(45, ErrorCategoryInvalidArguments, "invalid nonce for dct")
 
 // ErrTokenHasValidMetadata signals that token has a valid metadata
-var ErrTokenHasValidMetadata = errors.New("token has valid metadata")
+var ErrTokenHasValidMetadata = newBuiltInError(46, ErrorCategoryInvalidState, "token has valid metadata")
 
 // ErrInvalidTokenID signals that invalid tokenID was provided
-var ErrInvalidTokenID = errors.New("invalid tokenID")
+var ErrInvalidTokenID = newBuiltInError(47, ErrorCategoryInvalidArguments, "invalid tokenID")
 
 // ErrNilDCTData signals that DCT data does not exist
-var ErrNilDCTData = errors.New("nil dct data")
+var ErrNilDCTData = newBuiltInError(48, ErrorCategoryInternal, "nil dct data")
 
 // ErrInvalidMetadata signals that invalid metadata was provided
-var ErrInvalidMetadata = errors.New("invalid metadata")
+var ErrInvalidMetadata = newBuiltInError(49, ErrorCategoryInvalidArguments, "invalid metadata")
 
 // ErrInvalidLiquidityForDCT signals that liquidity is invalid for DCT
-var ErrInvalidLiquidityForDCT = errors.New("invalid liquidity for DCT")
+var ErrInvalidLiquidityForDCT = newBuiltInError(50, ErrorCategoryInvalidState, "invalid liquidity for DCT")
 
 // ErrTooManyTransferAddresses signals that too many transfer address roles has been added
-var ErrTooManyTransferAddresses = errors.New("too many transfer addresses")
+var ErrTooManyTransferAddresses = newBuiltInError(51, ErrorCategoryInvalidArguments, "too many transfer addresses")
 
 // ErrInvalidMaxNumAddresses signals that there is an invalid max number of addresses
-var ErrInvalidMaxNumAddresses = errors.New("invalid max number of addresses")
+var ErrInvalidMaxNumAddresses = newBuiltInError(52, ErrorCategoryInternal, "invalid max number of addresses")
 
 // ErrNilEnableEpochsHandler signals that a nil enable epochs handler was provided
-var ErrNilEnableEpochsHandler = errors.New("nil enable epochs handler")
+var ErrNilEnableEpochsHandler = newBuiltInError(53, ErrorCategoryInternal, "nil enable epochs handler")
 
 // ErrNilActiveHandler signals that a nil active handler has been provided
-var ErrNilActiveHandler = errors.New("nil active handler")
+var ErrNilActiveHandler = newBuiltInError(54, ErrorCategoryInternal, "nil active handler")
 
 // ErrInvalidNumberOfArguments signals that an invalid number of arguments has been provided
-var ErrInvalidNumberOfArguments = errors.New("invalid number of arguments")
+var ErrInvalidNumberOfArguments = newBuiltInError(55, ErrorCategoryWrongSignature, "invalid number of arguments")
 
 // ErrInvalidAddress signals that an invalid address has been provided
-var ErrInvalidAddress = errors.New("invalid address")
+var ErrInvalidAddress = newBuiltInError(56, ErrorCategoryInvalidArguments, "invalid address")
 
 // ErrCannotSetOwnAddressAsGuardian signals that an owner cannot set its own address as guardian
-var ErrCannotSetOwnAddressAsGuardian = errors.New("cannot set own address as guardian")
+var ErrCannotSetOwnAddressAsGuardian = newBuiltInError(57, ErrorCategoryNotPermitted, "cannot set own address as guardian")
 
 // ErrOwnerAlreadyHasOneGuardianPending signals that an owner already has one guardian pending
-var ErrOwnerAlreadyHasOneGuardianPending = errors.New("owner already has one guardian pending")
+var ErrOwnerAlreadyHasOneGuardianPending = newBuiltInError(58, ErrorCategoryInvalidState, "owner already has one guardian pending")
 
 // ErrGuardianAlreadyExists signals that a guardian with the same address already exists
-var ErrGuardianAlreadyExists = errors.New("a guardian with the same address already exists")
+var ErrGuardianAlreadyExists = newBuiltInError(59, ErrorCategoryInvalidState, "a guardian with the same address already exists")
 
 // ErrNoGuardianEnabled signals that account has no guardian enabled
-var ErrNoGuardianEnabled = errors.New("account has no guardian enabled")
+var ErrNoGuardianEnabled = newBuiltInError(60, ErrorCategoryInvalidState, "account has no guardian enabled")
 
 // ErrSetGuardAccountFlag signals that an account is already guarded when trying to guard it
-var ErrSetGuardAccountFlag = errors.New("cannot guard account, it is already guarded")
+var ErrSetGuardAccountFlag = newBuiltInError(61, ErrorCategoryInvalidState, "cannot guard account, it is already guarded")
 
 // ErrSetUnGuardAccount signals that an account is already unguarded when trying to un-guard it
-var ErrSetUnGuardAccount = errors.New("cannot un-guard account, it is not guarded")
+var ErrSetUnGuardAccount = newBuiltInError(62, ErrorCategoryInvalidState, "cannot un-guard account, it is not guarded")
 
 // ErrNilAccountHandler signals that a nil account handler has been provided
-var ErrNilAccountHandler = errors.New("nil account handler provided")
+var ErrNilAccountHandler = newBuiltInError(63, ErrorCategoryInternal, "nil account handler provided")
 
 // ErrNilGuardedAccountHandler signals that a nil guarded account handler was provided
-var ErrNilGuardedAccountHandler = errors.New("nil guarded account handler")
+var ErrNilGuardedAccountHandler = newBuiltInError(64, ErrorCategoryInternal, "nil guarded account handler")
 
 // ErrInvalidServiceUID signals that an invalid service UID was provided
-var ErrInvalidServiceUID = errors.New("service UID is invalid")
+var ErrInvalidServiceUID = newBuiltInError(65, ErrorCategoryInvalidArguments, "service UID is invalid")
 
 // ErrCannotMigrateNilUserName signals that a nil username is migrated
-var ErrCannotMigrateNilUserName = errors.New("cannot migrate nil username")
+var ErrCannotMigrateNilUserName = newBuiltInError(66, ErrorCategoryInvalidState, "cannot migrate nil username")
 
 // ErrWrongUserNameSplit signals that user name split is wrong
-var ErrWrongUserNameSplit = errors.New("wrong user name split")
+var ErrWrongUserNameSplit = newBuiltInError(67, ErrorCategoryInvalidState, "wrong user name split")
 
 // ErrUserNamePrefixNotEqual signals that user name prefix is not equal
-var ErrUserNamePrefixNotEqual = errors.New("user name prefix is not equal")
+var ErrUserNamePrefixNotEqual = newBuiltInError(68, ErrorCategoryInvalidState, "user name prefix is not equal")
+
+// ErrNilBuiltInFunctionsContainer signals that a nil built-in functions container was provided
+var ErrNilBuiltInFunctionsContainer = newBuiltInError(69, ErrorCategoryInternal, "nil built-in functions container")
+
+// ErrBuiltInFunctionIsNotActive signals that the built-in function is not active
+var ErrBuiltInFunctionIsNotActive = newBuiltInError(70, ErrorCategoryFunctionNotFound, "built-in function is not active")
+
+// ErrDryRunNotSupported signals that the built-in function can not be executed without changing the state
+var ErrDryRunNotSupported = newBuiltInError(71, ErrorCategoryInternal, "dry run not supported")
+
+// ErrNilGasCost signals that a nil gas cost was provided
+var ErrNilGasCost = newBuiltInError(72, ErrorCategoryInternal, "nil gas cost")
+
+// ErrGasEstimationNotSupported signals that the gas consumed by the built-in function can not be estimated
+var ErrGasEstimationNotSupported = newBuiltInError(73, ErrorCategoryInternal, "gas estimation not supported")
+
+// ErrNilRoundNotifier signals that a nil round notifier was provided
+var ErrNilRoundNotifier = newBuiltInError(74, ErrorCategoryInternal, "nil round notifier")
+
+// ErrBuiltInFunctionCannotBeGated signals that the activation of the built-in function can not be restricted
+var ErrBuiltInFunctionCannotBeGated = newBuiltInError(75, ErrorCategoryInternal, "built-in function activation can not be gated")
+
+// ErrUnknownGasCostField signals that a built-in function is charged with a gas cost field that does not exist
+var ErrUnknownGasCostField = newBuiltInError(76, ErrorCategoryInternal, "unknown gas cost field")
+
+// ErrNilBuiltInFunctionMetricsHandler signals that a nil built-in function metrics handler was provided
+var ErrNilBuiltInFunctionMetricsHandler = newBuiltInError(77, ErrorCategoryInternal, "nil built-in function metrics handler")
+
+// ErrInvalidLatencyBuckets signals that the latency histogram buckets are not positive and strictly increasing
+var ErrInvalidLatencyBuckets = newBuiltInError(78, ErrorCategoryInternal, "invalid latency buckets")
+
+// ErrNilStorageTracer signals that a nil storage tracer was provided
+var ErrNilStorageTracer = newBuiltInError(79, ErrorCategoryInternal, "nil storage tracer")
+
+// ErrReadWriteSetNotSupported signals that the storage locations touched by the built-in function can not be computed
+var ErrReadWriteSetNotSupported = newBuiltInError(80, ErrorCategoryInternal, "read/write set not supported")
+
+// ErrNilBuiltInFunctionsCreator signals that a nil built-in functions creator was provided
+var ErrNilBuiltInFunctionsCreator = newBuiltInError(81, ErrorCategoryInternal, "nil built-in functions creator")
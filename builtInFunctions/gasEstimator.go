@@ -0,0 +1,173 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/subrahamanyam341/andes-core-16/core"
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+)
+
+// GasBreakdown holds the gas consumed by a built-in function call, split by what the gas is paid for
+type GasBreakdown struct {
+	// BaseCost is the fixed cost of the built-in function, from BuiltInCost
+	BaseCost uint64
+	// StoreCost is the cost of the bytes written in the account storage, from BaseOperationCost.StorePerByte
+	StoreCost uint64
+	// PersistCost is the cost of the bytes persisted in the data trie, from BaseOperationCost.PersistPerByte
+	PersistCost uint64
+	// TrieLoadCost is the cost of the data trie nodes loaded by the call, from BuiltInCost.TrieLoadPerNode.
+	// None of the estimated functions charges it for now.
+	TrieLoadCost uint64
+}
+
+// Total returns the gas consumed by the call
+func (breakdown *GasBreakdown) Total() uint64 {
+	return breakdown.BaseCost + breakdown.StoreCost + breakdown.PersistCost + breakdown.TrieLoadCost
+}
+
+// ArgsNewGasEstimator is the argument structure used to create a new gas estimator
+type ArgsNewGasEstimator struct {
+	Accounts vmcommon.AccountsAdapter
+}
+
+type gasEstimator struct {
+	accounts vmcommon.AccountsAdapter
+}
+
+// NewGasEstimator creates a component that computes the gas consumed by a built-in function call, without
+// executing it. The accounts are used to read the storage values that are overwritten by the call.
+func NewGasEstimator(args ArgsNewGasEstimator) (*gasEstimator, error) {
+	if check.IfNil(args.Accounts) {
+		return nil, ErrNilAccountsAdapter
+	}
+
+	return &gasEstimator{
+		accounts: args.Accounts,
+	}, nil
+}
+
+// EstimateGas returns the gas consumed on the sender shard by the built-in function named in the input, using the
+// provided gas cost. The gas forwarded to a smart contract call that follows the built-in function is not included,
+// and neither is the data copy cost of the NFTs sent to another shard as marshalled data, as it depends on the token
+// metadata. The estimation only checks the arguments it needs, the other checks are done by the execution.
+func (ge *gasEstimator) EstimateGas(input *vmcommon.ContractCallInput, gasCost *vmcommon.GasCost) (*GasBreakdown, error) {
+	if input == nil {
+		return nil, ErrNilVmInput
+	}
+	if gasCost == nil {
+		return nil, ErrNilGasCost
+	}
+
+	switch input.Function {
+	case core.BuiltInFunctionDCTTransfer:
+		return estimateDCTTransferGas(input, gasCost)
+	case core.BuiltInFunctionMultiDCTNFTTransfer:
+		return estimateMultiDCTNFTTransferGas(input, gasCost)
+	case core.BuiltInFunctionDCTNFTCreate:
+		return estimateDCTNFTCreateGas(input, gasCost)
+	case core.BuiltInFunctionSaveKeyValue:
+		return ge.estimateSaveKeyValueGas(input, gasCost)
+	default:
+		return nil, fmt.Errorf("%w for function %s", ErrGasEstimationNotSupported, input.Function)
+	}
+}
+
+func estimateDCTTransferGas(input *vmcommon.ContractCallInput, gasCost *vmcommon.GasCost) (*GasBreakdown, error) {
+	if len(input.Arguments) < core.MinLenArgumentsDCTTransfer {
+		return nil, ErrInvalidArguments
+	}
+
+	return &GasBreakdown{
+		BaseCost: gasCost.BuiltInCost.DCTTransfer,
+	}, nil
+}
+
+func estimateMultiDCTNFTTransferGas(input *vmcommon.ContractCallInput, gasCost *vmcommon.GasCost) (*GasBreakdown, error) {
+	if !bytes.Equal(input.CallerAddr, input.RecipientAddr) {
+		// on the destination shard the gas was already paid by the sender
+		return &GasBreakdown{}, nil
+	}
+	if len(input.Arguments) < 4 {
+		return nil, ErrInvalidArguments
+	}
+
+	numOfTransfers := big.NewInt(0).SetBytes(input.Arguments[1]).Uint64()
+	if numOfTransfers == 0 {
+		return nil, fmt.Errorf("%w, 0 tokens to transfer", ErrInvalidArguments)
+	}
+	minNumOfArguments := numOfTransfers*argumentsPerTransfer + 2
+	if uint64(len(input.Arguments)) < minNumOfArguments {
+		return nil, fmt.Errorf("%w, invalid number of arguments", ErrInvalidArguments)
+	}
+
+	return &GasBreakdown{
+		BaseCost: numOfTransfers * gasCost.BuiltInCost.DCTNFTMultiTransfer,
+	}, nil
+}
+
+func estimateDCTNFTCreateGas(input *vmcommon.ContractCallInput, gasCost *vmcommon.GasCost) (*GasBreakdown, error) {
+	if len(input.Arguments) < 7 {
+		return nil, fmt.Errorf("%w, wrong number of arguments", ErrInvalidArguments)
+	}
+
+	totalLength := uint64(0)
+	for _, arg := range input.Arguments {
+		totalLength += uint64(len(arg))
+	}
+
+	return &GasBreakdown{
+		BaseCost:  gasCost.BuiltInCost.DCTNFTCreate,
+		StoreCost: totalLength * gasCost.BaseOperationCost.StorePerByte,
+	}, nil
+}
+
+func (ge *gasEstimator) estimateSaveKeyValueGas(input *vmcommon.ContractCallInput, gasCost *vmcommon.GasCost) (*GasBreakdown, error) {
+	if len(input.Arguments) < 2 || len(input.Arguments)%2 != 0 {
+		return nil, ErrInvalidArguments
+	}
+
+	account, err := ge.accounts.LoadAccount(input.RecipientAddr)
+	if err != nil {
+		return nil, err
+	}
+	userAccount, ok := account.(vmcommon.UserAccountHandler)
+	if !ok {
+		return nil, ErrWrongTypeAssertion
+	}
+
+	// the values are tracked as they would be after each write, so that a key written twice is charged as in the execution
+	newValues := make(map[string][]byte)
+	breakdown := &GasBreakdown{
+		BaseCost: gasCost.BuiltInCost.SaveKeyValue,
+	}
+	for i := 0; i < len(input.Arguments); i += 2 {
+		key := input.Arguments[i]
+		value := input.Arguments[i+1]
+		breakdown.PersistCost += uint64(len(value)+len(key)) * gasCost.BaseOperationCost.PersistPerByte
+
+		oldValue, found := newValues[string(key)]
+		if !found {
+			oldValue, _, err = userAccount.AccountDataHandler().RetrieveValue(key)
+			if core.IsGetNodeFromDBError(err) {
+				return nil, err
+			}
+		}
+		if bytes.Equal(oldValue, value) {
+			continue
+		}
+		if len(oldValue) < len(value) {
+			breakdown.StoreCost += uint64(len(value)-len(oldValue)) * gasCost.BaseOperationCost.StorePerByte
+		}
+		newValues[string(key)] = value
+	}
+
+	return breakdown, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (ge *gasEstimator) IsInterfaceNil() bool {
+	return ge == nil
+}
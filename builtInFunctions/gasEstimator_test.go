@@ -0,0 +1,186 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core"
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	"github.com/subrahamanyam341/andes-core-16/data/dct"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+	"github.com/subrahamanyam341/andes-vm-common-123/mock"
+)
+
+func TestNewGasEstimator(t *testing.T) {
+	t.Parallel()
+
+	ge, err := NewGasEstimator(ArgsNewGasEstimator{})
+	assert.True(t, check.IfNil(ge))
+	assert.Equal(t, ErrNilAccountsAdapter, err)
+
+	ge, err = NewGasEstimator(ArgsNewGasEstimator{Accounts: &mock.AccountsStub{}})
+	assert.False(t, check.IfNil(ge))
+	assert.Nil(t, err)
+}
+
+func TestGasEstimator_EstimateGas(t *testing.T) {
+	t.Parallel()
+
+	gasCost := &vmcommon.GasCost{
+		BaseOperationCost: vmcommon.BaseOperationCost{
+			StorePerByte:   3,
+			PersistPerByte: 5,
+		},
+		BuiltInCost: vmcommon.BuiltInCost{
+			DCTTransfer:         7,
+			DCTNFTMultiTransfer: 11,
+			DCTNFTCreate:        13,
+			SaveKeyValue:        17,
+		},
+	}
+	address := bytes.Repeat([]byte{1}, 32)
+	createInput := func(function string, args ...[]byte) *vmcommon.ContractCallInput {
+		input := createBuiltInFunctionsProcessorInput(function, address, address)
+		input.Arguments = args
+		return input
+	}
+
+	t.Run("invalid input should error", func(t *testing.T) {
+		t.Parallel()
+
+		ge, _ := NewGasEstimator(ArgsNewGasEstimator{Accounts: &mock.AccountsStub{}})
+
+		_, err := ge.EstimateGas(nil, gasCost)
+		assert.Equal(t, ErrNilVmInput, err)
+		_, err = ge.EstimateGas(createInput(core.BuiltInFunctionDCTTransfer), nil)
+		assert.Equal(t, ErrNilGasCost, err)
+		_, err = ge.EstimateGas(createInput(core.BuiltInFunctionDCTBurn), gasCost)
+		assert.True(t, errors.Is(err, ErrGasEstimationNotSupported))
+		_, err = ge.EstimateGas(createInput(core.BuiltInFunctionDCTTransfer, []byte("TOKEN-abcdef")), gasCost)
+		assert.Equal(t, ErrInvalidArguments, err)
+		_, err = ge.EstimateGas(createInput(core.BuiltInFunctionSaveKeyValue, []byte("key")), gasCost)
+		assert.Equal(t, ErrInvalidArguments, err)
+	})
+	t.Run("should compute the breakdown", func(t *testing.T) {
+		t.Parallel()
+
+		account := mock.NewUserAccount(address)
+		_ = account.SaveKeyValue([]byte("key"), []byte("ab"))
+		ge, _ := NewGasEstimator(ArgsNewGasEstimator{Accounts: &mock.AccountsStub{
+			LoadAccountCalled: func(_ []byte) (vmcommon.AccountHandler, error) {
+				return account, nil
+			},
+		}})
+
+		breakdown, err := ge.EstimateGas(createInput(core.BuiltInFunctionDCTTransfer, []byte("TOKEN-abcdef"), []byte{1}), gasCost)
+		assert.Nil(t, err)
+		assert.Equal(t, &GasBreakdown{BaseCost: 7}, breakdown)
+
+		breakdown, err = ge.EstimateGas(createInput(core.BuiltInFunctionMultiDCTNFTTransfer,
+			bytes.Repeat([]byte{2}, 32), big.NewInt(2).Bytes(),
+			[]byte("TOKEN-abcdef"), []byte{0}, []byte{1},
+			[]byte("TOKEN-abcdef"), []byte{0}, []byte{1}), gasCost)
+		assert.Nil(t, err)
+		assert.Equal(t, &GasBreakdown{BaseCost: 22}, breakdown)
+
+		breakdown, err = ge.EstimateGas(createInput(core.BuiltInFunctionDCTNFTCreate,
+			[]byte("NFT-abcdef"), []byte{1}, []byte("name"), []byte{10}, []byte("hash"), []byte("attr"), []byte("uri")), gasCost)
+		assert.Nil(t, err)
+		assert.Equal(t, &GasBreakdown{BaseCost: 13, StoreCost: 27 * 3}, breakdown)
+		assert.Equal(t, uint64(13+27*3), breakdown.Total())
+
+		// the first write grows the value with 3 bytes, the second one does not change it
+		breakdown, err = ge.EstimateGas(createInput(core.BuiltInFunctionSaveKeyValue,
+			[]byte("key"), []byte("abcde"), []byte("key"), []byte("abcde")), gasCost)
+		assert.Nil(t, err)
+		assert.Equal(t, &GasBreakdown{BaseCost: 17, StoreCost: 3 * 3, PersistCost: 16 * 5}, breakdown)
+	})
+}
+
+func TestGasEstimator_ShouldMatchTheExecution(t *testing.T) {
+	t.Parallel()
+
+	marshaller := &mock.MarshalizerMock{}
+	processorArgs := createMockBuiltInFunctionsProcessorArgs()
+	creatorArgs := createMockArguments()
+	creatorArgs.Accounts = processorArgs.Accounts
+	creatorArgs.Marshalizer = marshaller
+	creatorArgs.EnableEpochsHandler = &mock.EnableEpochsHandlerStub{
+		FixGasRemainingForSaveKeyValueBuiltinFunctionEnabledField: true,
+		IsDCTNFTImprovementV1FlagEnabledField:                     true,
+	}
+	// distinct costs, so that a cost used in place of another one is detected
+	creatorArgs.GasMap[core.BaseOperationCostString]["StorePerByte"] = 3
+	creatorArgs.GasMap[core.BaseOperationCostString]["PersistPerByte"] = 5
+	creatorArgs.GasMap[core.BuiltInCostString]["DCTTransfer"] = 7
+	creatorArgs.GasMap[core.BuiltInCostString]["DCTNFTMultiTransfer"] = 11
+	creatorArgs.GasMap[core.BuiltInCostString]["DCTNFTCreate"] = 13
+	creatorArgs.GasMap[core.BuiltInCostString]["SaveKeyValue"] = 17
+	gasCost, err := createGasConfig(creatorArgs.GasMap)
+	require.Nil(t, err)
+
+	creator, _ := NewBuiltInFunctionsCreator(creatorArgs)
+	require.Nil(t, creator.CreateBuiltInFunctionContainer())
+	require.Nil(t, creator.SetPayableHandler(&mock.PayableHandlerStub{}))
+	processorArgs.BuiltInFunctions = creator.BuiltInFunctionContainer()
+	bfp, _ := NewBuiltInFunctionsProcessor(processorArgs)
+	ge, _ := NewGasEstimator(ArgsNewGasEstimator{Accounts: processorArgs.Accounts})
+
+	tokenID := []byte("TOKEN-abcdef")
+	nftID := []byte("NFT-abcdef")
+	sender := bytes.Repeat([]byte{1}, 32)
+	receiver := bytes.Repeat([]byte{2}, 32)
+
+	account, _ := processorArgs.Accounts.LoadAccount(sender)
+	userAccount := account.(vmcommon.UserAccountHandler)
+	dctData := &dct.DCToken{Value: big.NewInt(100), Type: uint32(core.Fungible)}
+	require.Nil(t, saveDCTData(userAccount, dctData, append([]byte(baseDCTKeyPrefix), tokenID...), marshaller))
+	roles := &dct.DCTRoles{Roles: [][]byte{[]byte(core.DCTRoleNFTCreate)}}
+	marshaledRoles, _ := marshaller.Marshal(roles)
+	require.Nil(t, userAccount.AccountDataHandler().SaveKeyValue(append([]byte(core.ProtectedKeyPrefix+core.DCTRoleIdentifier+core.DCTKeyIdentifier), nftID...), marshaledRoles))
+	require.Nil(t, userAccount.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("ab")))
+	require.Nil(t, processorArgs.Accounts.SaveAccount(account))
+	_, _ = processorArgs.Accounts.Commit()
+
+	createInput := func(function string, recipient []byte, args ...[]byte) *vmcommon.ContractCallInput {
+		input := createBuiltInFunctionsProcessorInput(function, sender, recipient)
+		input.Arguments = args
+		return input
+	}
+	inputs := map[string]*vmcommon.ContractCallInput{
+		"transfer": createInput(core.BuiltInFunctionDCTTransfer, receiver, tokenID, big.NewInt(10).Bytes()),
+		"multi transfer": createInput(core.BuiltInFunctionMultiDCTNFTTransfer, sender,
+			receiver, big.NewInt(2).Bytes(),
+			tokenID, []byte{0}, big.NewInt(1).Bytes(),
+			tokenID, []byte{0}, big.NewInt(2).Bytes()),
+		"NFT create": createInput(core.BuiltInFunctionDCTNFTCreate, sender,
+			nftID, big.NewInt(1).Bytes(), []byte("name"), big.NewInt(10).Bytes(), []byte("hash"), []byte("attributes"), []byte("uri")),
+		"save key value": createInput(core.BuiltInFunctionSaveKeyValue, sender,
+			[]byte("key"), []byte("abcde"), []byte("other"), []byte("value"), []byte("key"), []byte("abcde")),
+	}
+
+	for name, input := range inputs {
+		breakdown, errEstimate := ge.EstimateGas(input, gasCost)
+		require.Nil(t, errEstimate, name)
+		estimated := breakdown.Total()
+
+		input.GasProvided = estimated - 1
+		_, err = bfp.ProcessBuiltInFunction(input)
+		assert.Equal(t, ErrNotEnoughGas, err, name)
+
+		input.GasProvided = estimated
+		vmOutput, errProcess := bfp.ProcessBuiltInFunction(input)
+		require.Nil(t, errProcess, name)
+		assert.Equal(t, uint64(0), vmOutput.GasRemaining, name)
+
+		input.GasProvided = estimated + 1000
+		estimatedAgain, _ := ge.EstimateGas(input, gasCost)
+		vmOutput, errProcess = bfp.ProcessBuiltInFunction(input)
+		require.Nil(t, errProcess, name)
+		assert.Equal(t, estimatedAgain.Total(), input.GasProvided-vmOutput.GasRemaining, name)
+	}
+}
@@ -0,0 +1,31 @@
+package builtInFunctions
+
+import "github.com/subrahamanyam341/andes-vm-common-123/gasSchedule"
+
+var _ gasSchedule.GasScheduleSubscriberHandler = (*gasScheduleSubscriber)(nil)
+
+type gasScheduleSubscriber struct {
+	creator *builtInFuncCreator
+}
+
+// NewGasScheduleSubscriber wraps the built-in functions creator, so that it can be registered to the gas schedule
+// notifier
+func NewGasScheduleSubscriber(creator *builtInFuncCreator) (*gasScheduleSubscriber, error) {
+	if creator == nil {
+		return nil, ErrNilBuiltInFunctionsCreator
+	}
+
+	return &gasScheduleSubscriber{
+		creator: creator,
+	}, nil
+}
+
+// GasScheduleChange applies the gas schedule on all the built-in functions, returning an error if it is not valid
+func (subscriber *gasScheduleSubscriber) GasScheduleChange(gasSchedule map[string]map[string]uint64) error {
+	return subscriber.creator.applyGasSchedule(gasSchedule)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (subscriber *gasScheduleSubscriber) IsInterfaceNil() bool {
+	return subscriber == nil
+}
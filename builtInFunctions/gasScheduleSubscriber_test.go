@@ -0,0 +1,52 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core"
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	"github.com/subrahamanyam341/andes-vm-common-123/gasSchedule"
+)
+
+func TestNewGasScheduleSubscriber(t *testing.T) {
+	t.Parallel()
+
+	subscriber, err := NewGasScheduleSubscriber(nil)
+	assert.True(t, check.IfNil(subscriber))
+	assert.Equal(t, ErrNilBuiltInFunctionsCreator, err)
+
+	creator, _ := NewBuiltInFunctionsCreator(createMockArguments())
+	subscriber, err = NewGasScheduleSubscriber(creator)
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(subscriber))
+}
+
+func TestGasScheduleSubscriber_GasScheduleChange(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArguments()
+	creator, _ := NewBuiltInFunctionsCreator(args)
+	subscriber, _ := NewGasScheduleSubscriber(creator)
+
+	fillGasMapInternal(args.GasMap, 5)
+	args.GasMap[core.BuiltInCostString]["ClaimDeveloperRewards"] = 0
+	err := subscriber.GasScheduleChange(args.GasMap)
+	assert.True(t, errors.Is(err, gasSchedule.ErrZeroGasScheduleValue))
+	assert.Equal(t, uint64(1), creator.gasConfig.BuiltInCost.ClaimDeveloperRewards)
+
+	delete(args.GasMap[core.BuiltInCostString], "ClaimDeveloperRewards")
+	delete(args.GasMap[core.BuiltInCostString], "DCTBurn")
+	err = subscriber.GasScheduleChange(args.GasMap)
+	assert.True(t, errors.Is(err, gasSchedule.ErrMissingGasScheduleKey))
+	assert.Contains(t, err.Error(), "BuiltInCost.ClaimDeveloperRewards, BuiltInCost.DCTBurn")
+
+	fillGasMapInternal(args.GasMap, 5)
+	gsn, err := gasSchedule.NewGasScheduleNotifier(gasSchedule.ArgsNewGasScheduleNotifier{GasSchedule: args.GasMap})
+	require.Nil(t, err)
+	err = gsn.RegisterNotifyHandler(subscriber)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(5), creator.gasConfig.BuiltInCost.ClaimDeveloperRewards)
+}
@@ -51,6 +51,24 @@ func (fa *guardAccountFunc) ProcessBuiltinFunction(
 	}, nil
 }
 
+// CheckIsExecutable will check if the guard account built-in function can be executed. The code metadata
+// is changed on a view of the sender account, so the account is not changed.
+func (fa *guardAccountFunc) CheckIsExecutable(
+	acntSnd, _ vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) error {
+	fa.mutExecution.RLock()
+	defer fa.mutExecution.RUnlock()
+
+	err := fa.checkGuardAccountArgs(acntSnd, vmInput)
+	if err != nil {
+		return err
+	}
+
+	ctx := newDryRunContext()
+	return guardAccount(ctx.userAccount(acntSnd))
+}
+
 func guardAccount(account vmcommon.UserAccountHandler) error {
 	codeMetaData := getCodeMetaData(account)
 	if codeMetaData.Guarded {
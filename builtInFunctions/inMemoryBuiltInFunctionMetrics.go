@@ -0,0 +1,167 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+const unknownErrorName = "unknown error"
+
+// DefaultLatencyBuckets are the upper bounds of the latency histogram buckets used when none are provided
+var DefaultLatencyBuckets = []time.Duration{
+	10 * time.Microsecond,
+	50 * time.Microsecond,
+	100 * time.Microsecond,
+	500 * time.Microsecond,
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+}
+
+// ArgsNewInMemoryBuiltInFunctionMetrics is the argument structure used to create the in-memory metrics
+type ArgsNewInMemoryBuiltInFunctionMetrics struct {
+	// LatencyBuckets are the strictly increasing upper bounds of the latency histogram buckets. The histogram has an
+	// additional bucket for the calls slower than the last bound. DefaultLatencyBuckets are used if empty.
+	LatencyBuckets []time.Duration
+}
+
+// BuiltInFunctionStats holds the metrics recorded for a built-in function
+type BuiltInFunctionStats struct {
+	NumCalls  uint64
+	NumErrors uint64
+	// ErrorsBySentinel counts the errors by the message of the built-in error they wrap. The other errors are
+	// counted by the message of the innermost wrapped error.
+	ErrorsBySentinel map[string]uint64
+	TotalGasConsumed uint64
+	TotalOutputSize  uint64
+	TotalDuration    time.Duration
+	// LatencyHistogram counts the calls in each of the latency buckets, the last element counts the slower calls
+	LatencyHistogram []uint64
+}
+
+type inMemoryBuiltInFunctionMetrics struct {
+	latencyBuckets []time.Duration
+	mut            sync.RWMutex
+	stats          map[string]*BuiltInFunctionStats
+}
+
+// NewInMemoryBuiltInFunctionMetrics creates a metrics handler that aggregates the calls in memory, by function
+func NewInMemoryBuiltInFunctionMetrics(args ArgsNewInMemoryBuiltInFunctionMetrics) (*inMemoryBuiltInFunctionMetrics, error) {
+	latencyBuckets := args.LatencyBuckets
+	if len(latencyBuckets) == 0 {
+		latencyBuckets = DefaultLatencyBuckets
+	}
+	for i, bound := range latencyBuckets {
+		if bound <= 0 || (i > 0 && bound <= latencyBuckets[i-1]) {
+			return nil, ErrInvalidLatencyBuckets
+		}
+	}
+
+	return &inMemoryBuiltInFunctionMetrics{
+		latencyBuckets: append([]time.Duration{}, latencyBuckets...),
+		stats:          make(map[string]*BuiltInFunctionStats),
+	}, nil
+}
+
+// RecordCall adds the call to the metrics of its function
+func (metrics *inMemoryBuiltInFunctionMetrics) RecordCall(call BuiltInFunctionCallMetrics) {
+	metrics.mut.Lock()
+	defer metrics.mut.Unlock()
+
+	stats, found := metrics.stats[call.Function]
+	if !found {
+		stats = &BuiltInFunctionStats{
+			ErrorsBySentinel: make(map[string]uint64),
+			LatencyHistogram: make([]uint64, len(metrics.latencyBuckets)+1),
+		}
+		metrics.stats[call.Function] = stats
+	}
+
+	stats.NumCalls++
+	if call.Err != nil {
+		stats.NumErrors++
+		stats.ErrorsBySentinel[sentinelName(call.Err)]++
+	}
+	stats.TotalGasConsumed += call.GasConsumed
+	stats.TotalOutputSize += call.OutputSize
+	stats.TotalDuration += call.Duration
+
+	bucket := sort.Search(len(metrics.latencyBuckets), func(i int) bool {
+		return call.Duration <= metrics.latencyBuckets[i]
+	})
+	stats.LatencyHistogram[bucket]++
+}
+
+// LatencyBuckets returns the upper bounds of the latency histogram buckets
+func (metrics *inMemoryBuiltInFunctionMetrics) LatencyBuckets() []time.Duration {
+	return append([]time.Duration{}, metrics.latencyBuckets...)
+}
+
+// Stats returns a copy of the metrics recorded for the function
+func (metrics *inMemoryBuiltInFunctionMetrics) Stats(function string) (BuiltInFunctionStats, bool) {
+	metrics.mut.RLock()
+	defer metrics.mut.RUnlock()
+
+	stats, found := metrics.stats[function]
+	if !found {
+		return BuiltInFunctionStats{}, false
+	}
+
+	return copyBuiltInFunctionStats(stats), true
+}
+
+// AllStats returns a copy of the metrics recorded for all the called functions
+func (metrics *inMemoryBuiltInFunctionMetrics) AllStats() map[string]BuiltInFunctionStats {
+	metrics.mut.RLock()
+	defer metrics.mut.RUnlock()
+
+	allStats := make(map[string]BuiltInFunctionStats, len(metrics.stats))
+	for function, stats := range metrics.stats {
+		allStats[function] = copyBuiltInFunctionStats(stats)
+	}
+
+	return allStats
+}
+
+// Reset removes all the recorded metrics
+func (metrics *inMemoryBuiltInFunctionMetrics) Reset() {
+	metrics.mut.Lock()
+	metrics.stats = make(map[string]*BuiltInFunctionStats)
+	metrics.mut.Unlock()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (metrics *inMemoryBuiltInFunctionMetrics) IsInterfaceNil() bool {
+	return metrics == nil
+}
+
+func sentinelName(err error) string {
+	builtInErr, ok := GetBuiltInError(err)
+	if ok {
+		return builtInErr.Error()
+	}
+
+	for unwrapped := errors.Unwrap(err); unwrapped != nil; unwrapped = errors.Unwrap(err) {
+		err = unwrapped
+	}
+	if len(err.Error()) == 0 {
+		return unknownErrorName
+	}
+
+	return err.Error()
+}
+
+func copyBuiltInFunctionStats(stats *BuiltInFunctionStats) BuiltInFunctionStats {
+	copied := *stats
+	copied.ErrorsBySentinel = make(map[string]uint64, len(stats.ErrorsBySentinel))
+	for name, count := range stats.ErrorsBySentinel {
+		copied.ErrorsBySentinel[name] = count
+	}
+	copied.LatencyHistogram = append([]uint64{}, stats.LatencyHistogram...)
+
+	return copied
+}
@@ -0,0 +1,103 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+)
+
+func TestNewInMemoryBuiltInFunctionMetrics(t *testing.T) {
+	t.Parallel()
+
+	invalidBuckets := [][]time.Duration{
+		{0},
+		{-time.Millisecond},
+		{time.Millisecond, time.Millisecond},
+		{time.Second, time.Millisecond},
+	}
+	for _, buckets := range invalidBuckets {
+		metrics, err := NewInMemoryBuiltInFunctionMetrics(ArgsNewInMemoryBuiltInFunctionMetrics{LatencyBuckets: buckets})
+		assert.Nil(t, metrics)
+		assert.Equal(t, ErrInvalidLatencyBuckets, err, "%v", buckets)
+	}
+
+	metrics, err := NewInMemoryBuiltInFunctionMetrics(ArgsNewInMemoryBuiltInFunctionMetrics{})
+	require.Nil(t, err)
+	assert.False(t, check.IfNil(metrics))
+	assert.Equal(t, DefaultLatencyBuckets, metrics.LatencyBuckets())
+}
+
+func TestInMemoryBuiltInFunctionMetrics_RecordCall(t *testing.T) {
+	t.Parallel()
+
+	metrics, _ := NewInMemoryBuiltInFunctionMetrics(ArgsNewInMemoryBuiltInFunctionMetrics{
+		LatencyBuckets: []time.Duration{time.Millisecond, 10 * time.Millisecond},
+	})
+	_, found := metrics.Stats("transfer")
+	assert.False(t, found)
+
+	otherErr := errors.New("other error")
+	metrics.RecordCall(BuiltInFunctionCallMetrics{Function: "transfer", Duration: time.Millisecond, GasConsumed: 10, OutputSize: 5})
+	metrics.RecordCall(BuiltInFunctionCallMetrics{Function: "transfer", Duration: 5 * time.Millisecond, GasConsumed: 20, OutputSize: 7})
+	metrics.RecordCall(BuiltInFunctionCallMetrics{Function: "transfer", Duration: time.Second, Err: fmt.Errorf("%w for token", ErrInsufficientFunds)})
+	metrics.RecordCall(BuiltInFunctionCallMetrics{Function: "transfer", Err: &ArgumentError{Index: 1, Err: fmt.Errorf("%w: max length", ErrInvalidArguments)}})
+	metrics.RecordCall(BuiltInFunctionCallMetrics{Function: "transfer", Err: fmt.Errorf("wrapped: %w", otherErr)})
+	metrics.RecordCall(BuiltInFunctionCallMetrics{Function: "burn", Duration: time.Millisecond})
+
+	stats, found := metrics.Stats("transfer")
+	require.True(t, found)
+	expected := BuiltInFunctionStats{
+		NumCalls:  5,
+		NumErrors: 3,
+		ErrorsBySentinel: map[string]uint64{
+			ErrInsufficientFunds.Error(): 1,
+			ErrInvalidArguments.Error():  1,
+			otherErr.Error():             1,
+		},
+		TotalGasConsumed: 30,
+		TotalOutputSize:  12,
+		TotalDuration:    time.Second + 6*time.Millisecond,
+		LatencyHistogram: []uint64{3, 1, 1},
+	}
+	assert.Equal(t, expected, stats)
+
+	allStats := metrics.AllStats()
+	assert.Equal(t, 2, len(allStats))
+	assert.Equal(t, expected, allStats["transfer"])
+	assert.Equal(t, uint64(1), allStats["burn"].NumCalls)
+
+	stats.ErrorsBySentinel[ErrInsufficientFunds.Error()] = 100
+	stats.LatencyHistogram[0] = 100
+	stats, _ = metrics.Stats("transfer")
+	assert.Equal(t, expected, stats)
+
+	metrics.Reset()
+	assert.Empty(t, metrics.AllStats())
+}
+
+func TestInMemoryBuiltInFunctionMetrics_ConcurrentAccess(t *testing.T) {
+	t.Parallel()
+
+	metrics, _ := NewInMemoryBuiltInFunctionMetrics(ArgsNewInMemoryBuiltInFunctionMetrics{})
+	numCalls := 100
+	wg := sync.WaitGroup{}
+	wg.Add(numCalls)
+	for i := 0; i < numCalls; i++ {
+		go func(idx int) {
+			defer wg.Done()
+
+			metrics.RecordCall(BuiltInFunctionCallMetrics{Function: "function", Duration: time.Duration(idx) * time.Microsecond})
+			_ = metrics.AllStats()
+		}(i)
+	}
+	wg.Wait()
+
+	stats, _ := metrics.Stats("function")
+	assert.Equal(t, uint64(numCalls), stats.NumCalls)
+}
@@ -0,0 +1,98 @@
+package builtInFunctions
+
+import (
+	"time"
+
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+)
+
+type instrumentedBuiltInFunction struct {
+	builtInFunctionWrapper
+	name           string
+	metricsHandler BuiltInFunctionMetricsHandler
+}
+
+// NewInstrumentedBuiltInFunction wraps the built-in function, so that each call is measured and sent to the metrics
+// handler
+func NewInstrumentedBuiltInFunction(
+	name string,
+	builtInFunction vmcommon.BuiltinFunction,
+	metricsHandler BuiltInFunctionMetricsHandler,
+) (*instrumentedBuiltInFunction, error) {
+	if check.IfNil(builtInFunction) {
+		return nil, ErrNilContainerElement
+	}
+	if check.IfNil(metricsHandler) {
+		return nil, ErrNilBuiltInFunctionMetricsHandler
+	}
+
+	return &instrumentedBuiltInFunction{
+		builtInFunctionWrapper: builtInFunctionWrapper{BuiltinFunction: builtInFunction},
+		name:                   name,
+		metricsHandler:         metricsHandler,
+	}, nil
+}
+
+// ProcessBuiltinFunction calls the wrapped function and records the call
+func (ibf *instrumentedBuiltInFunction) ProcessBuiltinFunction(
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	start := time.Now()
+	vmOutput, err := ibf.BuiltinFunction.ProcessBuiltinFunction(acntSnd, acntDst, vmInput)
+
+	metrics := BuiltInFunctionCallMetrics{
+		Function: ibf.name,
+		Duration: time.Since(start),
+		Err:      err,
+	}
+	if err == nil && vmOutput != nil {
+		metrics.OutputSize = vmOutputSize(vmOutput)
+		if vmInput != nil && vmInput.GasProvided > vmOutput.GasRemaining {
+			metrics.GasConsumed = vmInput.GasProvided - vmOutput.GasRemaining
+		}
+	}
+	ibf.metricsHandler.RecordCall(metrics)
+
+	return vmOutput, err
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (ibf *instrumentedBuiltInFunction) IsInterfaceNil() bool {
+	return ibf == nil
+}
+
+func vmOutputSize(vmOutput *vmcommon.VMOutput) uint64 {
+	size := 0
+	for _, data := range vmOutput.ReturnData {
+		size += len(data)
+	}
+	for _, outputAccount := range vmOutput.OutputAccounts {
+		if outputAccount == nil {
+			continue
+		}
+		for _, storageUpdate := range outputAccount.StorageUpdates {
+			if storageUpdate != nil {
+				size += len(storageUpdate.Offset) + len(storageUpdate.Data)
+			}
+		}
+		for _, outputTransfer := range outputAccount.OutputTransfers {
+			size += len(outputTransfer.Data)
+		}
+	}
+	for _, logEntry := range vmOutput.Logs {
+		if logEntry == nil {
+			continue
+		}
+		size += len(logEntry.Identifier) + len(logEntry.Address)
+		for _, topic := range logEntry.Topics {
+			size += len(topic)
+		}
+		for _, data := range logEntry.Data {
+			size += len(data)
+		}
+	}
+
+	return uint64(size)
+}
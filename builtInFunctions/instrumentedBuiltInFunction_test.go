@@ -0,0 +1,119 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+	"github.com/subrahamanyam341/andes-vm-common-123/mock"
+)
+
+type builtInFunctionMetricsHandlerStub struct {
+	recorded []BuiltInFunctionCallMetrics
+}
+
+func (stub *builtInFunctionMetricsHandlerStub) RecordCall(metrics BuiltInFunctionCallMetrics) {
+	stub.recorded = append(stub.recorded, metrics)
+}
+
+func (stub *builtInFunctionMetricsHandlerStub) IsInterfaceNil() bool {
+	return stub == nil
+}
+
+func TestNewInstrumentedBuiltInFunction(t *testing.T) {
+	t.Parallel()
+
+	instrumented, err := NewInstrumentedBuiltInFunction("function", nil, &builtInFunctionMetricsHandlerStub{})
+	assert.Nil(t, instrumented)
+	assert.Equal(t, ErrNilContainerElement, err)
+
+	instrumented, err = NewInstrumentedBuiltInFunction("function", &mock.BuiltInFunctionStub{}, nil)
+	assert.Nil(t, instrumented)
+	assert.Equal(t, ErrNilBuiltInFunctionMetricsHandler, err)
+
+	instrumented, err = NewInstrumentedBuiltInFunction("function", &mock.BuiltInFunctionStub{}, &builtInFunctionMetricsHandlerStub{})
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(instrumented))
+}
+
+func TestInstrumentedBuiltInFunction_ProcessBuiltinFunction(t *testing.T) {
+	t.Parallel()
+
+	t.Run("successful call should record the gas and the output size", func(t *testing.T) {
+		t.Parallel()
+
+		vmOutput := &vmcommon.VMOutput{
+			GasRemaining: 30,
+			ReturnData:   [][]byte{[]byte("ret")},
+			OutputAccounts: map[string]*vmcommon.OutputAccount{
+				"address": {
+					StorageUpdates:  map[string]*vmcommon.StorageUpdate{"key": {Offset: []byte("key"), Data: []byte("value")}},
+					OutputTransfers: []vmcommon.OutputTransfer{{Data: []byte("data")}},
+				},
+			},
+			Logs: []*vmcommon.LogEntry{{Identifier: []byte("id"), Address: []byte("addr"), Topics: [][]byte{[]byte("t")}, Data: [][]byte{[]byte("d")}}},
+		}
+		metricsHandler := &builtInFunctionMetricsHandlerStub{}
+		instrumented, _ := NewInstrumentedBuiltInFunction("function", &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(_, _ vmcommon.UserAccountHandler, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				return vmOutput, nil
+			},
+		}, metricsHandler)
+
+		output, err := instrumented.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{VMInput: vmcommon.VMInput{GasProvided: 100}})
+		assert.Nil(t, err)
+		assert.True(t, output == vmOutput)
+		require.Equal(t, 1, len(metricsHandler.recorded))
+		assert.Equal(t, "function", metricsHandler.recorded[0].Function)
+		assert.Equal(t, uint64(70), metricsHandler.recorded[0].GasConsumed)
+		assert.Equal(t, uint64(len("ret")+len("key")+len("value")+len("data")+len("id")+len("addr")+len("t")+len("d")), metricsHandler.recorded[0].OutputSize)
+		assert.Nil(t, metricsHandler.recorded[0].Err)
+	})
+	t.Run("failed call should record the error", func(t *testing.T) {
+		t.Parallel()
+
+		metricsHandler := &builtInFunctionMetricsHandlerStub{}
+		instrumented, _ := NewInstrumentedBuiltInFunction("function", &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(_, _ vmcommon.UserAccountHandler, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				return nil, ErrInsufficientFunds
+			},
+		}, metricsHandler)
+
+		output, err := instrumented.ProcessBuiltinFunction(nil, nil, nil)
+		assert.Nil(t, output)
+		assert.Equal(t, ErrInsufficientFunds, err)
+		require.Equal(t, 1, len(metricsHandler.recorded))
+		assert.Equal(t, ErrInsufficientFunds, metricsHandler.recorded[0].Err)
+		assert.Equal(t, uint64(0), metricsHandler.recorded[0].GasConsumed)
+		assert.Equal(t, uint64(0), metricsHandler.recorded[0].OutputSize)
+	})
+}
+
+func TestInstrumentedBuiltInFunction_ForwardsToWrappedFunction(t *testing.T) {
+	t.Parallel()
+
+	setNewGasConfigCalled := false
+	stub := &mock.BuiltInFunctionStub{
+		SetNewGasConfigCalled: func(_ *vmcommon.GasCost) {
+			setNewGasConfigCalled = true
+		},
+		IsActiveCalled: func() bool {
+			return false
+		},
+	}
+	instrumented, _ := NewInstrumentedBuiltInFunction("function", stub, &builtInFunctionMetricsHandlerStub{})
+	instrumented.SetNewGasConfig(&vmcommon.GasCost{})
+	assert.True(t, setNewGasConfigCalled)
+	assert.False(t, instrumented.IsActive())
+	assert.Equal(t, ErrDryRunNotSupported, instrumented.CheckIsExecutable(nil, nil, nil))
+	assert.Equal(t, ErrWrongTypeAssertion, instrumented.SetPayableChecker(&mock.PayableHandlerStub{}))
+
+	claimDeveloperRewards := NewClaimDeveloperRewardsFunc(10)
+	instrumented, _ = NewInstrumentedBuiltInFunction("function", claimDeveloperRewards, &builtInFunctionMetricsHandlerStub{})
+	err := instrumented.CheckIsExecutable(nil, nil, nil)
+	assert.False(t, errors.Is(err, ErrDryRunNotSupported))
+	assert.Equal(t, claimDeveloperRewards.CheckIsExecutable(nil, nil, nil), err)
+}
@@ -0,0 +1,37 @@
+package builtInFunctions
+
+import (
+	"time"
+
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+)
+
+// BuiltInFunctionCallMetrics holds the measurements of a built-in function call
+type BuiltInFunctionCallMetrics struct {
+	Function string
+	Duration time.Duration
+	// GasConsumed is only measured for the successful calls
+	GasConsumed uint64
+	// OutputSize is the number of bytes of the return data, storage updates, transfers data and logs in the output
+	OutputSize uint64
+	Err        error
+}
+
+// BuiltInFunctionMetricsHandler receives the measurements of the instrumented built-in function calls
+type BuiltInFunctionMetricsHandler interface {
+	RecordCall(metrics BuiltInFunctionCallMetrics)
+	IsInterfaceNil() bool
+}
+
+// StorageTracer records the storage accesses made while a trace is started. A started trace collects the accesses of
+// all the callers, so the traced calls must not run concurrently.
+type StorageTracer interface {
+	StartTrace(function string)
+	EndTrace() *vmcommon.StorageTrace
+	TraceAccount(account vmcommon.UserAccountHandler) vmcommon.UserAccountHandler
+	IsInterfaceNil() bool
+}
+
+type storageTracedChecker interface {
+	IsStorageTraced() bool
+}
@@ -11,6 +11,8 @@ import (
 	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
 )
 
+var saveKeyValueArgumentsSchema = NewArgumentsSchema().WithVariadic(1, BytesArgument("key"), BytesArgument("value"))
+
 type saveKeyValueStorage struct {
 	baseAlwaysActiveHandler
 	gasConfig           vmcommon.BaseOperationCost
@@ -127,14 +129,9 @@ func (k *saveKeyValueStorage) subtractGasFromVMoutput(vmOutput *vmcommon.VMOutpu
 }
 
 func checkArgumentsForSaveKeyValue(acntDst vmcommon.UserAccountHandler, input *vmcommon.ContractCallInput) error {
-	if input == nil {
-		return ErrNilVmInput
-	}
-	if len(input.Arguments) < 2 {
-		return ErrInvalidArguments
-	}
-	if len(input.Arguments)%2 != 0 {
-		return ErrInvalidArguments
+	err := saveKeyValueArgumentsSchema.Validate(input)
+	if err != nil {
+		return err
 	}
 	if input.CallValue.Cmp(zero) != 0 {
 		return ErrBuiltInFunctionCalledWithValue
@@ -156,3 +153,8 @@ func checkArgumentsForSaveKeyValue(acntDst vmcommon.UserAccountHandler, input *v
 func (k *saveKeyValueStorage) IsInterfaceNil() bool {
 	return k == nil
 }
+
+// CheckIsExecutable checks if the save key value can be executed, without changing the accounts
+func (k *saveKeyValueStorage) CheckIsExecutable(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) error {
+	return checkIsExecutableOnDryRunAccounts(k, acntSnd, acntDst, vmInput)
+}
@@ -1,19 +1,12 @@
 package builtInFunctions
 
 import (
-	"bytes"
-	"fmt"
 	"math/big"
 	"strconv"
 
 	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
 )
 
-const (
-	dctIdentifierSeparator  = "-"
-	dctRandomSequenceLength = 6
-)
-
 // TopicTokenData groups data that will end up in Topics section of LogEntry
 type TopicTokenData struct {
 	TokenID []byte
@@ -79,22 +72,6 @@ func newEntryForDCT(identifier, tokenID []byte, nonce uint64, value *big.Int, ar
 	return logEntry
 }
 
-func extractTokenIdentifierAndNonceDCTWipe(args []byte) ([]byte, uint64) {
-	argsSplit := bytes.Split(args, []byte(dctIdentifierSeparator))
-	if len(argsSplit) < 2 {
-		return args, 0
-	}
-
-	if len(argsSplit[1]) <= dctRandomSequenceLength {
-		return args, 0
-	}
-
-	identifier := []byte(fmt.Sprintf("%s-%s", argsSplit[0], argsSplit[1][:dctRandomSequenceLength]))
-	nonce := big.NewInt(0).SetBytes(argsSplit[1][dctRandomSequenceLength:])
-
-	return identifier, nonce.Uint64()
-}
-
 func boolToSlice(b bool) []byte {
 	return []byte(strconv.FormatBool(b))
 }
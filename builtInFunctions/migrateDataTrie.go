@@ -115,6 +115,24 @@ func (mdt *migrateDataTrie) getExistingAccount(address []byte) (vmcommon.UserAcc
 	return userAccount, nil
 }
 
+// CheckIsExecutable checks if the data trie migration can be executed, without changing the accounts. As no leaf
+// is migrated during the check, the consumed gas is not computed.
+func (mdt *migrateDataTrie) CheckIsExecutable(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) error {
+	ctx := newDryRunContext()
+	return ctx.checkIsExecutable(mdt.dryRunCopy(ctx), acntSnd, acntDst, vmInput)
+}
+
+func (mdt *migrateDataTrie) dryRunCopy(ctx *dryRunContext) *migrateDataTrie {
+	mdt.mutExecution.RLock()
+	defer mdt.mutExecution.RUnlock()
+
+	return &migrateDataTrie{
+		baseActiveHandler: mdt.baseActiveHandler,
+		accounts:          ctx.accountsAdapter(mdt.accounts),
+		builtInCost:       mdt.builtInCost,
+	}
+}
+
 // SetNewGasConfig is called whenever gas cost is changed
 func (mdt *migrateDataTrie) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 	if gasCost == nil {
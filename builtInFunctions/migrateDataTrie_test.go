@@ -3,6 +3,7 @@ package builtInFunctions
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"math/big"
 	"strings"
 	"sync"
@@ -10,9 +11,12 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core"
 	"github.com/subrahamanyam341/andes-core-16/core/check"
+	"github.com/subrahamanyam341/andes-core-16/hashing/sha256"
 	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
 	"github.com/subrahamanyam341/andes-vm-common-123/mock"
+	"github.com/subrahamanyam341/andes-vm-common-123/state"
 )
 
 func TestNewMigrateDataTrieFunc(t *testing.T) {
@@ -282,3 +286,74 @@ func TestMigrateDataTrie_Concurrency(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestMigrateDataTrie_ProcessBuiltinFunctionWithInMemoryState(t *testing.T) {
+	t.Parallel()
+
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{
+		IsMigrateDataTrieEnabledField: true,
+	}
+	accounts, _ := state.NewAccountsAdapter(state.ArgsNewAccountsAdapter{
+		Hasher:              sha256.NewSha256(),
+		EnableEpochsHandler: enableEpochsHandler,
+	})
+	address := bytes.Repeat([]byte{1}, 32)
+	account, _ := accounts.LoadAccount(address)
+	userAccount := account.(vmcommon.UserAccountHandler)
+	numLeaves := 20
+	for i := 0; i < numLeaves; i++ {
+		_ = userAccount.AccountDataHandler().SaveKeyValue([]byte(fmt.Sprintf("key%d", i)), []byte("value"))
+	}
+	require.Nil(t, accounts.SaveAccount(userAccount))
+
+	enableEpochsHandler.IsAutoBalanceDataTriesEnabledField = true
+	builtInCost := vmcommon.BuiltInCost{
+		TrieLoadPerNode:  10,
+		TrieStorePerNode: 20,
+	}
+	mdtf, _ := NewMigrateDataTrieFunc(builtInCost, enableEpochsHandler, accounts)
+
+	migrate := func(gasProvided uint64) *vmcommon.VMOutput {
+		acnt, _ := accounts.LoadAccount(address)
+		input := &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr:  address,
+				CallValue:   big.NewInt(0),
+				GasProvided: gasProvided,
+			},
+			RecipientAddr: address,
+		}
+		vmOutput, err := mdtf.ProcessBuiltinFunction(nil, acnt.(vmcommon.UserAccountHandler), input)
+		require.Nil(t, err)
+		require.Nil(t, accounts.SaveAccount(acnt))
+
+		return vmOutput
+	}
+	countMigrated := func() int {
+		acnt, _ := accounts.LoadAccount(address)
+		dataTrie := acnt.(vmcommon.UserAccountHandler).AccountDataHandler().(interface {
+			GetLeafVersion(key []byte) (core.TrieNodeVersion, bool)
+		})
+		numMigrated := 0
+		for i := 0; i < numLeaves; i++ {
+			version, _ := dataTrie.GetLeafVersion([]byte(fmt.Sprintf("key%d", i)))
+			if version == core.AutoBalanceEnabled {
+				numMigrated++
+			}
+		}
+
+		return numMigrated
+	}
+
+	vmOutput := migrate(100)
+	migratedWithFirstCall := countMigrated()
+	assert.True(t, migratedWithFirstCall > 0 && migratedWithFirstCall < numLeaves)
+	assert.True(t, vmOutput.GasRemaining < 30)
+
+	migrate(10000)
+	assert.Equal(t, numLeaves, countMigrated())
+
+	acnt, _ := accounts.LoadAccount(address)
+	value, _, _ := acnt.(vmcommon.UserAccountHandler).AccountDataHandler().RetrieveValue([]byte("key1"))
+	assert.Equal(t, []byte("value"), value)
+}
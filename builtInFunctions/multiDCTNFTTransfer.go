@@ -589,6 +589,42 @@ func (e *dctNFTMultiTransfer) addNFTToDestination(
 	return nil
 }
 
+// CheckIsExecutable checks if the multi NFT transfer can be executed, without changing the accounts
+func (e *dctNFTMultiTransfer) CheckIsExecutable(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) error {
+	ctx := newDryRunContext()
+	dryRun, err := e.dryRunCopy(ctx)
+	if err != nil {
+		return err
+	}
+
+	return ctx.checkIsExecutable(dryRun, acntSnd, acntDst, vmInput)
+}
+
+func (e *dctNFTMultiTransfer) dryRunCopy(ctx *dryRunContext) (*dctNFTMultiTransfer, error) {
+	storageHandler, err := ctx.storageHandler(e.dctStorageHandler)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	return &dctNFTMultiTransfer{
+		baseActiveHandler:     e.baseActiveHandler,
+		keyPrefix:             e.keyPrefix,
+		marshaller:            e.marshaller,
+		globalSettingsHandler: e.globalSettingsHandler,
+		payableHandler:        e.payableHandler,
+		funcGasCost:           e.funcGasCost,
+		accounts:              ctx.accountsAdapter(e.accounts),
+		shardCoordinator:      e.shardCoordinator,
+		gasConfig:             e.gasConfig,
+		dctStorageHandler:     storageHandler,
+		rolesHandler:          e.rolesHandler,
+		enableEpochsHandler:   e.enableEpochsHandler,
+	}, nil
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (e *dctNFTMultiTransfer) IsInterfaceNil() bool {
 	return e == nil
@@ -0,0 +1,216 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/subrahamanyam341/andes-core-16/core"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+)
+
+// StorageLocation is a data key of an account
+type StorageLocation struct {
+	Address []byte
+	Key     []byte
+}
+
+func (location StorageLocation) id() string {
+	return fmt.Sprintf("%d:%s%s", len(location.Address), location.Address, location.Key)
+}
+
+// ReadWriteSet holds the storage locations a built-in function call reads and writes. The accounts are expected to be
+// merged by data key, as the data trie does, so the calls writing different keys of the same account do not conflict.
+type ReadWriteSet struct {
+	// Reads are the locations only read by the call
+	Reads []StorageLocation
+	// Writes are the locations written, and usually also read, by the call
+	Writes []StorageLocation
+}
+
+// ConflictsWith returns true if one of the sets writes a location the other one reads or writes
+func (set *ReadWriteSet) ConflictsWith(other *ReadWriteSet) bool {
+	return writesAny(set.Writes, other.Reads) || writesAny(set.Writes, other.Writes) || writesAny(other.Writes, set.Reads)
+}
+
+func writesAny(writes []StorageLocation, locations []StorageLocation) bool {
+	written := make(map[string]struct{}, len(writes))
+	for _, location := range writes {
+		written[location.id()] = struct{}{}
+	}
+	for _, location := range locations {
+		_, found := written[location.id()]
+		if found {
+			return true
+		}
+	}
+
+	return false
+}
+
+// readWriteSetBuilder collects the locations in the order they are added, a location added both as read and as
+// written being kept only as written
+type readWriteSetBuilder struct {
+	locations []StorageLocation
+	isWritten map[string]bool
+}
+
+func newReadWriteSetBuilder() *readWriteSetBuilder {
+	return &readWriteSetBuilder{
+		locations: make([]StorageLocation, 0),
+		isWritten: make(map[string]bool),
+	}
+}
+
+func (builder *readWriteSetBuilder) read(address []byte, key []byte) {
+	builder.add(StorageLocation{Address: address, Key: key}, false)
+}
+
+func (builder *readWriteSetBuilder) write(address []byte, key []byte) {
+	builder.add(StorageLocation{Address: address, Key: key}, true)
+}
+
+func (builder *readWriteSetBuilder) add(location StorageLocation, isWrite bool) {
+	id := location.id()
+	isWritten, found := builder.isWritten[id]
+	if !found {
+		builder.locations = append(builder.locations, location)
+	}
+	builder.isWritten[id] = isWritten || isWrite
+}
+
+func (builder *readWriteSetBuilder) build() *ReadWriteSet {
+	set := &ReadWriteSet{
+		Reads:  make([]StorageLocation, 0),
+		Writes: make([]StorageLocation, 0),
+	}
+	for _, location := range builder.locations {
+		if builder.isWritten[location.id()] {
+			set.Writes = append(set.Writes, location)
+			continue
+		}
+		set.Reads = append(set.Reads, location)
+	}
+
+	return set
+}
+
+// ComputeReadWriteSet returns the storage locations the built-in function named in the input reads and writes,
+// without executing it. The set is a superset of the locations touched by the execution, which might stop early or
+// skip some checks, depending on the state and on the active flags. Only the DCT transfer functions are supported.
+func ComputeReadWriteSet(input *vmcommon.ContractCallInput) (*ReadWriteSet, error) {
+	if input == nil {
+		return nil, ErrNilVmInput
+	}
+
+	builder := newReadWriteSetBuilder()
+	var err error
+	switch input.Function {
+	case core.BuiltInFunctionDCTTransfer:
+		err = addDCTTransferLocations(builder, input)
+	case core.BuiltInFunctionDCTNFTTransfer:
+		err = addDCTNFTTransferLocations(builder, input)
+	case core.BuiltInFunctionMultiDCTNFTTransfer:
+		err = addMultiDCTNFTTransferLocations(builder, input)
+	default:
+		return nil, fmt.Errorf("%w for function %s", ErrReadWriteSetNotSupported, input.Function)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return builder.build(), nil
+}
+
+func addDCTTransferLocations(builder *readWriteSetBuilder, input *vmcommon.ContractCallInput) error {
+	if len(input.Arguments) < core.MinLenArgumentsDCTTransfer {
+		return ErrInvalidArguments
+	}
+
+	addTokenTransferLocations(builder, input.CallerAddr, input.RecipientAddr, input.Arguments[0], 0)
+	return nil
+}
+
+func addDCTNFTTransferLocations(builder *readWriteSetBuilder, input *vmcommon.ContractCallInput) error {
+	if len(input.Arguments) < core.MinLenArgumentsDCTNFTTransfer {
+		return ErrInvalidArguments
+	}
+
+	tokenID := input.Arguments[0]
+	nonce := big.NewInt(0).SetBytes(input.Arguments[1]).Uint64()
+	if !bytes.Equal(input.CallerAddr, input.RecipientAddr) {
+		// on the destination shard the sender account is not loaded
+		addTokenTransferLocations(builder, nil, input.RecipientAddr, tokenID, nonce)
+		return nil
+	}
+
+	addTokenTransferLocations(builder, input.CallerAddr, input.Arguments[3], tokenID, nonce)
+	return nil
+}
+
+func addMultiDCTNFTTransferLocations(builder *readWriteSetBuilder, input *vmcommon.ContractCallInput) error {
+	sender := input.CallerAddr
+	destination := input.RecipientAddr
+	startIndex := uint64(1)
+	if bytes.Equal(input.CallerAddr, input.RecipientAddr) {
+		if len(input.Arguments) < 2 {
+			return ErrInvalidArguments
+		}
+		destination = input.Arguments[0]
+		startIndex = 2
+	} else {
+		// on the destination shard the sender account is not loaded
+		sender = nil
+	}
+	if uint64(len(input.Arguments)) < startIndex {
+		return ErrInvalidArguments
+	}
+
+	numOfTransfers := big.NewInt(0).SetBytes(input.Arguments[startIndex-1]).Uint64()
+	if numOfTransfers == 0 {
+		return fmt.Errorf("%w, 0 tokens to transfer", ErrInvalidArguments)
+	}
+	if uint64(len(input.Arguments)) < numOfTransfers*argumentsPerTransfer+startIndex {
+		return fmt.Errorf("%w, invalid number of arguments", ErrInvalidArguments)
+	}
+
+	for i := uint64(0); i < numOfTransfers; i++ {
+		tokenStartIndex := startIndex + i*argumentsPerTransfer
+		tokenID := input.Arguments[tokenStartIndex]
+		nonce := big.NewInt(0).SetBytes(input.Arguments[tokenStartIndex+1]).Uint64()
+		addTokenTransferLocations(builder, sender, destination, tokenID, nonce)
+	}
+
+	return nil
+}
+
+// addTokenTransferLocations adds the balances of the sender and of the destination, the roles checked for the
+// limited transfers and the global settings of the token. The NFT transfers also read the frozen collection on the
+// destination and update the metadata and the liquidity held by the system account.
+func addTokenTransferLocations(builder *readWriteSetBuilder, sender []byte, destination []byte, tokenID []byte, nonce uint64) {
+	token := vmcommon.TokenIdentifier{TokenID: tokenID, Nonce: nonce}
+	collection := vmcommon.TokenIdentifier{TokenID: tokenID}
+	for _, address := range [][]byte{sender, destination} {
+		if len(address) == 0 {
+			continue
+		}
+
+		builder.write(address, token.StorageKey())
+		builder.read(address, concatKey(roleKeyPrefix, tokenID))
+		if nonce > 0 {
+			builder.read(address, collection.StorageKey())
+		}
+	}
+
+	builder.read(vmcommon.SystemAccountAddress, collection.StorageKey())
+	builder.read(vmcommon.SystemAccountAddress, concatKey(transferAddressesKeyPrefix, tokenID))
+	if nonce > 0 {
+		builder.write(vmcommon.SystemAccountAddress, token.StorageKey())
+	}
+}
+
+func concatKey(prefix []byte, suffix []byte) []byte {
+	key := make([]byte, 0, len(prefix)+len(suffix))
+	key = append(key, prefix...)
+	return append(key, suffix...)
+}
@@ -0,0 +1,150 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+)
+
+func createReadWriteSetInput(function string, caller []byte, recipient []byte, arguments ...[]byte) *vmcommon.ContractCallInput {
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr: caller,
+			Arguments:  arguments,
+		},
+		RecipientAddr: recipient,
+		Function:      function,
+	}
+}
+
+func TestComputeReadWriteSet(t *testing.T) {
+	t.Parallel()
+
+	sender := bytes.Repeat([]byte{1}, 32)
+	receiver := bytes.Repeat([]byte{2}, 32)
+	tokenID := []byte("TOKEN-abcdef")
+	nftID := []byte("NFT-abcdef")
+	token := vmcommon.TokenIdentifier{TokenID: tokenID}
+	nft := vmcommon.TokenIdentifier{TokenID: nftID, Nonce: 5}
+	collection := vmcommon.TokenIdentifier{TokenID: nftID}
+	system := vmcommon.SystemAccountAddress
+
+	t.Run("invalid input should error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ComputeReadWriteSet(nil)
+		assert.Equal(t, ErrNilVmInput, err)
+
+		_, err = ComputeReadWriteSet(createReadWriteSetInput(core.BuiltInFunctionDCTLocalMint, sender, sender))
+		assert.True(t, errors.Is(err, ErrReadWriteSetNotSupported))
+
+		_, err = ComputeReadWriteSet(createReadWriteSetInput(core.BuiltInFunctionDCTTransfer, sender, receiver, tokenID))
+		assert.Equal(t, ErrInvalidArguments, err)
+
+		_, err = ComputeReadWriteSet(createReadWriteSetInput(core.BuiltInFunctionMultiDCTNFTTransfer, sender, sender, receiver, []byte{2}, tokenID, nil, []byte{1}))
+		assert.True(t, errors.Is(err, ErrInvalidArguments))
+	})
+	t.Run("DCTTransfer", func(t *testing.T) {
+		t.Parallel()
+
+		set, err := ComputeReadWriteSet(createReadWriteSetInput(core.BuiltInFunctionDCTTransfer, sender, receiver, tokenID, []byte{10}))
+		require.Nil(t, err)
+		expected := &ReadWriteSet{
+			Reads: []StorageLocation{
+				{Address: sender, Key: concatKey(roleKeyPrefix, tokenID)},
+				{Address: receiver, Key: concatKey(roleKeyPrefix, tokenID)},
+				{Address: system, Key: token.StorageKey()},
+				{Address: system, Key: concatKey(transferAddressesKeyPrefix, tokenID)},
+			},
+			Writes: []StorageLocation{
+				{Address: sender, Key: token.StorageKey()},
+				{Address: receiver, Key: token.StorageKey()},
+			},
+		}
+		assert.Equal(t, expected, set)
+	})
+	t.Run("DCTNFTTransfer on sender shard", func(t *testing.T) {
+		t.Parallel()
+
+		input := createReadWriteSetInput(core.BuiltInFunctionDCTNFTTransfer, sender, sender, nftID, []byte{5}, []byte{1}, receiver)
+		set, err := ComputeReadWriteSet(input)
+		require.Nil(t, err)
+		expected := &ReadWriteSet{
+			Reads: []StorageLocation{
+				{Address: sender, Key: concatKey(roleKeyPrefix, nftID)},
+				{Address: sender, Key: collection.StorageKey()},
+				{Address: receiver, Key: concatKey(roleKeyPrefix, nftID)},
+				{Address: receiver, Key: collection.StorageKey()},
+				{Address: system, Key: collection.StorageKey()},
+				{Address: system, Key: concatKey(transferAddressesKeyPrefix, nftID)},
+			},
+			Writes: []StorageLocation{
+				{Address: sender, Key: nft.StorageKey()},
+				{Address: receiver, Key: nft.StorageKey()},
+				{Address: system, Key: nft.StorageKey()},
+			},
+		}
+		assert.Equal(t, expected, set)
+	})
+	t.Run("DCTNFTTransfer on destination shard", func(t *testing.T) {
+		t.Parallel()
+
+		input := createReadWriteSetInput(core.BuiltInFunctionDCTNFTTransfer, sender, receiver, nftID, []byte{5}, []byte{1}, []byte("marshalled token"))
+		set, err := ComputeReadWriteSet(input)
+		require.Nil(t, err)
+		assert.Equal(t, []StorageLocation{
+			{Address: receiver, Key: nft.StorageKey()},
+			{Address: system, Key: nft.StorageKey()},
+		}, set.Writes)
+		for _, location := range set.Reads {
+			assert.False(t, bytes.Equal(sender, location.Address))
+		}
+	})
+	t.Run("MultiDCTNFTTransfer", func(t *testing.T) {
+		t.Parallel()
+
+		input := createReadWriteSetInput(core.BuiltInFunctionMultiDCTNFTTransfer, sender, sender,
+			receiver, []byte{2}, tokenID, nil, []byte{1}, nftID, []byte{5}, []byte{1})
+		set, err := ComputeReadWriteSet(input)
+		require.Nil(t, err)
+		assert.Equal(t, []StorageLocation{
+			{Address: sender, Key: token.StorageKey()},
+			{Address: receiver, Key: token.StorageKey()},
+			{Address: sender, Key: nft.StorageKey()},
+			{Address: receiver, Key: nft.StorageKey()},
+			{Address: system, Key: nft.StorageKey()},
+		}, set.Writes)
+		assert.Equal(t, 10, len(set.Reads))
+
+		input = createReadWriteSetInput(core.BuiltInFunctionMultiDCTNFTTransfer, sender, receiver,
+			[]byte{1}, tokenID, nil, []byte{1})
+		set, err = ComputeReadWriteSet(input)
+		require.Nil(t, err)
+		assert.Equal(t, []StorageLocation{{Address: receiver, Key: token.StorageKey()}}, set.Writes)
+	})
+}
+
+func TestReadWriteSet_ConflictsWith(t *testing.T) {
+	t.Parallel()
+
+	first := StorageLocation{Address: []byte("address"), Key: []byte("first")}
+	second := StorageLocation{Address: []byte("address"), Key: []byte("second")}
+	otherAccount := StorageLocation{Address: []byte("other"), Key: []byte("first")}
+
+	readFirst := &ReadWriteSet{Reads: []StorageLocation{first}}
+	writeFirst := &ReadWriteSet{Writes: []StorageLocation{first}}
+	writeSecond := &ReadWriteSet{Reads: []StorageLocation{otherAccount}, Writes: []StorageLocation{second}}
+
+	assert.False(t, readFirst.ConflictsWith(readFirst))
+	assert.True(t, readFirst.ConflictsWith(writeFirst))
+	assert.True(t, writeFirst.ConflictsWith(readFirst))
+	assert.True(t, writeFirst.ConflictsWith(writeFirst))
+	assert.False(t, writeFirst.ConflictsWith(writeSecond))
+	assert.False(t, writeSecond.ConflictsWith(readFirst))
+	assert.False(t, (&ReadWriteSet{}).ConflictsWith(writeFirst))
+}
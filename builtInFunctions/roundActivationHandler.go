@@ -0,0 +1,44 @@
+package builtInFunctions
+
+import (
+	"sync/atomic"
+
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+)
+
+type roundActivationHandler struct {
+	activationRound uint64
+	isActive        atomic.Bool
+}
+
+// NewRoundActivationHandler creates a handler that becomes active when the activation round is confirmed. Its
+// IsActive method can be used as the active handler of a built-in function.
+func NewRoundActivationHandler(activationRound uint64, roundNotifier vmcommon.RoundNotifier) (*roundActivationHandler, error) {
+	if check.IfNil(roundNotifier) {
+		return nil, ErrNilRoundNotifier
+	}
+
+	handler := &roundActivationHandler{
+		activationRound: activationRound,
+	}
+	handler.isActive.Store(activationRound == 0)
+	roundNotifier.RegisterNotifyHandler(handler)
+
+	return handler, nil
+}
+
+// RoundConfirmed is called whenever a new round is confirmed
+func (handler *roundActivationHandler) RoundConfirmed(round uint64, _ uint64) {
+	handler.isActive.Store(round >= handler.activationRound)
+}
+
+// IsActive returns true if the activation round was reached
+func (handler *roundActivationHandler) IsActive() bool {
+	return handler.isActive.Load()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (handler *roundActivationHandler) IsInterfaceNil() bool {
+	return handler == nil
+}
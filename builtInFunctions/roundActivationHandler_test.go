@@ -0,0 +1,49 @@
+package builtInFunctions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+	"github.com/subrahamanyam341/andes-vm-common-123/mock"
+)
+
+func TestNewRoundActivationHandler(t *testing.T) {
+	t.Parallel()
+
+	handler, err := NewRoundActivationHandler(10, nil)
+	assert.True(t, check.IfNil(handler))
+	assert.Equal(t, ErrNilRoundNotifier, err)
+
+	var registered vmcommon.RoundSubscriberHandler
+	roundNotifier := &mock.RoundNotifierStub{
+		RegisterNotifyHandlerCalled: func(subscriber vmcommon.RoundSubscriberHandler) {
+			registered = subscriber
+		},
+	}
+	handler, err = NewRoundActivationHandler(10, roundNotifier)
+	require.Nil(t, err)
+	assert.False(t, check.IfNil(handler))
+	assert.Equal(t, handler, registered)
+
+	handler, _ = NewRoundActivationHandler(0, roundNotifier)
+	assert.True(t, handler.IsActive())
+}
+
+func TestRoundActivationHandler_RoundConfirmed(t *testing.T) {
+	t.Parallel()
+
+	handler, _ := NewRoundActivationHandler(10, &mock.RoundNotifierStub{})
+	assert.False(t, handler.IsActive())
+
+	handler.RoundConfirmed(9, 0)
+	assert.False(t, handler.IsActive())
+
+	handler.RoundConfirmed(10, 0)
+	assert.True(t, handler.IsActive())
+
+	handler.RoundConfirmed(11, 0)
+	assert.True(t, handler.IsActive())
+}
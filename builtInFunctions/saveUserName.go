@@ -164,6 +164,11 @@ func (s *saveUserName) ProcessBuiltinFunction(
 	return vmOutput, nil
 }
 
+// CheckIsExecutable checks if the save user name can be executed, without changing the accounts
+func (s *saveUserName) CheckIsExecutable(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) error {
+	return checkIsExecutableOnDryRunAccounts(s, acntSnd, acntDst, vmInput)
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (s *saveUserName) IsInterfaceNil() bool {
 	return s == nil
@@ -43,29 +43,20 @@ func (sg *setGuardian) ProcessBuiltinFunction(
 	acntSnd, _ vmcommon.UserAccountHandler,
 	vmInput *vmcommon.ContractCallInput,
 ) (*vmcommon.VMOutput, error) {
-	if check.IfNil(acntSnd) {
-		return nil, fmt.Errorf("%w for sender", ErrNilUserAccount)
-	}
-	if vmInput == nil {
-		return nil, ErrNilVmInput
-	}
-	if len(vmInput.Arguments) != noOfArgsSetGuardian {
-		return nil, fmt.Errorf("%w, expected %d, got %d ", ErrInvalidNumberOfArguments, noOfArgsSetGuardian, len(vmInput.Arguments))
+	err := checkSetGuardianCall(acntSnd, vmInput)
+	if err != nil {
+		return nil, err
 	}
 
-	senderAddr := acntSnd.AddressBytes()
-	senderIsNotCaller := !bytes.Equal(senderAddr, vmInput.CallerAddr)
-	if senderIsNotCaller {
-		return nil, ErrOperationNotPermitted
-	}
 	sg.mutExecution.RLock()
 	defer sg.mutExecution.RUnlock()
 
+	senderAddr := acntSnd.AddressBytes()
 	newGuardian := vmInput.Arguments[0]
 	guardianServiceUID := vmInput.Arguments[1]
 	gasProvidedForCall := vmInput.GasProvided
 
-	err := sg.CheckIsExecutable(
+	err = sg.CheckIsExecutable(
 		senderAddr,
 		vmInput.CallValue,
 		vmInput.RecipientAddr,
@@ -118,6 +109,25 @@ func (sg *setGuardian) CheckIsExecutable(
 	return sg.checkSetGuardianArgs(senderAddr, arguments)
 }
 
+func checkSetGuardianCall(acntSnd vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) error {
+	if check.IfNil(acntSnd) {
+		return fmt.Errorf("%w for sender", ErrNilUserAccount)
+	}
+	if vmInput == nil {
+		return ErrNilVmInput
+	}
+	if len(vmInput.Arguments) != noOfArgsSetGuardian {
+		return fmt.Errorf("%w, expected %d, got %d ", ErrInvalidNumberOfArguments, noOfArgsSetGuardian, len(vmInput.Arguments))
+	}
+
+	senderIsNotCaller := !bytes.Equal(acntSnd.AddressBytes(), vmInput.CallerAddr)
+	if senderIsNotCaller {
+		return ErrOperationNotPermitted
+	}
+
+	return nil
+}
+
 func (sg *setGuardian) checkSetGuardianArgs(
 	senderAddr []byte,
 	arguments [][]byte,
@@ -149,3 +159,54 @@ func (sg *setGuardian) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 	sg.funcGasCost = gasCost.BuiltInCost.SetGuardian
 	sg.mutExecution.Unlock()
 }
+
+// setGuardianExecutableChecker exposes the set guardian function as a vmcommon.ExecutableChecker, as the function
+// keeps its own CheckIsExecutable on the call arguments
+type setGuardianExecutableChecker struct {
+	*setGuardian
+}
+
+// NewSetGuardianExecutableChecker wraps the set guardian function so that it can be checked without changing the state
+func NewSetGuardianExecutableChecker(setGuardianFunc *setGuardian) (*setGuardianExecutableChecker, error) {
+	if setGuardianFunc == nil {
+		return nil, ErrNilContainerElement
+	}
+
+	return &setGuardianExecutableChecker{
+		setGuardian: setGuardianFunc,
+	}, nil
+}
+
+// CheckIsExecutable will check if the set guardian built-in function can be executed. The guardian is set on a
+// view of the sender account, so the account is not changed.
+func (checker *setGuardianExecutableChecker) CheckIsExecutable(
+	acntSnd, _ vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) error {
+	err := checkSetGuardianCall(acntSnd, vmInput)
+	if err != nil {
+		return err
+	}
+
+	checker.mutExecution.RLock()
+	defer checker.mutExecution.RUnlock()
+
+	err = checker.setGuardian.CheckIsExecutable(
+		acntSnd.AddressBytes(),
+		vmInput.CallValue,
+		vmInput.RecipientAddr,
+		vmInput.GasProvided,
+		vmInput.Arguments,
+	)
+	if err != nil {
+		return err
+	}
+
+	ctx := newDryRunContext()
+	return checker.guardedAccountHandler.SetGuardian(ctx.userAccount(acntSnd), vmInput.Arguments[0], vmInput.TxGuardian, vmInput.Arguments[1])
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (checker *setGuardianExecutableChecker) IsInterfaceNil() bool {
+	return checker == nil
+}
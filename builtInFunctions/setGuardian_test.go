@@ -280,6 +280,53 @@ func TestSetGuardian_ProcessBuiltinFunctionSetGuardianOK(t *testing.T) {
 	}, output.Logs)
 }
 
+func TestNewSetGuardianExecutableChecker(t *testing.T) {
+	t.Parallel()
+
+	checker, err := NewSetGuardianExecutableChecker(nil)
+	require.Nil(t, checker)
+	require.Equal(t, ErrNilContainerElement, err)
+
+	setGuardianFunc, _ := NewSetGuardianFunc(createSetGuardianFuncMockArgs())
+	checker, err = NewSetGuardianExecutableChecker(setGuardianFunc)
+	require.Nil(t, err)
+	require.False(t, checker.IsInterfaceNil())
+}
+
+func TestSetGuardianExecutableChecker_CheckIsExecutable(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("expected error")
+	var accountSetGuardian vmcommon.UserAccountHandler
+	args := createSetGuardianFuncMockArgs()
+	args.GuardedAccountHandler = &mockvm.GuardedAccountHandlerStub{
+		SetGuardianCalled: func(account vmcommon.UserAccountHandler, _ []byte, _ []byte, _ []byte) error {
+			accountSetGuardian = account
+			return expectedErr
+		},
+	}
+	setGuardianFunc, _ := NewSetGuardianFunc(args)
+	checker, _ := NewSetGuardianExecutableChecker(setGuardianFunc)
+
+	account := mockvm.NewUserAccount(userAddress)
+	vmInput := getDefaultVmInput([][]byte{generateRandomByteArray(pubKeyLen), []byte{1, 1, 1}})
+
+	err := checker.CheckIsExecutable(account, account, nil)
+	require.Equal(t, ErrNilVmInput, err)
+
+	vmInput.CallValue = big.NewInt(1)
+	err = checker.CheckIsExecutable(account, account, vmInput)
+	require.True(t, errors.Is(err, ErrBuiltInFunctionCalledWithValue))
+	require.Equal(t, err, setGuardianFunc.CheckIsExecutable(userAddress, vmInput.CallValue, vmInput.RecipientAddr, vmInput.GasProvided, vmInput.Arguments))
+	require.Nil(t, accountSetGuardian)
+
+	vmInput.CallValue = big.NewInt(0)
+	err = checker.CheckIsExecutable(account, account, vmInput)
+	require.Equal(t, expectedErr, err)
+	require.NotNil(t, accountSetGuardian)
+	require.False(t, accountSetGuardian == vmcommon.UserAccountHandler(account))
+}
+
 func generateRandomByteArray(size uint32) []byte {
 	ret := make([]byte, size)
 	_, _ = rand.Read(ret)
@@ -0,0 +1,62 @@
+package builtInFunctions
+
+import (
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+)
+
+type tracedBuiltInFunction struct {
+	builtInFunctionWrapper
+	name          string
+	storageTracer StorageTracer
+}
+
+// NewTracedBuiltInFunction wraps the built-in function, so that the storage accesses of each successful call are
+// attached to its VM output
+func NewTracedBuiltInFunction(
+	name string,
+	builtInFunction vmcommon.BuiltinFunction,
+	storageTracer StorageTracer,
+) (*tracedBuiltInFunction, error) {
+	if check.IfNil(builtInFunction) {
+		return nil, ErrNilContainerElement
+	}
+	if check.IfNil(storageTracer) {
+		return nil, ErrNilStorageTracer
+	}
+
+	return &tracedBuiltInFunction{
+		builtInFunctionWrapper: builtInFunctionWrapper{BuiltinFunction: builtInFunction},
+		name:                   name,
+		storageTracer:          storageTracer,
+	}, nil
+}
+
+// ProcessBuiltinFunction calls the wrapped function with the traced accounts and attaches the trace to the output
+func (tbf *tracedBuiltInFunction) ProcessBuiltinFunction(
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	tbf.storageTracer.StartTrace(tbf.name)
+	vmOutput, err := tbf.BuiltinFunction.ProcessBuiltinFunction(
+		tbf.storageTracer.TraceAccount(acntSnd),
+		tbf.storageTracer.TraceAccount(acntDst),
+		vmInput,
+	)
+	trace := tbf.storageTracer.EndTrace()
+	if err == nil && vmOutput != nil {
+		vmOutput.StorageTrace = trace
+	}
+
+	return vmOutput, err
+}
+
+// IsStorageTraced returns true, as the calls share the storage tracer and must be processed sequentially
+func (tbf *tracedBuiltInFunction) IsStorageTraced() bool {
+	return true
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (tbf *tracedBuiltInFunction) IsInterfaceNil() bool {
+	return tbf == nil
+}
@@ -0,0 +1,120 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core"
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	"github.com/subrahamanyam341/andes-core-16/data/dct"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+	"github.com/subrahamanyam341/andes-vm-common-123/mock"
+)
+
+func TestNewTracedBuiltInFunction(t *testing.T) {
+	t.Parallel()
+
+	tracer, _ := NewTracingAccountsAdapter(&mock.AccountsStub{})
+	traced, err := NewTracedBuiltInFunction("function", nil, tracer)
+	assert.Nil(t, traced)
+	assert.Equal(t, ErrNilContainerElement, err)
+
+	traced, err = NewTracedBuiltInFunction("function", &mock.BuiltInFunctionStub{}, nil)
+	assert.Nil(t, traced)
+	assert.Equal(t, ErrNilStorageTracer, err)
+
+	traced, err = NewTracedBuiltInFunction("function", &mock.BuiltInFunctionStub{}, tracer)
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(traced))
+}
+
+func TestTracedBuiltInFunction_ProcessBuiltinFunction(t *testing.T) {
+	t.Parallel()
+
+	t.Run("successful call should attach the trace", func(t *testing.T) {
+		t.Parallel()
+
+		tracer, _ := NewTracingAccountsAdapter(&mock.AccountsStub{})
+		traced, _ := NewTracedBuiltInFunction("function", &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				assert.Nil(t, acntDst)
+				_, _, _ = acntSnd.AccountDataHandler().RetrieveValue([]byte("key"))
+				return &vmcommon.VMOutput{}, nil
+			},
+		}, tracer)
+
+		vmOutput, err := traced.ProcessBuiltinFunction(mock.NewUserAccount([]byte("sender")), nil, &vmcommon.ContractCallInput{})
+		require.Nil(t, err)
+		require.NotNil(t, vmOutput.StorageTrace)
+		assert.Equal(t, "function", vmOutput.StorageTrace.Function)
+		require.Equal(t, 1, len(vmOutput.StorageTrace.Accesses))
+		assert.Equal(t, []byte("sender"), vmOutput.StorageTrace.Accesses[0].Address)
+		assert.Nil(t, tracer.EndTrace())
+	})
+	t.Run("failed call should end the trace", func(t *testing.T) {
+		t.Parallel()
+
+		tracer, _ := NewTracingAccountsAdapter(&mock.AccountsStub{})
+		traced, _ := NewTracedBuiltInFunction("function", &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(_, _ vmcommon.UserAccountHandler, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				return nil, ErrInsufficientFunds
+			},
+		}, tracer)
+
+		vmOutput, err := traced.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+		assert.Nil(t, vmOutput)
+		assert.Equal(t, ErrInsufficientFunds, err)
+		assert.Nil(t, tracer.EndTrace())
+	})
+}
+
+func TestTracedBuiltInFunction_DCTTransferWithInMemoryState(t *testing.T) {
+	t.Parallel()
+
+	marshaller := &mock.MarshalizerMock{}
+	processorArgs := createMockBuiltInFunctionsProcessorArgs()
+	creatorArgs := createMockArguments()
+	creatorArgs.Accounts = processorArgs.Accounts
+	creatorArgs.Marshalizer = marshaller
+	creatorArgs.TraceStorage = true
+	creator, _ := NewBuiltInFunctionsCreator(creatorArgs)
+	require.Nil(t, creator.CreateBuiltInFunctionContainer())
+	require.Nil(t, creator.SetPayableHandler(&mock.PayableHandlerStub{}))
+	processorArgs.BuiltInFunctions = creator.BuiltInFunctionContainer()
+	bfp, _ := NewBuiltInFunctionsProcessor(processorArgs)
+
+	token := vmcommon.TokenIdentifier{TokenID: []byte("TOKEN-abcdef")}
+	sender := bytes.Repeat([]byte{1}, 32)
+	receiver := bytes.Repeat([]byte{2}, 32)
+	account, _ := processorArgs.Accounts.LoadAccount(sender)
+	dctData := &dct.DCToken{Value: big.NewInt(100), Type: uint32(core.Fungible)}
+	require.Nil(t, saveDCTData(account.(vmcommon.UserAccountHandler), dctData, token.StorageKey(), marshaller))
+	require.Nil(t, processorArgs.Accounts.SaveAccount(account))
+
+	input := createBuiltInFunctionsProcessorInput(core.BuiltInFunctionDCTTransfer, sender, receiver)
+	input.Arguments = [][]byte{token.TokenID, big.NewInt(40).Bytes()}
+	vmOutput, err := bfp.ProcessBuiltInFunction(input)
+	require.Nil(t, err)
+	trace := vmOutput.StorageTrace
+	require.NotNil(t, trace)
+	assert.Equal(t, core.BuiltInFunctionDCTTransfer, trace.Function)
+
+	balanceKey := vmcommon.DecodedStorageKey{Kind: vmcommon.DCTBalanceStorageKey, Token: token}
+	globalSettingsKey := vmcommon.DecodedStorageKey{Kind: vmcommon.DCTGlobalSettingsStorageKey, Token: token}
+	hasAccess := func(accesses []*vmcommon.StorageAccess, address []byte, decodedKey vmcommon.DecodedStorageKey) bool {
+		for _, access := range accesses {
+			if bytes.Equal(access.Address, address) && assert.ObjectsAreEqual(decodedKey, access.DecodedKey) {
+				return true
+			}
+		}
+		return false
+	}
+	assert.True(t, hasAccess(trace.Reads(), sender, balanceKey))
+	assert.True(t, hasAccess(trace.Writes(), sender, balanceKey))
+	assert.True(t, hasAccess(trace.Reads(), receiver, balanceKey))
+	assert.True(t, hasAccess(trace.Writes(), receiver, balanceKey))
+	assert.True(t, hasAccess(trace.Reads(), vmcommon.SystemAccountAddress, globalSettingsKey))
+}
@@ -0,0 +1,212 @@
+package builtInFunctions
+
+import (
+	"sync"
+
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+)
+
+var _ vmcommon.AccountsAdapter = (*tracingAccountsAdapter)(nil)
+var _ StorageTracer = (*tracingAccountsAdapter)(nil)
+
+type tracingAccountsAdapter struct {
+	vmcommon.AccountsAdapter
+	mutTraces sync.Mutex
+	traces    []*vmcommon.StorageTrace
+}
+
+// NewTracingAccountsAdapter wraps the accounts adapter, so that the account loads and saves and the data reads and
+// writes of the loaded accounts are recorded in the started trace. Nothing is recorded while no trace is started.
+// The adapter keeps a single stack of traces, not one per call, so tracing is meant for sequential execution only:
+// the accesses of concurrent calls would be mixed in the same trace.
+func NewTracingAccountsAdapter(accounts vmcommon.AccountsAdapter) (*tracingAccountsAdapter, error) {
+	if check.IfNil(accounts) {
+		return nil, ErrNilAccountsAdapter
+	}
+
+	return &tracingAccountsAdapter{
+		AccountsAdapter: accounts,
+	}, nil
+}
+
+// GetExistingAccount returns the traced existing account
+func (adapter *tracingAccountsAdapter) GetExistingAccount(address []byte) (vmcommon.AccountHandler, error) {
+	account, err := adapter.AccountsAdapter.GetExistingAccount(address)
+	adapter.recordAccountAccess(vmcommon.LoadAccountAccess, address, err)
+
+	return adapter.traceAccountHandler(account), err
+}
+
+// LoadAccount returns the traced loaded account
+func (adapter *tracingAccountsAdapter) LoadAccount(address []byte) (vmcommon.AccountHandler, error) {
+	account, err := adapter.AccountsAdapter.LoadAccount(address)
+	adapter.recordAccountAccess(vmcommon.LoadAccountAccess, address, err)
+
+	return adapter.traceAccountHandler(account), err
+}
+
+// SaveAccount saves the account wrapped by the traced account
+func (adapter *tracingAccountsAdapter) SaveAccount(account vmcommon.AccountHandler) error {
+	tracedAccount, ok := account.(*tracingUserAccount)
+	if ok {
+		account = tracedAccount.UserAccountHandler
+	}
+
+	err := adapter.AccountsAdapter.SaveAccount(account)
+	if !check.IfNil(account) {
+		adapter.recordAccountAccess(vmcommon.SaveAccountAccess, account.AddressBytes(), err)
+	}
+
+	return err
+}
+
+// StartTrace starts a new trace for the function. The traces can be nested, the accesses of an ended trace are
+// also added to the trace that was started before it.
+func (adapter *tracingAccountsAdapter) StartTrace(function string) {
+	adapter.mutTraces.Lock()
+	adapter.traces = append(adapter.traces, &vmcommon.StorageTrace{
+		Function: function,
+		Accesses: make([]*vmcommon.StorageAccess, 0),
+	})
+	adapter.mutTraces.Unlock()
+}
+
+// EndTrace ends and returns the last started trace. It returns nil if no trace was started.
+func (adapter *tracingAccountsAdapter) EndTrace() *vmcommon.StorageTrace {
+	adapter.mutTraces.Lock()
+	defer adapter.mutTraces.Unlock()
+
+	numTraces := len(adapter.traces)
+	if numTraces == 0 {
+		return nil
+	}
+
+	trace := adapter.traces[numTraces-1]
+	adapter.traces = adapter.traces[:numTraces-1]
+	if numTraces > 1 {
+		parent := adapter.traces[numTraces-2]
+		parent.Accesses = append(parent.Accesses, trace.Accesses...)
+	}
+
+	return trace
+}
+
+// TraceAccount returns the account wrapped so that its data reads and writes are recorded
+func (adapter *tracingAccountsAdapter) TraceAccount(account vmcommon.UserAccountHandler) vmcommon.UserAccountHandler {
+	if check.IfNil(account) {
+		return account
+	}
+	_, isTraced := account.(*tracingUserAccount)
+	if isTraced {
+		return account
+	}
+
+	return &tracingUserAccount{
+		UserAccountHandler: account,
+		tracer:             adapter,
+	}
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (adapter *tracingAccountsAdapter) IsInterfaceNil() bool {
+	return adapter == nil
+}
+
+func (adapter *tracingAccountsAdapter) traceAccountHandler(account vmcommon.AccountHandler) vmcommon.AccountHandler {
+	userAccount, ok := account.(vmcommon.UserAccountHandler)
+	if !ok {
+		return account
+	}
+
+	return adapter.TraceAccount(userAccount)
+}
+
+func (adapter *tracingAccountsAdapter) recordAccountAccess(kind vmcommon.StorageAccessKind, address []byte, err error) {
+	adapter.record(&vmcommon.StorageAccess{
+		Kind:    kind,
+		Address: cloneBytes(address),
+		Err:     err,
+	})
+}
+
+func (adapter *tracingAccountsAdapter) recordDataAccess(
+	kind vmcommon.StorageAccessKind,
+	address []byte,
+	key []byte,
+	value []byte,
+	err error,
+) {
+	adapter.record(&vmcommon.StorageAccess{
+		Kind:       kind,
+		Address:    cloneBytes(address),
+		Key:        cloneBytes(key),
+		DecodedKey: vmcommon.DecodeStorageKey(address, key),
+		Value:      cloneBytes(value),
+		Err:        err,
+	})
+}
+
+func (adapter *tracingAccountsAdapter) record(access *vmcommon.StorageAccess) {
+	adapter.mutTraces.Lock()
+	defer adapter.mutTraces.Unlock()
+
+	numTraces := len(adapter.traces)
+	if numTraces == 0 {
+		return
+	}
+
+	trace := adapter.traces[numTraces-1]
+	trace.Accesses = append(trace.Accesses, access)
+}
+
+type tracingUserAccount struct {
+	vmcommon.UserAccountHandler
+	tracer *tracingAccountsAdapter
+}
+
+// AccountDataHandler returns the traced data handler of the account
+func (account *tracingUserAccount) AccountDataHandler() vmcommon.AccountDataHandler {
+	dataHandler := account.UserAccountHandler.AccountDataHandler()
+	if check.IfNil(dataHandler) {
+		return dataHandler
+	}
+
+	return &tracingAccountDataHandler{
+		AccountDataHandler: dataHandler,
+		address:            account.AddressBytes(),
+		tracer:             account.tracer,
+	}
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (account *tracingUserAccount) IsInterfaceNil() bool {
+	return account == nil
+}
+
+type tracingAccountDataHandler struct {
+	vmcommon.AccountDataHandler
+	address []byte
+	tracer  *tracingAccountsAdapter
+}
+
+// RetrieveValue returns the value of the key and records the read
+func (handler *tracingAccountDataHandler) RetrieveValue(key []byte) ([]byte, uint32, error) {
+	value, depth, err := handler.AccountDataHandler.RetrieveValue(key)
+	handler.tracer.recordDataAccess(vmcommon.RetrieveValueAccess, handler.address, key, value, err)
+
+	return value, depth, err
+}
+
+// SaveKeyValue saves the value of the key and records the write
+func (handler *tracingAccountDataHandler) SaveKeyValue(key []byte, value []byte) error {
+	err := handler.AccountDataHandler.SaveKeyValue(key, value)
+	handler.tracer.recordDataAccess(vmcommon.SaveKeyValueAccess, handler.address, key, value, err)
+
+	return err
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (handler *tracingAccountDataHandler) IsInterfaceNil() bool {
+	return handler == nil
+}
@@ -0,0 +1,107 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+	"github.com/subrahamanyam341/andes-vm-common-123/mock"
+)
+
+func TestNewTracingAccountsAdapter(t *testing.T) {
+	t.Parallel()
+
+	adapter, err := NewTracingAccountsAdapter(nil)
+	assert.True(t, check.IfNil(adapter))
+	assert.Equal(t, ErrNilAccountsAdapter, err)
+
+	adapter, err = NewTracingAccountsAdapter(&mock.AccountsStub{})
+	assert.False(t, check.IfNil(adapter))
+	assert.Nil(t, err)
+}
+
+func TestTracingAccountsAdapter_RecordsAccesses(t *testing.T) {
+	t.Parallel()
+
+	address := bytes.Repeat([]byte{1}, 32)
+	token := vmcommon.TokenIdentifier{TokenID: []byte("NFT-abcdef"), Nonce: 3}
+	account := mock.NewUserAccount(address)
+	errSave := errors.New("save error")
+	var savedAccount vmcommon.AccountHandler
+	adapter, _ := NewTracingAccountsAdapter(&mock.AccountsStub{
+		LoadAccountCalled: func(_ []byte) (vmcommon.AccountHandler, error) {
+			return account, nil
+		},
+		SaveAccountCalled: func(acnt vmcommon.AccountHandler) error {
+			savedAccount = acnt
+			return errSave
+		},
+	})
+
+	loaded, err := adapter.LoadAccount(address)
+	require.Nil(t, err)
+	userAccount := loaded.(vmcommon.UserAccountHandler)
+	_ = userAccount.AccountDataHandler().SaveKeyValue([]byte("untraced"), []byte("value"))
+
+	adapter.StartTrace("function")
+	key := token.StorageKey()
+	err = userAccount.AccountDataHandler().SaveKeyValue(key, []byte("value"))
+	require.Nil(t, err)
+	key[0] = 0
+	value, _, err := userAccount.AccountDataHandler().RetrieveValue(token.StorageKey())
+	require.Nil(t, err)
+	assert.Equal(t, []byte("value"), value)
+	err = adapter.SaveAccount(loaded)
+	assert.Equal(t, errSave, err)
+	assert.True(t, savedAccount == account)
+	trace := adapter.EndTrace()
+
+	decodedKey := vmcommon.DecodedStorageKey{Kind: vmcommon.DCTBalanceStorageKey, Token: token}
+	expected := &vmcommon.StorageTrace{
+		Function: "function",
+		Accesses: []*vmcommon.StorageAccess{
+			{Kind: vmcommon.SaveKeyValueAccess, Address: address, Key: token.StorageKey(), DecodedKey: decodedKey, Value: []byte("value")},
+			{Kind: vmcommon.RetrieveValueAccess, Address: address, Key: token.StorageKey(), DecodedKey: decodedKey, Value: []byte("value")},
+			{Kind: vmcommon.SaveAccountAccess, Address: address, Err: errSave},
+		},
+	}
+	assert.Equal(t, expected, trace)
+	assert.Nil(t, adapter.EndTrace())
+}
+
+func TestTracingAccountsAdapter_NestedTraces(t *testing.T) {
+	t.Parallel()
+
+	adapter, _ := NewTracingAccountsAdapter(&mock.AccountsStub{})
+	adapter.StartTrace("outer")
+	_, _ = adapter.LoadAccount([]byte("first"))
+	adapter.StartTrace("inner")
+	_, _ = adapter.GetExistingAccount([]byte("second"))
+
+	inner := adapter.EndTrace()
+	require.Equal(t, 1, len(inner.Accesses))
+	assert.Equal(t, []byte("second"), inner.Accesses[0].Address)
+	assert.NotNil(t, inner.Accesses[0].Err)
+
+	outer := adapter.EndTrace()
+	require.Equal(t, 2, len(outer.Accesses))
+	assert.Equal(t, []byte("first"), outer.Accesses[0].Address)
+	assert.Equal(t, []byte("second"), outer.Accesses[1].Address)
+}
+
+func TestTracingAccountsAdapter_TraceAccount(t *testing.T) {
+	t.Parallel()
+
+	adapter, _ := NewTracingAccountsAdapter(&mock.AccountsStub{})
+	assert.Nil(t, adapter.TraceAccount(nil))
+
+	traced := adapter.TraceAccount(mock.NewUserAccount([]byte("address")))
+	_, ok := traced.(*tracingUserAccount)
+	assert.True(t, ok)
+	assert.True(t, traced == adapter.TraceAccount(traced))
+	assert.Nil(t, adapter.TraceAccount(&mock.UserAccountStub{}).AccountDataHandler())
+}
@@ -49,6 +49,24 @@ func (ua *unGuardAccountFunc) ProcessBuiltinFunction(
 	}, nil
 }
 
+// CheckIsExecutable will check if the un-guard account built-in function can be executed. The code metadata
+// is changed on a view of the sender account, so the account is not changed.
+func (ua *unGuardAccountFunc) CheckIsExecutable(
+	acntSnd, _ vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) error {
+	ua.mutExecution.RLock()
+	defer ua.mutExecution.RUnlock()
+
+	err := ua.checkGuardAccountArgs(acntSnd, vmInput)
+	if err != nil {
+		return err
+	}
+
+	ctx := newDryRunContext()
+	return unGuardAccount(ctx.userAccount(acntSnd))
+}
+
 func unGuardAccount(account vmcommon.UserAccountHandler) error {
 	codeMetaData := getCodeMetaData(account)
 	if !codeMetaData.Guarded {
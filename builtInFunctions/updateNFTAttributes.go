@@ -9,6 +9,12 @@ import (
 	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
 )
 
+var nftUpdateAttributesArgumentsSchema = NewArgumentsSchema(
+	BytesArgument("tokenIdentifier"),
+	BytesArgument("nonce"),
+	BytesArgument("attributes"),
+)
+
 type dctNFTupdate struct {
 	baseActiveHandler
 	keyPrefix             []byte
@@ -85,8 +91,9 @@ func (e *dctNFTupdate) ProcessBuiltinFunction(
 	if err != nil {
 		return nil, err
 	}
-	if len(vmInput.Arguments) != 3 {
-		return nil, ErrInvalidArguments
+	err = nftUpdateAttributesArgumentsSchema.Validate(vmInput)
+	if err != nil {
+		return nil, err
 	}
 
 	err = e.rolesHandler.CheckAllowedToExecute(acntSnd, vmInput.Arguments[0], []byte(core.DCTRoleNFTUpdateAttributes))
@@ -126,6 +133,37 @@ func (e *dctNFTupdate) ProcessBuiltinFunction(
 	return vmOutput, nil
 }
 
+// CheckIsExecutable checks if the NFT attributes update can be executed, without changing the accounts
+func (e *dctNFTupdate) CheckIsExecutable(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) error {
+	ctx := newDryRunContext()
+	dryRun, err := e.dryRunCopy(ctx)
+	if err != nil {
+		return err
+	}
+
+	return ctx.checkIsExecutable(dryRun, acntSnd, acntDst, vmInput)
+}
+
+func (e *dctNFTupdate) dryRunCopy(ctx *dryRunContext) (*dctNFTupdate, error) {
+	storageHandler, err := ctx.storageHandler(e.dctStorageHandler)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	return &dctNFTupdate{
+		baseActiveHandler:     e.baseActiveHandler,
+		keyPrefix:             e.keyPrefix,
+		dctStorageHandler:     storageHandler,
+		globalSettingsHandler: e.globalSettingsHandler,
+		rolesHandler:          e.rolesHandler,
+		gasConfig:             e.gasConfig,
+		funcGasCost:           e.funcGasCost,
+	}, nil
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (e *dctNFTupdate) IsInterfaceNil() bool {
 	return e == nil
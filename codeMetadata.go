@@ -1,5 +1,11 @@
 package vmcommon
 
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
 const lengthOfCodeMetadata = 2
 
 // Const group for the first byte of the metadata
@@ -20,6 +26,19 @@ const (
 	MetadataPayableBySC = 4
 )
 
+const (
+	upgradeableName = "upgradeable"
+	readableName    = "readable"
+	guardedName     = "guarded"
+	payableName     = "payable"
+	payableBySCName = "payableBySC"
+)
+
+var knownMetadataBits = [lengthOfCodeMetadata]byte{
+	MetadataUpgradeable | MetadataReadable | MetadataGuarded,
+	MetadataPayable | MetadataPayableBySC,
+}
+
 // CodeMetadata represents smart contract code metadata
 type CodeMetadata struct {
 	Payable     bool
@@ -27,13 +46,30 @@ type CodeMetadata struct {
 	Upgradeable bool
 	Readable    bool
 	Guarded     bool
+
+	// unknownBits holds the bits without a flag, so that they are not lost when the metadata is written back
+	unknownBits [lengthOfCodeMetadata]byte
 }
 
-// CodeMetadataFromBytes creates a metadata object from bytes
+// CodeMetadataFromBytes creates a metadata object from bytes. The metadata is empty if the length is not valid.
+// The unknown bits are dropped, so that the legacy callers keep writing back the same bytes; use
+// ParseCodeMetadataBytes to keep them.
 func CodeMetadataFromBytes(bytes []byte) CodeMetadata {
-	if len(bytes) != lengthOfCodeMetadata {
+	metadata, err := ParseCodeMetadataBytes(bytes)
+	if err != nil {
 		return CodeMetadata{}
 	}
+	metadata.unknownBits = [lengthOfCodeMetadata]byte{}
+
+	return metadata
+}
+
+// ParseCodeMetadataBytes creates a metadata object from bytes, keeping the unknown bits. It returns an error if the
+// length is not valid.
+func ParseCodeMetadataBytes(bytes []byte) (CodeMetadata, error) {
+	if len(bytes) != lengthOfCodeMetadata {
+		return CodeMetadata{}, fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidCodeMetadata, lengthOfCodeMetadata, len(bytes))
+	}
 
 	return CodeMetadata{
 		Upgradeable: (bytes[0] & MetadataUpgradeable) != 0,
@@ -41,12 +77,93 @@ func CodeMetadataFromBytes(bytes []byte) CodeMetadata {
 		Guarded:     (bytes[0] & MetadataGuarded) != 0,
 		Payable:     (bytes[1] & MetadataPayable) != 0,
 		PayableBySC: (bytes[1] & MetadataPayableBySC) != 0,
+		unknownBits: [lengthOfCodeMetadata]byte{
+			bytes[0] &^ knownMetadataBits[0],
+			bytes[1] &^ knownMetadataBits[1],
+		},
+	}, nil
+}
+
+// ParseCodeMetadata creates a metadata object from its string form: a comma separated list of the flag names,
+// as in "upgradeable,readable,payable", followed by the hex encoded unknown bits, if any, as written by String
+func ParseCodeMetadata(str string) (CodeMetadata, error) {
+	metadata := CodeMetadata{}
+	if len(strings.TrimSpace(str)) == 0 {
+		return metadata, nil
 	}
+
+	seen := make(map[string]struct{})
+	for _, part := range strings.Split(str, ",") {
+		name := strings.TrimSpace(part)
+		if _, found := seen[name]; found {
+			return CodeMetadata{}, fmt.Errorf("%w: duplicated %q", ErrInvalidCodeMetadata, name)
+		}
+		seen[name] = struct{}{}
+
+		switch name {
+		case upgradeableName:
+			metadata.Upgradeable = true
+		case readableName:
+			metadata.Readable = true
+		case guardedName:
+			metadata.Guarded = true
+		case payableName:
+			metadata.Payable = true
+		case payableBySCName:
+			metadata.PayableBySC = true
+		default:
+			err := metadata.setUnknownBits(name)
+			if err != nil {
+				return CodeMetadata{}, err
+			}
+		}
+	}
+
+	return metadata, nil
+}
+
+func (metadata *CodeMetadata) setUnknownBits(encoded string) error {
+	bits, err := hex.DecodeString(strings.TrimPrefix(encoded, "0x"))
+	if !strings.HasPrefix(encoded, "0x") || err != nil || len(bits) != lengthOfCodeMetadata {
+		return fmt.Errorf("%w: unknown flag %q", ErrInvalidCodeMetadata, encoded)
+	}
+	if bits[0]&knownMetadataBits[0] != 0 || bits[1]&knownMetadataBits[1] != 0 {
+		return fmt.Errorf("%w: %q sets known flags", ErrInvalidCodeMetadata, encoded)
+	}
+
+	metadata.unknownBits = [lengthOfCodeMetadata]byte{bits[0], bits[1]}
+	return nil
+}
+
+// String returns the comma separated list of the set flags, followed by the hex encoded unknown bits, if any
+func (metadata CodeMetadata) String() string {
+	names := make([]string, 0)
+	if metadata.Upgradeable {
+		names = append(names, upgradeableName)
+	}
+	if metadata.Readable {
+		names = append(names, readableName)
+	}
+	if metadata.Guarded {
+		names = append(names, guardedName)
+	}
+	if metadata.Payable {
+		names = append(names, payableName)
+	}
+	if metadata.PayableBySC {
+		names = append(names, payableBySCName)
+	}
+	if metadata.unknownBits != [lengthOfCodeMetadata]byte{} {
+		names = append(names, "0x"+hex.EncodeToString(metadata.unknownBits[:]))
+	}
+
+	return strings.Join(names, ",")
 }
 
-// ToBytes converts the metadata to bytes
+// ToBytes converts the metadata to bytes, including the unknown bits kept by the strict and string parsers
 func (metadata *CodeMetadata) ToBytes() []byte {
 	bytes := make([]byte, lengthOfCodeMetadata)
+	copy(bytes, metadata.unknownBits[:])
 
 	if metadata.Upgradeable {
 		bytes[0] |= MetadataUpgradeable
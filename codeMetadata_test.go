@@ -35,3 +35,68 @@ func TestCodeMetadata_ToBytes(t *testing.T) {
 	require.Equal(t, byte(4), (&CodeMetadata{PayableBySC: true}).ToBytes()[1])
 	require.Equal(t, byte(8), (&CodeMetadata{Guarded: true}).ToBytes()[0])
 }
+
+func TestCodeMetadata_FromBytesShouldDropUnknownBits(t *testing.T) {
+	metadata := CodeMetadataFromBytes([]byte{0x19, 0x83})
+	require.Equal(t, CodeMetadata{Upgradeable: true, Guarded: true, Payable: true}, metadata)
+	require.Equal(t, []byte{0x09, 0x02}, metadata.ToBytes())
+}
+
+func TestCodeMetadata_ShouldKeepUnknownBits(t *testing.T) {
+	metadata, err := ParseCodeMetadataBytes([]byte{0x11, 0x81})
+	require.Nil(t, err)
+	require.True(t, metadata.Upgradeable)
+	require.False(t, metadata.Payable)
+	require.Equal(t, []byte{0x11, 0x81}, metadata.ToBytes())
+
+	metadata.Upgradeable = false
+	metadata.Payable = true
+	require.Equal(t, []byte{0x10, 0x83}, metadata.ToBytes())
+}
+
+func TestParseCodeMetadataBytes(t *testing.T) {
+	_, err := ParseCodeMetadataBytes([]byte{1})
+	require.ErrorIs(t, err, ErrInvalidCodeMetadata)
+	_, err = ParseCodeMetadataBytes([]byte{1, 2, 0})
+	require.ErrorIs(t, err, ErrInvalidCodeMetadata)
+
+	metadata, err := ParseCodeMetadataBytes([]byte{5, 4})
+	require.Nil(t, err)
+	require.Equal(t, CodeMetadata{Upgradeable: true, Readable: true, PayableBySC: true}, metadata)
+}
+
+func TestCodeMetadata_String(t *testing.T) {
+	require.Equal(t, "", CodeMetadata{}.String())
+	require.Equal(t, "upgradeable,readable,payable", CodeMetadata{Upgradeable: true, Readable: true, Payable: true}.String())
+	metadata, _ := ParseCodeMetadataBytes([]byte{0x18, 0x05})
+	require.Equal(t, "guarded,payableBySC,0x1001", metadata.String())
+}
+
+func TestParseCodeMetadata(t *testing.T) {
+	metadata, err := ParseCodeMetadata("upgradeable,readable,payable")
+	require.Nil(t, err)
+	require.Equal(t, CodeMetadata{Upgradeable: true, Readable: true, Payable: true}, metadata)
+
+	metadata, err = ParseCodeMetadata(" payable , upgradeable ")
+	require.Nil(t, err)
+	require.Equal(t, CodeMetadata{Upgradeable: true, Payable: true}, metadata)
+
+	metadata, err = ParseCodeMetadata("")
+	require.Nil(t, err)
+	require.Equal(t, CodeMetadata{}, metadata)
+
+	for _, bytes := range [][]byte{{0, 0}, {0xff, 0xff}, {0x18, 0x05}, {0x01, 0x02}} {
+		metadata, err = ParseCodeMetadataBytes(bytes)
+		require.Nil(t, err)
+		parsed, errParse := ParseCodeMetadata(metadata.String())
+		require.Nil(t, errParse)
+		require.Equal(t, metadata, parsed)
+		require.Equal(t, bytes, parsed.ToBytes())
+	}
+
+	invalid := []string{"upgradable", "payable,payable", "payable,", "1001", "0x0100", "0x10"}
+	for _, str := range invalid {
+		_, err = ParseCodeMetadata(str)
+		require.ErrorIs(t, err, ErrInvalidCodeMetadata, str)
+	}
+}
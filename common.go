@@ -9,8 +9,6 @@ import (
 const tickerMinLength = 3
 const tickerMaxLength = 10
 const additionalRandomCharsLength = 6
-const identifierMinLength = tickerMinLength + additionalRandomCharsLength + 1
-const identifierMaxLength = tickerMaxLength + additionalRandomCharsLength + 1
 
 // DCTDeleteMetadata represents the defined built in function name for dct delete metadata
 const DCTDeleteMetadata = "DCTDeleteMetadata"
@@ -35,27 +33,7 @@ const DCTRoleBurnForAll = "DCTRoleBurnForAll"
 
 // ValidateToken - validates the token ID
 func ValidateToken(tokenID []byte) bool {
-	tokenIDLen := len(tokenID)
-	if tokenIDLen < identifierMinLength || tokenIDLen > identifierMaxLength {
-		return false
-	}
-
-	tickerLen := tokenIDLen - additionalRandomCharsLength
-
-	if !isTickerValid(tokenID[0 : tickerLen-1]) {
-		return false
-	}
-
-	// dash char between the random chars and the ticker
-	if tokenID[tickerLen-1] != '-' {
-		return false
-	}
-
-	if !randomCharsAreValid(tokenID[tickerLen:tokenIDLen]) {
-		return false
-	}
-
-	return true
+	return TokenIdentifier{TokenID: tokenID}.Validate() == nil
 }
 
 // ticker must be all uppercase alphanumeric
@@ -0,0 +1,47 @@
+package enablers
+
+// EnableEpochs holds the epochs in which the VM features are activated
+type EnableEpochs struct {
+	// GlobalMintBurnDisableEpoch is the epoch in which the global mint and burn is disabled
+	GlobalMintBurnDisableEpoch                               uint32
+	DCTTransferRoleEnableEpoch                               uint32
+	BuiltInFunctionsEnableEpoch                              uint32
+	CheckCorrectTokenIDForTransferRoleEnableEpoch            uint32
+	MultiDCTTransferFixOnCallBackOnEnableEpoch               uint32
+	FixOOGReturnCodeEnableEpoch                              uint32
+	RemoveNonUpdatedStorageEnableEpoch                       uint32
+	CreateNFTThroughExecByCallerEnableEpoch                  uint32
+	StorageAPICostOptimizationEnableEpoch                    uint32
+	FailExecutionOnEveryAPIErrorEnableEpoch                  uint32
+	ManagedCryptoAPIsEnableEpoch                             uint32
+	SCDeployEnableEpoch                                      uint32
+	AheadOfTimeGasUsageEnableEpoch                           uint32
+	RepairCallbackEnableEpoch                                uint32
+	DisableExecByCallerEnableEpoch                           uint32
+	RefactorContextEnableEpoch                               uint32
+	CheckFunctionArgumentEnableEpoch                         uint32
+	CheckExecuteOnReadOnlyEnableEpoch                        uint32
+	FixAsyncCallbackCheckEnableEpoch                         uint32
+	SaveToSystemAccountEnableEpoch                           uint32
+	CheckFrozenCollectionEnableEpoch                         uint32
+	SendAlwaysEnableEpoch                                    uint32
+	ValueLengthCheckEnableEpoch                              uint32
+	CheckTransferEnableEpoch                                 uint32
+	TransferToMetaEnableEpoch                                uint32
+	DCTNFTImprovementV1EnableEpoch                           uint32
+	FixOldTokenLiquidityEnableEpoch                          uint32
+	RuntimeMemStoreLimitEnableEpoch                          uint32
+	MaxBlockchainHookCountersEnableEpoch                     uint32
+	WipeSingleNFTLiquidityDecreaseEnableEpoch                uint32
+	AlwaysSaveTokenMetaDataEnableEpoch                       uint32
+	RuntimeCodeSizeFixEnableEpoch                            uint32
+	ChangeUsernameEnableEpoch                                uint32
+	DynamicGasCostForDataTrieStorageLoadEnableEpoch          uint32
+	SetGuardianEnableEpoch                                   uint32
+	ScToScLogEventEnableEpoch                                uint32
+	ConsistentTokensValuesLengthCheckEnableEpoch             uint32
+	AutoBalanceDataTriesEnableEpoch                          uint32
+	MigrateDataTrieEnableEpoch                               uint32
+	ChangeOwnerAddressCrossShardThroughSCEnableEpoch         uint32
+	FixGasRemainingForSaveKeyValueBuiltinFunctionEnableEpoch uint32
+}
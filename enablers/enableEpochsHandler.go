@@ -0,0 +1,399 @@
+package enablers
+
+import (
+	"sync/atomic"
+
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+)
+
+var _ vmcommon.EnableEpochsHandler = (*enableEpochsHandler)(nil)
+
+type flag int
+
+const (
+	globalMintBurnFlag flag = iota
+	dctTransferRoleFlag
+	builtInFunctionsFlag
+	checkCorrectTokenIDForTransferRoleFlag
+	multiDCTTransferFixOnCallBackFlag
+	fixOOGReturnCodeFlag
+	removeNonUpdatedStorageFlag
+	createNFTThroughExecByCallerFlag
+	storageAPICostOptimizationFlag
+	failExecutionOnEveryAPIErrorFlag
+	managedCryptoAPIsFlag
+	scDeployFlag
+	aheadOfTimeGasUsageFlag
+	repairCallbackFlag
+	disableExecByCallerFlag
+	refactorContextFlag
+	checkFunctionArgumentFlag
+	checkExecuteOnReadOnlyFlag
+	fixAsyncCallbackCheckFlag
+	saveToSystemAccountFlag
+	checkFrozenCollectionFlag
+	sendAlwaysFlag
+	valueLengthCheckFlag
+	checkTransferFlag
+	transferToMetaFlag
+	dctNFTImprovementV1Flag
+	fixOldTokenLiquidityFlag
+	runtimeMemStoreLimitFlag
+	maxBlockchainHookCountersFlag
+	wipeSingleNFTLiquidityDecreaseFlag
+	alwaysSaveTokenMetaDataFlag
+	runtimeCodeSizeFixFlag
+	changeUsernameFlag
+	dynamicGasCostForDataTrieStorageLoadFlag
+	setGuardianFlag
+	scToScEventLogFlag
+	consistentTokensValuesLengthCheckFlag
+	autoBalanceDataTriesFlag
+	migrateDataTrieFlag
+	changeOwnerAddressCrossShardThroughSCFlag
+	fixGasRemainingForSaveKeyValueBuiltinFunctionFlag
+	numFlags
+)
+
+type epochFlags [numFlags]bool
+
+// ArgsNewEnableEpochsHandler is the argument structure used to create a new enable epochs handler
+type ArgsNewEnableEpochsHandler struct {
+	EnableEpochs  EnableEpochs
+	EpochNotifier vmcommon.EpochNotifier
+}
+
+type enableEpochsHandler struct {
+	enableEpochs EnableEpochs
+	flags        atomic.Pointer[epochFlags]
+}
+
+// NewEnableEpochsHandler creates a handler that computes the VM flags from the enable epochs config. The flags are
+// computed for epoch 0 and then updated on every epoch confirmed by the epoch notifier.
+func NewEnableEpochsHandler(args ArgsNewEnableEpochsHandler) (*enableEpochsHandler, error) {
+	if check.IfNil(args.EpochNotifier) {
+		return nil, ErrNilEpochNotifier
+	}
+
+	handler := &enableEpochsHandler{
+		enableEpochs: args.EnableEpochs,
+	}
+	handler.EpochConfirmed(0, 0)
+	args.EpochNotifier.RegisterNotifyHandler(handler)
+
+	return handler, nil
+}
+
+// EpochConfirmed computes all the flags for the new epoch and replaces them at once
+func (handler *enableEpochsHandler) EpochConfirmed(epoch uint32, _ uint64) {
+	cfg := handler.enableEpochs
+	flags := &epochFlags{}
+	flags[globalMintBurnFlag] = epoch < cfg.GlobalMintBurnDisableEpoch
+	flags[dctTransferRoleFlag] = epoch >= cfg.DCTTransferRoleEnableEpoch
+	flags[builtInFunctionsFlag] = epoch >= cfg.BuiltInFunctionsEnableEpoch
+	flags[checkCorrectTokenIDForTransferRoleFlag] = epoch >= cfg.CheckCorrectTokenIDForTransferRoleEnableEpoch
+	flags[multiDCTTransferFixOnCallBackFlag] = epoch >= cfg.MultiDCTTransferFixOnCallBackOnEnableEpoch
+	flags[fixOOGReturnCodeFlag] = epoch >= cfg.FixOOGReturnCodeEnableEpoch
+	flags[removeNonUpdatedStorageFlag] = epoch >= cfg.RemoveNonUpdatedStorageEnableEpoch
+	flags[createNFTThroughExecByCallerFlag] = epoch >= cfg.CreateNFTThroughExecByCallerEnableEpoch
+	flags[storageAPICostOptimizationFlag] = epoch >= cfg.StorageAPICostOptimizationEnableEpoch
+	flags[failExecutionOnEveryAPIErrorFlag] = epoch >= cfg.FailExecutionOnEveryAPIErrorEnableEpoch
+	flags[managedCryptoAPIsFlag] = epoch >= cfg.ManagedCryptoAPIsEnableEpoch
+	flags[scDeployFlag] = epoch >= cfg.SCDeployEnableEpoch
+	flags[aheadOfTimeGasUsageFlag] = epoch >= cfg.AheadOfTimeGasUsageEnableEpoch
+	flags[repairCallbackFlag] = epoch >= cfg.RepairCallbackEnableEpoch
+	flags[disableExecByCallerFlag] = epoch >= cfg.DisableExecByCallerEnableEpoch
+	flags[refactorContextFlag] = epoch >= cfg.RefactorContextEnableEpoch
+	flags[checkFunctionArgumentFlag] = epoch >= cfg.CheckFunctionArgumentEnableEpoch
+	flags[checkExecuteOnReadOnlyFlag] = epoch >= cfg.CheckExecuteOnReadOnlyEnableEpoch
+	flags[fixAsyncCallbackCheckFlag] = epoch >= cfg.FixAsyncCallbackCheckEnableEpoch
+	flags[saveToSystemAccountFlag] = epoch >= cfg.SaveToSystemAccountEnableEpoch
+	flags[checkFrozenCollectionFlag] = epoch >= cfg.CheckFrozenCollectionEnableEpoch
+	flags[sendAlwaysFlag] = epoch >= cfg.SendAlwaysEnableEpoch
+	flags[valueLengthCheckFlag] = epoch >= cfg.ValueLengthCheckEnableEpoch
+	flags[checkTransferFlag] = epoch >= cfg.CheckTransferEnableEpoch
+	flags[transferToMetaFlag] = epoch >= cfg.TransferToMetaEnableEpoch
+	flags[dctNFTImprovementV1Flag] = epoch >= cfg.DCTNFTImprovementV1EnableEpoch
+	flags[fixOldTokenLiquidityFlag] = epoch >= cfg.FixOldTokenLiquidityEnableEpoch
+	flags[runtimeMemStoreLimitFlag] = epoch >= cfg.RuntimeMemStoreLimitEnableEpoch
+	flags[maxBlockchainHookCountersFlag] = epoch >= cfg.MaxBlockchainHookCountersEnableEpoch
+	flags[wipeSingleNFTLiquidityDecreaseFlag] = epoch >= cfg.WipeSingleNFTLiquidityDecreaseEnableEpoch
+	flags[alwaysSaveTokenMetaDataFlag] = epoch >= cfg.AlwaysSaveTokenMetaDataEnableEpoch
+	flags[runtimeCodeSizeFixFlag] = epoch >= cfg.RuntimeCodeSizeFixEnableEpoch
+	flags[changeUsernameFlag] = epoch >= cfg.ChangeUsernameEnableEpoch
+	flags[dynamicGasCostForDataTrieStorageLoadFlag] = epoch >= cfg.DynamicGasCostForDataTrieStorageLoadEnableEpoch
+	flags[setGuardianFlag] = epoch >= cfg.SetGuardianEnableEpoch
+	flags[scToScEventLogFlag] = epoch >= cfg.ScToScLogEventEnableEpoch
+	flags[consistentTokensValuesLengthCheckFlag] = epoch >= cfg.ConsistentTokensValuesLengthCheckEnableEpoch
+	flags[autoBalanceDataTriesFlag] = epoch >= cfg.AutoBalanceDataTriesEnableEpoch
+	flags[migrateDataTrieFlag] = epoch >= cfg.MigrateDataTrieEnableEpoch
+	flags[changeOwnerAddressCrossShardThroughSCFlag] = epoch >= cfg.ChangeOwnerAddressCrossShardThroughSCEnableEpoch
+	flags[fixGasRemainingForSaveKeyValueBuiltinFunctionFlag] = epoch >= cfg.FixGasRemainingForSaveKeyValueBuiltinFunctionEnableEpoch
+
+	handler.flags.Store(flags)
+}
+
+func (handler *enableEpochsHandler) isFlagEnabled(f flag) bool {
+	return handler.flags.Load()[f]
+}
+
+// IsGlobalMintBurnFlagEnabled returns true before the GlobalMintBurnDisableEpoch
+func (handler *enableEpochsHandler) IsGlobalMintBurnFlagEnabled() bool {
+	return handler.isFlagEnabled(globalMintBurnFlag)
+}
+
+// IsDCTTransferRoleFlagEnabled returns true if DCTTransferRoleEnableEpoch was reached
+func (handler *enableEpochsHandler) IsDCTTransferRoleFlagEnabled() bool {
+	return handler.isFlagEnabled(dctTransferRoleFlag)
+}
+
+// IsBuiltInFunctionsFlagEnabled returns true if BuiltInFunctionsEnableEpoch was reached
+func (handler *enableEpochsHandler) IsBuiltInFunctionsFlagEnabled() bool {
+	return handler.isFlagEnabled(builtInFunctionsFlag)
+}
+
+// IsCheckCorrectTokenIDForTransferRoleFlagEnabled returns true if CheckCorrectTokenIDForTransferRoleEnableEpoch was reached
+func (handler *enableEpochsHandler) IsCheckCorrectTokenIDForTransferRoleFlagEnabled() bool {
+	return handler.isFlagEnabled(checkCorrectTokenIDForTransferRoleFlag)
+}
+
+// IsMultiDCTTransferFixOnCallBackFlagEnabled returns true if MultiDCTTransferFixOnCallBackOnEnableEpoch was reached
+func (handler *enableEpochsHandler) IsMultiDCTTransferFixOnCallBackFlagEnabled() bool {
+	return handler.isFlagEnabled(multiDCTTransferFixOnCallBackFlag)
+}
+
+// IsFixOOGReturnCodeFlagEnabled returns true if FixOOGReturnCodeEnableEpoch was reached
+func (handler *enableEpochsHandler) IsFixOOGReturnCodeFlagEnabled() bool {
+	return handler.isFlagEnabled(fixOOGReturnCodeFlag)
+}
+
+// IsRemoveNonUpdatedStorageFlagEnabled returns true if RemoveNonUpdatedStorageEnableEpoch was reached
+func (handler *enableEpochsHandler) IsRemoveNonUpdatedStorageFlagEnabled() bool {
+	return handler.isFlagEnabled(removeNonUpdatedStorageFlag)
+}
+
+// IsCreateNFTThroughExecByCallerFlagEnabled returns true if CreateNFTThroughExecByCallerEnableEpoch was reached
+func (handler *enableEpochsHandler) IsCreateNFTThroughExecByCallerFlagEnabled() bool {
+	return handler.isFlagEnabled(createNFTThroughExecByCallerFlag)
+}
+
+// IsStorageAPICostOptimizationFlagEnabled returns true if StorageAPICostOptimizationEnableEpoch was reached
+func (handler *enableEpochsHandler) IsStorageAPICostOptimizationFlagEnabled() bool {
+	return handler.isFlagEnabled(storageAPICostOptimizationFlag)
+}
+
+// IsFailExecutionOnEveryAPIErrorFlagEnabled returns true if FailExecutionOnEveryAPIErrorEnableEpoch was reached
+func (handler *enableEpochsHandler) IsFailExecutionOnEveryAPIErrorFlagEnabled() bool {
+	return handler.isFlagEnabled(failExecutionOnEveryAPIErrorFlag)
+}
+
+// IsManagedCryptoAPIsFlagEnabled returns true if ManagedCryptoAPIsEnableEpoch was reached
+func (handler *enableEpochsHandler) IsManagedCryptoAPIsFlagEnabled() bool {
+	return handler.isFlagEnabled(managedCryptoAPIsFlag)
+}
+
+// IsSCDeployFlagEnabled returns true if SCDeployEnableEpoch was reached
+func (handler *enableEpochsHandler) IsSCDeployFlagEnabled() bool {
+	return handler.isFlagEnabled(scDeployFlag)
+}
+
+// IsAheadOfTimeGasUsageFlagEnabled returns true if AheadOfTimeGasUsageEnableEpoch was reached
+func (handler *enableEpochsHandler) IsAheadOfTimeGasUsageFlagEnabled() bool {
+	return handler.isFlagEnabled(aheadOfTimeGasUsageFlag)
+}
+
+// IsRepairCallbackFlagEnabled returns true if RepairCallbackEnableEpoch was reached
+func (handler *enableEpochsHandler) IsRepairCallbackFlagEnabled() bool {
+	return handler.isFlagEnabled(repairCallbackFlag)
+}
+
+// IsDisableExecByCallerFlagEnabled returns true if DisableExecByCallerEnableEpoch was reached
+func (handler *enableEpochsHandler) IsDisableExecByCallerFlagEnabled() bool {
+	return handler.isFlagEnabled(disableExecByCallerFlag)
+}
+
+// IsRefactorContextFlagEnabled returns true if RefactorContextEnableEpoch was reached
+func (handler *enableEpochsHandler) IsRefactorContextFlagEnabled() bool {
+	return handler.isFlagEnabled(refactorContextFlag)
+}
+
+// IsCheckFunctionArgumentFlagEnabled returns true if CheckFunctionArgumentEnableEpoch was reached
+func (handler *enableEpochsHandler) IsCheckFunctionArgumentFlagEnabled() bool {
+	return handler.isFlagEnabled(checkFunctionArgumentFlag)
+}
+
+// IsCheckExecuteOnReadOnlyFlagEnabled returns true if CheckExecuteOnReadOnlyEnableEpoch was reached
+func (handler *enableEpochsHandler) IsCheckExecuteOnReadOnlyFlagEnabled() bool {
+	return handler.isFlagEnabled(checkExecuteOnReadOnlyFlag)
+}
+
+// IsFixAsyncCallbackCheckFlagEnabled returns true if FixAsyncCallbackCheckEnableEpoch was reached
+func (handler *enableEpochsHandler) IsFixAsyncCallbackCheckFlagEnabled() bool {
+	return handler.isFlagEnabled(fixAsyncCallbackCheckFlag)
+}
+
+// IsSaveToSystemAccountFlagEnabled returns true if SaveToSystemAccountEnableEpoch was reached
+func (handler *enableEpochsHandler) IsSaveToSystemAccountFlagEnabled() bool {
+	return handler.isFlagEnabled(saveToSystemAccountFlag)
+}
+
+// IsCheckFrozenCollectionFlagEnabled returns true if CheckFrozenCollectionEnableEpoch was reached
+func (handler *enableEpochsHandler) IsCheckFrozenCollectionFlagEnabled() bool {
+	return handler.isFlagEnabled(checkFrozenCollectionFlag)
+}
+
+// IsSendAlwaysFlagEnabled returns true if SendAlwaysEnableEpoch was reached
+func (handler *enableEpochsHandler) IsSendAlwaysFlagEnabled() bool {
+	return handler.isFlagEnabled(sendAlwaysFlag)
+}
+
+// IsValueLengthCheckFlagEnabled returns true if ValueLengthCheckEnableEpoch was reached
+func (handler *enableEpochsHandler) IsValueLengthCheckFlagEnabled() bool {
+	return handler.isFlagEnabled(valueLengthCheckFlag)
+}
+
+// IsCheckTransferFlagEnabled returns true if CheckTransferEnableEpoch was reached
+func (handler *enableEpochsHandler) IsCheckTransferFlagEnabled() bool {
+	return handler.isFlagEnabled(checkTransferFlag)
+}
+
+// IsTransferToMetaFlagEnabled returns true if TransferToMetaEnableEpoch was reached
+func (handler *enableEpochsHandler) IsTransferToMetaFlagEnabled() bool {
+	return handler.isFlagEnabled(transferToMetaFlag)
+}
+
+// IsDCTNFTImprovementV1FlagEnabled returns true if DCTNFTImprovementV1EnableEpoch was reached
+func (handler *enableEpochsHandler) IsDCTNFTImprovementV1FlagEnabled() bool {
+	return handler.isFlagEnabled(dctNFTImprovementV1Flag)
+}
+
+// IsFixOldTokenLiquidityEnabled returns true if FixOldTokenLiquidityEnableEpoch was reached
+func (handler *enableEpochsHandler) IsFixOldTokenLiquidityEnabled() bool {
+	return handler.isFlagEnabled(fixOldTokenLiquidityFlag)
+}
+
+// IsRuntimeMemStoreLimitEnabled returns true if RuntimeMemStoreLimitEnableEpoch was reached
+func (handler *enableEpochsHandler) IsRuntimeMemStoreLimitEnabled() bool {
+	return handler.isFlagEnabled(runtimeMemStoreLimitFlag)
+}
+
+// IsMaxBlockchainHookCountersFlagEnabled returns true if MaxBlockchainHookCountersEnableEpoch was reached
+func (handler *enableEpochsHandler) IsMaxBlockchainHookCountersFlagEnabled() bool {
+	return handler.isFlagEnabled(maxBlockchainHookCountersFlag)
+}
+
+// IsWipeSingleNFTLiquidityDecreaseEnabled returns true if WipeSingleNFTLiquidityDecreaseEnableEpoch was reached
+func (handler *enableEpochsHandler) IsWipeSingleNFTLiquidityDecreaseEnabled() bool {
+	return handler.isFlagEnabled(wipeSingleNFTLiquidityDecreaseFlag)
+}
+
+// IsAlwaysSaveTokenMetaDataEnabled returns true if AlwaysSaveTokenMetaDataEnableEpoch was reached
+func (handler *enableEpochsHandler) IsAlwaysSaveTokenMetaDataEnabled() bool {
+	return handler.isFlagEnabled(alwaysSaveTokenMetaDataFlag)
+}
+
+// IsRuntimeCodeSizeFixEnabled returns true if RuntimeCodeSizeFixEnableEpoch was reached
+func (handler *enableEpochsHandler) IsRuntimeCodeSizeFixEnabled() bool {
+	return handler.isFlagEnabled(runtimeCodeSizeFixFlag)
+}
+
+// IsChangeUsernameEnabled returns true if ChangeUsernameEnableEpoch was reached
+func (handler *enableEpochsHandler) IsChangeUsernameEnabled() bool {
+	return handler.isFlagEnabled(changeUsernameFlag)
+}
+
+// IsDynamicGasCostForDataTrieStorageLoadEnabled returns true if DynamicGasCostForDataTrieStorageLoadEnableEpoch was reached
+func (handler *enableEpochsHandler) IsDynamicGasCostForDataTrieStorageLoadEnabled() bool {
+	return handler.isFlagEnabled(dynamicGasCostForDataTrieStorageLoadFlag)
+}
+
+// IsSetGuardianEnabled returns true if SetGuardianEnableEpoch was reached
+func (handler *enableEpochsHandler) IsSetGuardianEnabled() bool {
+	return handler.isFlagEnabled(setGuardianFlag)
+}
+
+// IsScToScEventLogEnabled returns true if ScToScLogEventEnableEpoch was reached
+func (handler *enableEpochsHandler) IsScToScEventLogEnabled() bool {
+	return handler.isFlagEnabled(scToScEventLogFlag)
+}
+
+// IsConsistentTokensValuesLengthCheckEnabled returns true if ConsistentTokensValuesLengthCheckEnableEpoch was reached
+func (handler *enableEpochsHandler) IsConsistentTokensValuesLengthCheckEnabled() bool {
+	return handler.isFlagEnabled(consistentTokensValuesLengthCheckFlag)
+}
+
+// IsAutoBalanceDataTriesEnabled returns true if AutoBalanceDataTriesEnableEpoch was reached
+func (handler *enableEpochsHandler) IsAutoBalanceDataTriesEnabled() bool {
+	return handler.isFlagEnabled(autoBalanceDataTriesFlag)
+}
+
+// IsMigrateDataTrieEnabled returns true if MigrateDataTrieEnableEpoch was reached
+func (handler *enableEpochsHandler) IsMigrateDataTrieEnabled() bool {
+	return handler.isFlagEnabled(migrateDataTrieFlag)
+}
+
+// IsChangeOwnerAddressCrossShardThroughSCEnabled returns true if ChangeOwnerAddressCrossShardThroughSCEnableEpoch was reached
+func (handler *enableEpochsHandler) IsChangeOwnerAddressCrossShardThroughSCEnabled() bool {
+	return handler.isFlagEnabled(changeOwnerAddressCrossShardThroughSCFlag)
+}
+
+// FixGasRemainingForSaveKeyValueBuiltinFunctionEnabled returns true if FixGasRemainingForSaveKeyValueBuiltinFunctionEnableEpoch was reached
+func (handler *enableEpochsHandler) FixGasRemainingForSaveKeyValueBuiltinFunctionEnabled() bool {
+	return handler.isFlagEnabled(fixGasRemainingForSaveKeyValueBuiltinFunctionFlag)
+}
+
+// MultiDCTTransferAsyncCallBackEnableEpoch returns the epoch in which the flag is activated
+func (handler *enableEpochsHandler) MultiDCTTransferAsyncCallBackEnableEpoch() uint32 {
+	return handler.enableEpochs.MultiDCTTransferFixOnCallBackOnEnableEpoch
+}
+
+// FixOOGReturnCodeEnableEpoch returns the epoch in which the flag is activated
+func (handler *enableEpochsHandler) FixOOGReturnCodeEnableEpoch() uint32 {
+	return handler.enableEpochs.FixOOGReturnCodeEnableEpoch
+}
+
+// RemoveNonUpdatedStorageEnableEpoch returns the epoch in which the flag is activated
+func (handler *enableEpochsHandler) RemoveNonUpdatedStorageEnableEpoch() uint32 {
+	return handler.enableEpochs.RemoveNonUpdatedStorageEnableEpoch
+}
+
+// CreateNFTThroughExecByCallerEnableEpoch returns the epoch in which the flag is activated
+func (handler *enableEpochsHandler) CreateNFTThroughExecByCallerEnableEpoch() uint32 {
+	return handler.enableEpochs.CreateNFTThroughExecByCallerEnableEpoch
+}
+
+// FixFailExecutionOnErrorEnableEpoch returns the epoch in which the flag is activated
+func (handler *enableEpochsHandler) FixFailExecutionOnErrorEnableEpoch() uint32 {
+	return handler.enableEpochs.FailExecutionOnEveryAPIErrorEnableEpoch
+}
+
+// ManagedCryptoAPIEnableEpoch returns the epoch in which the flag is activated
+func (handler *enableEpochsHandler) ManagedCryptoAPIEnableEpoch() uint32 {
+	return handler.enableEpochs.ManagedCryptoAPIsEnableEpoch
+}
+
+// DisableExecByCallerEnableEpoch returns the epoch in which the flag is activated
+func (handler *enableEpochsHandler) DisableExecByCallerEnableEpoch() uint32 {
+	return handler.enableEpochs.DisableExecByCallerEnableEpoch
+}
+
+// RefactorContextEnableEpoch returns the epoch in which the flag is activated
+func (handler *enableEpochsHandler) RefactorContextEnableEpoch() uint32 {
+	return handler.enableEpochs.RefactorContextEnableEpoch
+}
+
+// CheckExecuteReadOnlyEnableEpoch returns the epoch in which the flag is activated
+func (handler *enableEpochsHandler) CheckExecuteReadOnlyEnableEpoch() uint32 {
+	return handler.enableEpochs.CheckExecuteOnReadOnlyEnableEpoch
+}
+
+// StorageAPICostOptimizationEnableEpoch returns the epoch in which the flag is activated
+func (handler *enableEpochsHandler) StorageAPICostOptimizationEnableEpoch() uint32 {
+	return handler.enableEpochs.StorageAPICostOptimizationEnableEpoch
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (handler *enableEpochsHandler) IsInterfaceNil() bool {
+	return handler == nil
+}
@@ -0,0 +1,284 @@
+package enablers
+
+import (
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+)
+
+var _ vmcommon.EnableEpochsHandler = (*enableEpochsHandlerAdapter)(nil)
+
+type enableEpochsHandlerAdapter struct {
+	registry vmcommon.FlagRegistryHandler
+}
+
+// NewEnableEpochsHandlerAdapter creates a vmcommon.EnableEpochsHandler that reads the flags from the registry, so
+// that the components can move to the flag registry one at a time
+func NewEnableEpochsHandlerAdapter(registry vmcommon.FlagRegistryHandler) (*enableEpochsHandlerAdapter, error) {
+	if check.IfNil(registry) {
+		return nil, ErrNilFlagRegistry
+	}
+
+	return &enableEpochsHandlerAdapter{
+		registry: registry,
+	}, nil
+}
+
+// IsGlobalMintBurnFlagEnabled returns true until GlobalMintBurnDisableFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsGlobalMintBurnFlagEnabled() bool {
+	return !adapter.registry.IsFlagEnabled(GlobalMintBurnDisableFlag)
+}
+
+// IsDCTTransferRoleFlagEnabled returns true if DCTTransferRoleFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsDCTTransferRoleFlagEnabled() bool {
+	return adapter.registry.IsFlagEnabled(DCTTransferRoleFlag)
+}
+
+// IsBuiltInFunctionsFlagEnabled returns true if BuiltInFunctionsFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsBuiltInFunctionsFlagEnabled() bool {
+	return adapter.registry.IsFlagEnabled(BuiltInFunctionsFlag)
+}
+
+// IsCheckCorrectTokenIDForTransferRoleFlagEnabled returns true if CheckCorrectTokenIDForTransferRoleFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsCheckCorrectTokenIDForTransferRoleFlagEnabled() bool {
+	return adapter.registry.IsFlagEnabled(CheckCorrectTokenIDForTransferRoleFlag)
+}
+
+// IsMultiDCTTransferFixOnCallBackFlagEnabled returns true if MultiDCTTransferFixOnCallBackFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsMultiDCTTransferFixOnCallBackFlagEnabled() bool {
+	return adapter.registry.IsFlagEnabled(MultiDCTTransferFixOnCallBackFlag)
+}
+
+// IsFixOOGReturnCodeFlagEnabled returns true if FixOOGReturnCodeFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsFixOOGReturnCodeFlagEnabled() bool {
+	return adapter.registry.IsFlagEnabled(FixOOGReturnCodeFlag)
+}
+
+// IsRemoveNonUpdatedStorageFlagEnabled returns true if RemoveNonUpdatedStorageFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsRemoveNonUpdatedStorageFlagEnabled() bool {
+	return adapter.registry.IsFlagEnabled(RemoveNonUpdatedStorageFlag)
+}
+
+// IsCreateNFTThroughExecByCallerFlagEnabled returns true if CreateNFTThroughExecByCallerFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsCreateNFTThroughExecByCallerFlagEnabled() bool {
+	return adapter.registry.IsFlagEnabled(CreateNFTThroughExecByCallerFlag)
+}
+
+// IsStorageAPICostOptimizationFlagEnabled returns true if StorageAPICostOptimizationFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsStorageAPICostOptimizationFlagEnabled() bool {
+	return adapter.registry.IsFlagEnabled(StorageAPICostOptimizationFlag)
+}
+
+// IsFailExecutionOnEveryAPIErrorFlagEnabled returns true if FailExecutionOnEveryAPIErrorFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsFailExecutionOnEveryAPIErrorFlagEnabled() bool {
+	return adapter.registry.IsFlagEnabled(FailExecutionOnEveryAPIErrorFlag)
+}
+
+// IsManagedCryptoAPIsFlagEnabled returns true if ManagedCryptoAPIsFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsManagedCryptoAPIsFlagEnabled() bool {
+	return adapter.registry.IsFlagEnabled(ManagedCryptoAPIsFlag)
+}
+
+// IsSCDeployFlagEnabled returns true if SCDeployFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsSCDeployFlagEnabled() bool {
+	return adapter.registry.IsFlagEnabled(SCDeployFlag)
+}
+
+// IsAheadOfTimeGasUsageFlagEnabled returns true if AheadOfTimeGasUsageFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsAheadOfTimeGasUsageFlagEnabled() bool {
+	return adapter.registry.IsFlagEnabled(AheadOfTimeGasUsageFlag)
+}
+
+// IsRepairCallbackFlagEnabled returns true if RepairCallbackFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsRepairCallbackFlagEnabled() bool {
+	return adapter.registry.IsFlagEnabled(RepairCallbackFlag)
+}
+
+// IsDisableExecByCallerFlagEnabled returns true if DisableExecByCallerFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsDisableExecByCallerFlagEnabled() bool {
+	return adapter.registry.IsFlagEnabled(DisableExecByCallerFlag)
+}
+
+// IsRefactorContextFlagEnabled returns true if RefactorContextFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsRefactorContextFlagEnabled() bool {
+	return adapter.registry.IsFlagEnabled(RefactorContextFlag)
+}
+
+// IsCheckFunctionArgumentFlagEnabled returns true if CheckFunctionArgumentFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsCheckFunctionArgumentFlagEnabled() bool {
+	return adapter.registry.IsFlagEnabled(CheckFunctionArgumentFlag)
+}
+
+// IsCheckExecuteOnReadOnlyFlagEnabled returns true if CheckExecuteOnReadOnlyFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsCheckExecuteOnReadOnlyFlagEnabled() bool {
+	return adapter.registry.IsFlagEnabled(CheckExecuteOnReadOnlyFlag)
+}
+
+// IsFixAsyncCallbackCheckFlagEnabled returns true if FixAsyncCallbackCheckFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsFixAsyncCallbackCheckFlagEnabled() bool {
+	return adapter.registry.IsFlagEnabled(FixAsyncCallbackCheckFlag)
+}
+
+// IsSaveToSystemAccountFlagEnabled returns true if SaveToSystemAccountFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsSaveToSystemAccountFlagEnabled() bool {
+	return adapter.registry.IsFlagEnabled(SaveToSystemAccountFlag)
+}
+
+// IsCheckFrozenCollectionFlagEnabled returns true if CheckFrozenCollectionFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsCheckFrozenCollectionFlagEnabled() bool {
+	return adapter.registry.IsFlagEnabled(CheckFrozenCollectionFlag)
+}
+
+// IsSendAlwaysFlagEnabled returns true if SendAlwaysFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsSendAlwaysFlagEnabled() bool {
+	return adapter.registry.IsFlagEnabled(SendAlwaysFlag)
+}
+
+// IsValueLengthCheckFlagEnabled returns true if ValueLengthCheckFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsValueLengthCheckFlagEnabled() bool {
+	return adapter.registry.IsFlagEnabled(ValueLengthCheckFlag)
+}
+
+// IsCheckTransferFlagEnabled returns true if CheckTransferFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsCheckTransferFlagEnabled() bool {
+	return adapter.registry.IsFlagEnabled(CheckTransferFlag)
+}
+
+// IsTransferToMetaFlagEnabled returns true if TransferToMetaFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsTransferToMetaFlagEnabled() bool {
+	return adapter.registry.IsFlagEnabled(TransferToMetaFlag)
+}
+
+// IsDCTNFTImprovementV1FlagEnabled returns true if DCTNFTImprovementV1Flag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsDCTNFTImprovementV1FlagEnabled() bool {
+	return adapter.registry.IsFlagEnabled(DCTNFTImprovementV1Flag)
+}
+
+// IsFixOldTokenLiquidityEnabled returns true if FixOldTokenLiquidityFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsFixOldTokenLiquidityEnabled() bool {
+	return adapter.registry.IsFlagEnabled(FixOldTokenLiquidityFlag)
+}
+
+// IsRuntimeMemStoreLimitEnabled returns true if RuntimeMemStoreLimitFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsRuntimeMemStoreLimitEnabled() bool {
+	return adapter.registry.IsFlagEnabled(RuntimeMemStoreLimitFlag)
+}
+
+// IsMaxBlockchainHookCountersFlagEnabled returns true if MaxBlockchainHookCountersFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsMaxBlockchainHookCountersFlagEnabled() bool {
+	return adapter.registry.IsFlagEnabled(MaxBlockchainHookCountersFlag)
+}
+
+// IsWipeSingleNFTLiquidityDecreaseEnabled returns true if WipeSingleNFTLiquidityDecreaseFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsWipeSingleNFTLiquidityDecreaseEnabled() bool {
+	return adapter.registry.IsFlagEnabled(WipeSingleNFTLiquidityDecreaseFlag)
+}
+
+// IsAlwaysSaveTokenMetaDataEnabled returns true if AlwaysSaveTokenMetaDataFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsAlwaysSaveTokenMetaDataEnabled() bool {
+	return adapter.registry.IsFlagEnabled(AlwaysSaveTokenMetaDataFlag)
+}
+
+// IsRuntimeCodeSizeFixEnabled returns true if RuntimeCodeSizeFixFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsRuntimeCodeSizeFixEnabled() bool {
+	return adapter.registry.IsFlagEnabled(RuntimeCodeSizeFixFlag)
+}
+
+// IsChangeUsernameEnabled returns true if ChangeUsernameFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsChangeUsernameEnabled() bool {
+	return adapter.registry.IsFlagEnabled(ChangeUsernameFlag)
+}
+
+// IsDynamicGasCostForDataTrieStorageLoadEnabled returns true if DynamicGasCostForDataTrieStorageLoadFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsDynamicGasCostForDataTrieStorageLoadEnabled() bool {
+	return adapter.registry.IsFlagEnabled(DynamicGasCostForDataTrieStorageLoadFlag)
+}
+
+// IsSetGuardianEnabled returns true if SetGuardianFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsSetGuardianEnabled() bool {
+	return adapter.registry.IsFlagEnabled(SetGuardianFlag)
+}
+
+// IsScToScEventLogEnabled returns true if ScToScEventLogFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsScToScEventLogEnabled() bool {
+	return adapter.registry.IsFlagEnabled(ScToScEventLogFlag)
+}
+
+// IsConsistentTokensValuesLengthCheckEnabled returns true if ConsistentTokensValuesLengthCheckFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsConsistentTokensValuesLengthCheckEnabled() bool {
+	return adapter.registry.IsFlagEnabled(ConsistentTokensValuesLengthCheckFlag)
+}
+
+// IsAutoBalanceDataTriesEnabled returns true if AutoBalanceDataTriesFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsAutoBalanceDataTriesEnabled() bool {
+	return adapter.registry.IsFlagEnabled(AutoBalanceDataTriesFlag)
+}
+
+// IsMigrateDataTrieEnabled returns true if MigrateDataTrieFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsMigrateDataTrieEnabled() bool {
+	return adapter.registry.IsFlagEnabled(MigrateDataTrieFlag)
+}
+
+// IsChangeOwnerAddressCrossShardThroughSCEnabled returns true if ChangeOwnerAddressCrossShardThroughSCFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) IsChangeOwnerAddressCrossShardThroughSCEnabled() bool {
+	return adapter.registry.IsFlagEnabled(ChangeOwnerAddressCrossShardThroughSCFlag)
+}
+
+// FixGasRemainingForSaveKeyValueBuiltinFunctionEnabled returns true if FixGasRemainingForSaveKeyValueBuiltinFunctionFlag is enabled
+func (adapter *enableEpochsHandlerAdapter) FixGasRemainingForSaveKeyValueBuiltinFunctionEnabled() bool {
+	return adapter.registry.IsFlagEnabled(FixGasRemainingForSaveKeyValueBuiltinFunctionFlag)
+}
+
+// MultiDCTTransferAsyncCallBackEnableEpoch returns the activation epoch of MultiDCTTransferFixOnCallBackFlag
+func (adapter *enableEpochsHandlerAdapter) MultiDCTTransferAsyncCallBackEnableEpoch() uint32 {
+	return adapter.registry.GetActivationEpoch(MultiDCTTransferFixOnCallBackFlag)
+}
+
+// FixOOGReturnCodeEnableEpoch returns the activation epoch of FixOOGReturnCodeFlag
+func (adapter *enableEpochsHandlerAdapter) FixOOGReturnCodeEnableEpoch() uint32 {
+	return adapter.registry.GetActivationEpoch(FixOOGReturnCodeFlag)
+}
+
+// RemoveNonUpdatedStorageEnableEpoch returns the activation epoch of RemoveNonUpdatedStorageFlag
+func (adapter *enableEpochsHandlerAdapter) RemoveNonUpdatedStorageEnableEpoch() uint32 {
+	return adapter.registry.GetActivationEpoch(RemoveNonUpdatedStorageFlag)
+}
+
+// CreateNFTThroughExecByCallerEnableEpoch returns the activation epoch of CreateNFTThroughExecByCallerFlag
+func (adapter *enableEpochsHandlerAdapter) CreateNFTThroughExecByCallerEnableEpoch() uint32 {
+	return adapter.registry.GetActivationEpoch(CreateNFTThroughExecByCallerFlag)
+}
+
+// FixFailExecutionOnErrorEnableEpoch returns the activation epoch of FailExecutionOnEveryAPIErrorFlag
+func (adapter *enableEpochsHandlerAdapter) FixFailExecutionOnErrorEnableEpoch() uint32 {
+	return adapter.registry.GetActivationEpoch(FailExecutionOnEveryAPIErrorFlag)
+}
+
+// ManagedCryptoAPIEnableEpoch returns the activation epoch of ManagedCryptoAPIsFlag
+func (adapter *enableEpochsHandlerAdapter) ManagedCryptoAPIEnableEpoch() uint32 {
+	return adapter.registry.GetActivationEpoch(ManagedCryptoAPIsFlag)
+}
+
+// DisableExecByCallerEnableEpoch returns the activation epoch of DisableExecByCallerFlag
+func (adapter *enableEpochsHandlerAdapter) DisableExecByCallerEnableEpoch() uint32 {
+	return adapter.registry.GetActivationEpoch(DisableExecByCallerFlag)
+}
+
+// RefactorContextEnableEpoch returns the activation epoch of RefactorContextFlag
+func (adapter *enableEpochsHandlerAdapter) RefactorContextEnableEpoch() uint32 {
+	return adapter.registry.GetActivationEpoch(RefactorContextFlag)
+}
+
+// CheckExecuteReadOnlyEnableEpoch returns the activation epoch of CheckExecuteOnReadOnlyFlag
+func (adapter *enableEpochsHandlerAdapter) CheckExecuteReadOnlyEnableEpoch() uint32 {
+	return adapter.registry.GetActivationEpoch(CheckExecuteOnReadOnlyFlag)
+}
+
+// StorageAPICostOptimizationEnableEpoch returns the activation epoch of StorageAPICostOptimizationFlag
+func (adapter *enableEpochsHandlerAdapter) StorageAPICostOptimizationEnableEpoch() uint32 {
+	return adapter.registry.GetActivationEpoch(StorageAPICostOptimizationFlag)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (adapter *enableEpochsHandlerAdapter) IsInterfaceNil() bool {
+	return adapter == nil
+}
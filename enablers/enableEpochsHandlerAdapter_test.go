@@ -0,0 +1,57 @@
+package enablers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+	"github.com/subrahamanyam341/andes-vm-common-123/mock"
+)
+
+func TestNewEnableEpochsHandlerAdapter(t *testing.T) {
+	t.Parallel()
+
+	adapter, err := NewEnableEpochsHandlerAdapter(nil)
+	assert.True(t, check.IfNil(adapter))
+	assert.Equal(t, ErrNilFlagRegistry, err)
+
+	registry, _ := NewFlagRegistry(createMockArgsNewFlagRegistry())
+	adapter, err = NewEnableEpochsHandlerAdapter(registry)
+	require.Nil(t, err)
+	assert.False(t, check.IfNil(adapter))
+}
+
+func TestEnableEpochsHandlerAdapter_MatchesEnableEpochsHandler(t *testing.T) {
+	t.Parallel()
+
+	enableEpochs := EnableEpochs{}
+	configValue := reflect.ValueOf(&enableEpochs).Elem()
+	for i := 0; i < configValue.NumField(); i++ {
+		configValue.Field(i).SetUint(uint64(i))
+	}
+
+	handler, _ := NewEnableEpochsHandler(ArgsNewEnableEpochsHandler{
+		EnableEpochs:  enableEpochs,
+		EpochNotifier: &mock.EpochNotifierStub{},
+	})
+	registry, _ := NewFlagRegistry(createMockArgsNewFlagRegistry())
+	err := registry.RegisterEnableEpochs(enableEpochs)
+	require.Nil(t, err)
+	adapter, _ := NewEnableEpochsHandlerAdapter(registry)
+
+	interfaceType := reflect.TypeOf((*vmcommon.EnableEpochsHandler)(nil)).Elem()
+	for epoch := uint32(0); epoch <= uint32(configValue.NumField()); epoch++ {
+		handler.EpochConfirmed(epoch, 0)
+		registry.EpochConfirmed(epoch, 0)
+
+		for i := 0; i < interfaceType.NumMethod(); i++ {
+			methodName := interfaceType.Method(i).Name
+			expected := reflect.ValueOf(handler).MethodByName(methodName).Call(nil)[0].Interface()
+			actual := reflect.ValueOf(adapter).MethodByName(methodName).Call(nil)[0].Interface()
+			assert.Equal(t, expected, actual, "%s in epoch %d", methodName, epoch)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package enablers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+	"github.com/subrahamanyam341/andes-vm-common-123/mock"
+)
+
+const activationEpoch = 5
+
+func createArgsWithOneActivationEpoch(fieldName string) ArgsNewEnableEpochsHandler {
+	enableEpochs := EnableEpochs{}
+	reflect.ValueOf(&enableEpochs).Elem().FieldByName(fieldName).SetUint(activationEpoch)
+
+	return ArgsNewEnableEpochsHandler{
+		EnableEpochs:  enableEpochs,
+		EpochNotifier: &mock.EpochNotifierStub{},
+	}
+}
+
+func callBoolMethods(handler vmcommon.EnableEpochsHandler) map[string]bool {
+	results := make(map[string]bool)
+	handlerValue := reflect.ValueOf(handler)
+	interfaceType := reflect.TypeOf((*vmcommon.EnableEpochsHandler)(nil)).Elem()
+	for i := 0; i < interfaceType.NumMethod(); i++ {
+		method := interfaceType.Method(i)
+		if method.Name == "IsInterfaceNil" || method.Type.Out(0).Kind() != reflect.Bool {
+			continue
+		}
+		results[method.Name] = handlerValue.MethodByName(method.Name).Call(nil)[0].Bool()
+	}
+
+	return results
+}
+
+func TestNewEnableEpochsHandler(t *testing.T) {
+	t.Parallel()
+
+	handler, err := NewEnableEpochsHandler(ArgsNewEnableEpochsHandler{})
+	assert.True(t, check.IfNil(handler))
+	assert.Equal(t, ErrNilEpochNotifier, err)
+
+	var registered vmcommon.EpochSubscriberHandler
+	handler, err = NewEnableEpochsHandler(ArgsNewEnableEpochsHandler{
+		EnableEpochs: EnableEpochs{SetGuardianEnableEpoch: 1, GlobalMintBurnDisableEpoch: 1},
+		EpochNotifier: &mock.EpochNotifierStub{
+			RegisterNotifyHandlerCalled: func(h vmcommon.EpochSubscriberHandler) {
+				registered = h
+			},
+		},
+	})
+	require.Nil(t, err)
+	assert.False(t, check.IfNil(handler))
+	assert.Equal(t, handler, registered)
+
+	assert.True(t, handler.IsBuiltInFunctionsFlagEnabled())
+	assert.True(t, handler.IsGlobalMintBurnFlagEnabled())
+	assert.False(t, handler.IsSetGuardianEnabled())
+
+	registered.EpochConfirmed(1, 0)
+	assert.False(t, handler.IsGlobalMintBurnFlagEnabled())
+	assert.True(t, handler.IsSetGuardianEnabled())
+}
+
+func TestEnableEpochsHandler_EveryMethodIsBackedByAConfigField(t *testing.T) {
+	t.Parallel()
+
+	flagsPerField := make(map[string]string)
+	gettersPerField := make(map[string]string)
+	configType := reflect.TypeOf(EnableEpochs{})
+	for i := 0; i < configType.NumField(); i++ {
+		fieldName := configType.Field(i).Name
+		handler, err := NewEnableEpochsHandler(createArgsWithOneActivationEpoch(fieldName))
+		require.Nil(t, err)
+
+		handler.EpochConfirmed(activationEpoch-1, 0)
+		before := callBoolMethods(handler)
+		handler.EpochConfirmed(activationEpoch, 0)
+		after := callBoolMethods(handler)
+
+		toggled := make([]string, 0)
+		for name, value := range after {
+			if before[name] != value {
+				toggled = append(toggled, name)
+			}
+		}
+		require.Len(t, toggled, 1, "config field %s should toggle exactly one flag, toggled %v", fieldName, toggled)
+		_, alreadyBacked := flagsPerField[toggled[0]]
+		assert.False(t, alreadyBacked, "flag %s is backed by more config fields", toggled[0])
+		flagsPerField[toggled[0]] = fieldName
+
+		handlerValue := reflect.ValueOf(handler)
+		interfaceType := reflect.TypeOf((*vmcommon.EnableEpochsHandler)(nil)).Elem()
+		for j := 0; j < interfaceType.NumMethod(); j++ {
+			method := interfaceType.Method(j)
+			if method.Type.NumOut() != 1 || method.Type.Out(0).Kind() != reflect.Uint32 {
+				continue
+			}
+			if handlerValue.MethodByName(method.Name).Call(nil)[0].Uint() == activationEpoch {
+				gettersPerField[method.Name] = fieldName
+			}
+		}
+	}
+
+	interfaceType := reflect.TypeOf((*vmcommon.EnableEpochsHandler)(nil)).Elem()
+	for i := 0; i < interfaceType.NumMethod(); i++ {
+		method := interfaceType.Method(i)
+		if method.Name == "IsInterfaceNil" {
+			continue
+		}
+
+		switch method.Type.Out(0).Kind() {
+		case reflect.Bool:
+			assert.Contains(t, flagsPerField, method.Name, "flag %s is not backed by a config field", method.Name)
+		case reflect.Uint32:
+			assert.Contains(t, gettersPerField, method.Name, "getter %s is not backed by a config field", method.Name)
+		default:
+			assert.Fail(t, "unexpected method "+method.Name)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package enablers
+
+import "errors"
+
+// ErrNilEpochNotifier signals that a nil epoch notifier was provided
+var ErrNilEpochNotifier = errors.New("nil epoch notifier")
+
+// ErrNilRoundNotifier signals that a nil round notifier was provided
+var ErrNilRoundNotifier = errors.New("nil round notifier")
+
+// ErrNilFlagRegistry signals that a nil flag registry was provided
+var ErrNilFlagRegistry = errors.New("nil flag registry")
+
+// ErrEmptyFlagName signals that a flag without a name was provided
+var ErrEmptyFlagName = errors.New("empty flag name")
+
+// ErrFlagAlreadyRegistered signals that the flag name is already registered
+var ErrFlagAlreadyRegistered = errors.New("flag already registered")
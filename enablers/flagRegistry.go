@@ -0,0 +1,140 @@
+package enablers
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+)
+
+var _ vmcommon.FlagRegistryHandler = (*flagRegistry)(nil)
+
+type flagActivation struct {
+	byRound bool
+	epoch   uint32
+	round   uint64
+}
+
+// ArgsNewFlagRegistry is the argument structure used to create a new flag registry
+type ArgsNewFlagRegistry struct {
+	EpochNotifier vmcommon.EpochNotifier
+	RoundNotifier vmcommon.RoundNotifier
+}
+
+type flagRegistry struct {
+	mut          sync.RWMutex
+	flags        map[vmcommon.Flag]flagActivation
+	currentEpoch uint32
+	currentRound uint64
+}
+
+// NewFlagRegistry creates a registry of named flags, activated either by epoch or by round. The registry follows
+// the epochs and the rounds confirmed by the notifiers.
+func NewFlagRegistry(args ArgsNewFlagRegistry) (*flagRegistry, error) {
+	if check.IfNil(args.EpochNotifier) {
+		return nil, ErrNilEpochNotifier
+	}
+	if check.IfNil(args.RoundNotifier) {
+		return nil, ErrNilRoundNotifier
+	}
+
+	registry := &flagRegistry{
+		flags: make(map[vmcommon.Flag]flagActivation),
+	}
+	args.EpochNotifier.RegisterNotifyHandler(registry)
+	args.RoundNotifier.RegisterNotifyHandler(registry)
+
+	return registry, nil
+}
+
+// RegisterEpochFlag registers a flag that is enabled starting with the activation epoch
+func (registry *flagRegistry) RegisterEpochFlag(flag vmcommon.Flag, activationEpoch uint32) error {
+	return registry.register(flag, flagActivation{epoch: activationEpoch})
+}
+
+// RegisterRoundFlag registers a flag that is enabled starting with the activation round
+func (registry *flagRegistry) RegisterRoundFlag(flag vmcommon.Flag, activationRound uint64) error {
+	return registry.register(flag, flagActivation{byRound: true, round: activationRound})
+}
+
+func (registry *flagRegistry) register(flag vmcommon.Flag, activation flagActivation) error {
+	if len(flag) == 0 {
+		return ErrEmptyFlagName
+	}
+
+	registry.mut.Lock()
+	defer registry.mut.Unlock()
+
+	if _, found := registry.flags[flag]; found {
+		return fmt.Errorf("%w: %s", ErrFlagAlreadyRegistered, flag)
+	}
+	registry.flags[flag] = activation
+
+	return nil
+}
+
+// IsFlagEnabled returns true if the activation epoch or round of the flag was reached. The flags that are not
+// registered are never enabled.
+func (registry *flagRegistry) IsFlagEnabled(flag vmcommon.Flag) bool {
+	registry.mut.RLock()
+	defer registry.mut.RUnlock()
+
+	activation, found := registry.flags[flag]
+	if !found {
+		return false
+	}
+	if activation.byRound {
+		return registry.currentRound >= activation.round
+	}
+
+	return registry.currentEpoch >= activation.epoch
+}
+
+// GetActivationEpoch returns the activation epoch of a flag activated by epoch. It returns math.MaxUint32 for the
+// flags activated by round and for the flags that are not registered.
+func (registry *flagRegistry) GetActivationEpoch(flag vmcommon.Flag) uint32 {
+	registry.mut.RLock()
+	defer registry.mut.RUnlock()
+
+	activation, found := registry.flags[flag]
+	if !found || activation.byRound {
+		return math.MaxUint32
+	}
+
+	return activation.epoch
+}
+
+// GetActivationRound returns the activation round of a flag activated by round. It returns math.MaxUint64 for the
+// flags activated by epoch and for the flags that are not registered.
+func (registry *flagRegistry) GetActivationRound(flag vmcommon.Flag) uint64 {
+	registry.mut.RLock()
+	defer registry.mut.RUnlock()
+
+	activation, found := registry.flags[flag]
+	if !found || !activation.byRound {
+		return math.MaxUint64
+	}
+
+	return activation.round
+}
+
+// EpochConfirmed is called whenever a new epoch is confirmed
+func (registry *flagRegistry) EpochConfirmed(epoch uint32, _ uint64) {
+	registry.mut.Lock()
+	registry.currentEpoch = epoch
+	registry.mut.Unlock()
+}
+
+// RoundConfirmed is called whenever a new round is confirmed
+func (registry *flagRegistry) RoundConfirmed(round uint64, _ uint64) {
+	registry.mut.Lock()
+	registry.currentRound = round
+	registry.mut.Unlock()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (registry *flagRegistry) IsInterfaceNil() bool {
+	return registry == nil
+}
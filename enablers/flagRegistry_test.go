@@ -0,0 +1,107 @@
+package enablers
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+	"github.com/subrahamanyam341/andes-vm-common-123/mock"
+)
+
+func createMockArgsNewFlagRegistry() ArgsNewFlagRegistry {
+	return ArgsNewFlagRegistry{
+		EpochNotifier: &mock.EpochNotifierStub{},
+		RoundNotifier: &mock.RoundNotifierStub{},
+	}
+}
+
+func TestNewFlagRegistry(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsNewFlagRegistry()
+	args.EpochNotifier = nil
+	registry, err := NewFlagRegistry(args)
+	assert.True(t, check.IfNil(registry))
+	assert.Equal(t, ErrNilEpochNotifier, err)
+
+	args = createMockArgsNewFlagRegistry()
+	args.RoundNotifier = nil
+	registry, err = NewFlagRegistry(args)
+	assert.True(t, check.IfNil(registry))
+	assert.Equal(t, ErrNilRoundNotifier, err)
+
+	var epochSubscriber vmcommon.EpochSubscriberHandler
+	var roundSubscriber vmcommon.RoundSubscriberHandler
+	args = ArgsNewFlagRegistry{
+		EpochNotifier: &mock.EpochNotifierStub{
+			RegisterNotifyHandlerCalled: func(handler vmcommon.EpochSubscriberHandler) {
+				epochSubscriber = handler
+			},
+		},
+		RoundNotifier: &mock.RoundNotifierStub{
+			RegisterNotifyHandlerCalled: func(handler vmcommon.RoundSubscriberHandler) {
+				roundSubscriber = handler
+			},
+		},
+	}
+	registry, err = NewFlagRegistry(args)
+	require.Nil(t, err)
+	assert.False(t, check.IfNil(registry))
+	assert.Equal(t, registry, epochSubscriber)
+	assert.Equal(t, registry, roundSubscriber)
+}
+
+func TestFlagRegistry_Register(t *testing.T) {
+	t.Parallel()
+
+	registry, _ := NewFlagRegistry(createMockArgsNewFlagRegistry())
+	assert.Equal(t, ErrEmptyFlagName, registry.RegisterEpochFlag("", 1))
+	assert.Nil(t, registry.RegisterEpochFlag("EpochFlag", 1))
+	assert.True(t, errors.Is(registry.RegisterRoundFlag("EpochFlag", 1), ErrFlagAlreadyRegistered))
+
+	assert.Nil(t, registry.RegisterEnableEpochs(EnableEpochs{}))
+	err := registry.RegisterEnableEpochs(EnableEpochs{})
+	assert.True(t, errors.Is(err, ErrFlagAlreadyRegistered))
+}
+
+func TestFlagRegistry_IsFlagEnabled(t *testing.T) {
+	t.Parallel()
+
+	registry, _ := NewFlagRegistry(createMockArgsNewFlagRegistry())
+	_ = registry.RegisterEpochFlag("EpochFlag", 2)
+	_ = registry.RegisterRoundFlag("RoundFlag", 100)
+
+	assert.False(t, registry.IsFlagEnabled("EpochFlag"))
+	assert.False(t, registry.IsFlagEnabled("RoundFlag"))
+	assert.False(t, registry.IsFlagEnabled("UnknownFlag"))
+
+	registry.EpochConfirmed(2, 0)
+	assert.True(t, registry.IsFlagEnabled("EpochFlag"))
+	assert.False(t, registry.IsFlagEnabled("RoundFlag"))
+
+	registry.RoundConfirmed(99, 0)
+	assert.False(t, registry.IsFlagEnabled("RoundFlag"))
+	registry.RoundConfirmed(100, 0)
+	assert.True(t, registry.IsFlagEnabled("RoundFlag"))
+	assert.False(t, registry.IsFlagEnabled("UnknownFlag"))
+}
+
+func TestFlagRegistry_GetActivation(t *testing.T) {
+	t.Parallel()
+
+	registry, _ := NewFlagRegistry(createMockArgsNewFlagRegistry())
+	_ = registry.RegisterEpochFlag("EpochFlag", 2)
+	_ = registry.RegisterRoundFlag("RoundFlag", 100)
+
+	assert.Equal(t, uint32(2), registry.GetActivationEpoch("EpochFlag"))
+	assert.Equal(t, uint32(math.MaxUint32), registry.GetActivationEpoch("RoundFlag"))
+	assert.Equal(t, uint32(math.MaxUint32), registry.GetActivationEpoch("UnknownFlag"))
+
+	assert.Equal(t, uint64(100), registry.GetActivationRound("RoundFlag"))
+	assert.Equal(t, uint64(math.MaxUint64), registry.GetActivationRound("EpochFlag"))
+	assert.Equal(t, uint64(math.MaxUint64), registry.GetActivationRound("UnknownFlag"))
+}
@@ -0,0 +1,104 @@
+package enablers
+
+import vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+
+// the flags backing the vmcommon.EnableEpochsHandler methods
+const (
+	GlobalMintBurnDisableFlag                         vmcommon.Flag = "GlobalMintBurnDisableFlag"
+	DCTTransferRoleFlag                               vmcommon.Flag = "DCTTransferRoleFlag"
+	BuiltInFunctionsFlag                              vmcommon.Flag = "BuiltInFunctionsFlag"
+	CheckCorrectTokenIDForTransferRoleFlag            vmcommon.Flag = "CheckCorrectTokenIDForTransferRoleFlag"
+	MultiDCTTransferFixOnCallBackFlag                 vmcommon.Flag = "MultiDCTTransferFixOnCallBackFlag"
+	FixOOGReturnCodeFlag                              vmcommon.Flag = "FixOOGReturnCodeFlag"
+	RemoveNonUpdatedStorageFlag                       vmcommon.Flag = "RemoveNonUpdatedStorageFlag"
+	CreateNFTThroughExecByCallerFlag                  vmcommon.Flag = "CreateNFTThroughExecByCallerFlag"
+	StorageAPICostOptimizationFlag                    vmcommon.Flag = "StorageAPICostOptimizationFlag"
+	FailExecutionOnEveryAPIErrorFlag                  vmcommon.Flag = "FailExecutionOnEveryAPIErrorFlag"
+	ManagedCryptoAPIsFlag                             vmcommon.Flag = "ManagedCryptoAPIsFlag"
+	SCDeployFlag                                      vmcommon.Flag = "SCDeployFlag"
+	AheadOfTimeGasUsageFlag                           vmcommon.Flag = "AheadOfTimeGasUsageFlag"
+	RepairCallbackFlag                                vmcommon.Flag = "RepairCallbackFlag"
+	DisableExecByCallerFlag                           vmcommon.Flag = "DisableExecByCallerFlag"
+	RefactorContextFlag                               vmcommon.Flag = "RefactorContextFlag"
+	CheckFunctionArgumentFlag                         vmcommon.Flag = "CheckFunctionArgumentFlag"
+	CheckExecuteOnReadOnlyFlag                        vmcommon.Flag = "CheckExecuteOnReadOnlyFlag"
+	FixAsyncCallbackCheckFlag                         vmcommon.Flag = "FixAsyncCallbackCheckFlag"
+	SaveToSystemAccountFlag                           vmcommon.Flag = "SaveToSystemAccountFlag"
+	CheckFrozenCollectionFlag                         vmcommon.Flag = "CheckFrozenCollectionFlag"
+	SendAlwaysFlag                                    vmcommon.Flag = "SendAlwaysFlag"
+	ValueLengthCheckFlag                              vmcommon.Flag = "ValueLengthCheckFlag"
+	CheckTransferFlag                                 vmcommon.Flag = "CheckTransferFlag"
+	TransferToMetaFlag                                vmcommon.Flag = "TransferToMetaFlag"
+	DCTNFTImprovementV1Flag                           vmcommon.Flag = "DCTNFTImprovementV1Flag"
+	FixOldTokenLiquidityFlag                          vmcommon.Flag = "FixOldTokenLiquidityFlag"
+	RuntimeMemStoreLimitFlag                          vmcommon.Flag = "RuntimeMemStoreLimitFlag"
+	MaxBlockchainHookCountersFlag                     vmcommon.Flag = "MaxBlockchainHookCountersFlag"
+	WipeSingleNFTLiquidityDecreaseFlag                vmcommon.Flag = "WipeSingleNFTLiquidityDecreaseFlag"
+	AlwaysSaveTokenMetaDataFlag                       vmcommon.Flag = "AlwaysSaveTokenMetaDataFlag"
+	RuntimeCodeSizeFixFlag                            vmcommon.Flag = "RuntimeCodeSizeFixFlag"
+	ChangeUsernameFlag                                vmcommon.Flag = "ChangeUsernameFlag"
+	DynamicGasCostForDataTrieStorageLoadFlag          vmcommon.Flag = "DynamicGasCostForDataTrieStorageLoadFlag"
+	SetGuardianFlag                                   vmcommon.Flag = "SetGuardianFlag"
+	ScToScEventLogFlag                                vmcommon.Flag = "ScToScEventLogFlag"
+	ConsistentTokensValuesLengthCheckFlag             vmcommon.Flag = "ConsistentTokensValuesLengthCheckFlag"
+	AutoBalanceDataTriesFlag                          vmcommon.Flag = "AutoBalanceDataTriesFlag"
+	MigrateDataTrieFlag                               vmcommon.Flag = "MigrateDataTrieFlag"
+	ChangeOwnerAddressCrossShardThroughSCFlag         vmcommon.Flag = "ChangeOwnerAddressCrossShardThroughSCFlag"
+	FixGasRemainingForSaveKeyValueBuiltinFunctionFlag vmcommon.Flag = "FixGasRemainingForSaveKeyValueBuiltinFunctionFlag"
+)
+
+// RegisterEnableEpochs registers, by epoch, the flags backing the vmcommon.EnableEpochsHandler methods
+func (registry *flagRegistry) RegisterEnableEpochs(enableEpochs EnableEpochs) error {
+	activationEpochs := map[vmcommon.Flag]uint32{
+		GlobalMintBurnDisableFlag:                         enableEpochs.GlobalMintBurnDisableEpoch,
+		DCTTransferRoleFlag:                               enableEpochs.DCTTransferRoleEnableEpoch,
+		BuiltInFunctionsFlag:                              enableEpochs.BuiltInFunctionsEnableEpoch,
+		CheckCorrectTokenIDForTransferRoleFlag:            enableEpochs.CheckCorrectTokenIDForTransferRoleEnableEpoch,
+		MultiDCTTransferFixOnCallBackFlag:                 enableEpochs.MultiDCTTransferFixOnCallBackOnEnableEpoch,
+		FixOOGReturnCodeFlag:                              enableEpochs.FixOOGReturnCodeEnableEpoch,
+		RemoveNonUpdatedStorageFlag:                       enableEpochs.RemoveNonUpdatedStorageEnableEpoch,
+		CreateNFTThroughExecByCallerFlag:                  enableEpochs.CreateNFTThroughExecByCallerEnableEpoch,
+		StorageAPICostOptimizationFlag:                    enableEpochs.StorageAPICostOptimizationEnableEpoch,
+		FailExecutionOnEveryAPIErrorFlag:                  enableEpochs.FailExecutionOnEveryAPIErrorEnableEpoch,
+		ManagedCryptoAPIsFlag:                             enableEpochs.ManagedCryptoAPIsEnableEpoch,
+		SCDeployFlag:                                      enableEpochs.SCDeployEnableEpoch,
+		AheadOfTimeGasUsageFlag:                           enableEpochs.AheadOfTimeGasUsageEnableEpoch,
+		RepairCallbackFlag:                                enableEpochs.RepairCallbackEnableEpoch,
+		DisableExecByCallerFlag:                           enableEpochs.DisableExecByCallerEnableEpoch,
+		RefactorContextFlag:                               enableEpochs.RefactorContextEnableEpoch,
+		CheckFunctionArgumentFlag:                         enableEpochs.CheckFunctionArgumentEnableEpoch,
+		CheckExecuteOnReadOnlyFlag:                        enableEpochs.CheckExecuteOnReadOnlyEnableEpoch,
+		FixAsyncCallbackCheckFlag:                         enableEpochs.FixAsyncCallbackCheckEnableEpoch,
+		SaveToSystemAccountFlag:                           enableEpochs.SaveToSystemAccountEnableEpoch,
+		CheckFrozenCollectionFlag:                         enableEpochs.CheckFrozenCollectionEnableEpoch,
+		SendAlwaysFlag:                                    enableEpochs.SendAlwaysEnableEpoch,
+		ValueLengthCheckFlag:                              enableEpochs.ValueLengthCheckEnableEpoch,
+		CheckTransferFlag:                                 enableEpochs.CheckTransferEnableEpoch,
+		TransferToMetaFlag:                                enableEpochs.TransferToMetaEnableEpoch,
+		DCTNFTImprovementV1Flag:                           enableEpochs.DCTNFTImprovementV1EnableEpoch,
+		FixOldTokenLiquidityFlag:                          enableEpochs.FixOldTokenLiquidityEnableEpoch,
+		RuntimeMemStoreLimitFlag:                          enableEpochs.RuntimeMemStoreLimitEnableEpoch,
+		MaxBlockchainHookCountersFlag:                     enableEpochs.MaxBlockchainHookCountersEnableEpoch,
+		WipeSingleNFTLiquidityDecreaseFlag:                enableEpochs.WipeSingleNFTLiquidityDecreaseEnableEpoch,
+		AlwaysSaveTokenMetaDataFlag:                       enableEpochs.AlwaysSaveTokenMetaDataEnableEpoch,
+		RuntimeCodeSizeFixFlag:                            enableEpochs.RuntimeCodeSizeFixEnableEpoch,
+		ChangeUsernameFlag:                                enableEpochs.ChangeUsernameEnableEpoch,
+		DynamicGasCostForDataTrieStorageLoadFlag:          enableEpochs.DynamicGasCostForDataTrieStorageLoadEnableEpoch,
+		SetGuardianFlag:                                   enableEpochs.SetGuardianEnableEpoch,
+		ScToScEventLogFlag:                                enableEpochs.ScToScLogEventEnableEpoch,
+		ConsistentTokensValuesLengthCheckFlag:             enableEpochs.ConsistentTokensValuesLengthCheckEnableEpoch,
+		AutoBalanceDataTriesFlag:                          enableEpochs.AutoBalanceDataTriesEnableEpoch,
+		MigrateDataTrieFlag:                               enableEpochs.MigrateDataTrieEnableEpoch,
+		ChangeOwnerAddressCrossShardThroughSCFlag:         enableEpochs.ChangeOwnerAddressCrossShardThroughSCEnableEpoch,
+		FixGasRemainingForSaveKeyValueBuiltinFunctionFlag: enableEpochs.FixGasRemainingForSaveKeyValueBuiltinFunctionEnableEpoch,
+	}
+
+	for flag, activationEpoch := range activationEpochs {
+		err := registry.RegisterEpochFlag(flag, activationEpoch)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -16,3 +16,36 @@ var ErrTransfersNotIndexed = errors.New("unindexed transfers found")
 
 // ErrNilTransferIndexer signals that the provided transfer indexer is nil
 var ErrNilTransferIndexer = errors.New("nil NextOutputTransferIndexProvider")
+
+// ErrNilHasher signals that a nil hasher was provided
+var ErrNilHasher = errors.New("nil hasher")
+
+// ErrInvalidVMOutputEncoding signals that the provided bytes are not a canonical VMOutput encoding
+var ErrInvalidVMOutputEncoding = errors.New("invalid VMOutput encoding")
+
+// ErrWrongTypeAssertion signals that a wrong type was provided
+var ErrWrongTypeAssertion = errors.New("wrong type assertion")
+
+// ErrNilVMInput signals that a nil VM input was provided
+var ErrNilVMInput = errors.New("nil VM input")
+
+// ErrNilVMOutput signals that a nil VM output was provided
+var ErrNilVMOutput = errors.New("nil VM output")
+
+// ErrInvalidVMJSONEncoding signals that the provided JSON is not a valid VM input or output encoding
+var ErrInvalidVMJSONEncoding = errors.New("invalid VM JSON encoding")
+
+// ErrInvalidAddressLength signals that an address with an invalid length was provided
+var ErrInvalidAddressLength = errors.New("invalid address length")
+
+// ErrInvalidVMTypeLength signals that a VM type with an invalid length was provided
+var ErrInvalidVMTypeLength = errors.New("invalid VM type length")
+
+// ErrSmartContractAddressMismatch signals that the address is not the one of the expected smart contract deploy
+var ErrSmartContractAddressMismatch = errors.New("smart contract address mismatch")
+
+// ErrInvalidTokenIdentifier signals that an invalid token identifier was provided
+var ErrInvalidTokenIdentifier = errors.New("invalid token identifier")
+
+// ErrInvalidCodeMetadata signals that invalid code metadata was provided
+var ErrInvalidCodeMetadata = errors.New("invalid code metadata")
@@ -0,0 +1,90 @@
+package gasSchedule
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// GasScheduleChange is a cost that differs between two gas schedules
+type GasScheduleChange struct {
+	Section string
+	Key     string
+	// OldValue and NewValue are nil when the cost is missing from the old or the new schedule
+	OldValue *uint64
+	NewValue *uint64
+}
+
+// String returns the change as "Section.Key: old -> new (+x.xx%)"
+func (change *GasScheduleChange) String() string {
+	name := change.Section + "." + change.Key
+	switch {
+	case change.OldValue == nil:
+		return fmt.Sprintf("%s: added %d", name, *change.NewValue)
+	case change.NewValue == nil:
+		return fmt.Sprintf("%s: removed %d", name, *change.OldValue)
+	case *change.OldValue == 0:
+		return fmt.Sprintf("%s: %d -> %d", name, *change.OldValue, *change.NewValue)
+	default:
+		percent := (float64(*change.NewValue) - float64(*change.OldValue)) * 100 / float64(*change.OldValue)
+		return fmt.Sprintf("%s: %d -> %d (%+.2f%%)", name, *change.OldValue, *change.NewValue, percent)
+	}
+}
+
+// GasScheduleDiff is the list of the changed costs, sorted by section and key
+type GasScheduleDiff []*GasScheduleChange
+
+// String returns the changes, one per line
+func (diff GasScheduleDiff) String() string {
+	lines := make([]string, 0, len(diff))
+	for _, change := range diff {
+		lines = append(lines, change.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// DiffGasSchedules returns the costs that were added, removed or changed in the new gas schedule
+func DiffGasSchedules(oldSchedule, newSchedule map[string]map[string]uint64) GasScheduleDiff {
+	diff := make(GasScheduleDiff, 0)
+	for _, section := range unionKeys(oldSchedule, newSchedule) {
+		oldSection := oldSchedule[section]
+		newSection := newSchedule[section]
+		for _, key := range unionKeys(oldSection, newSection) {
+			oldValue, inOld := oldSection[key]
+			newValue, inNew := newSection[key]
+			if inOld && inNew && oldValue == newValue {
+				continue
+			}
+
+			change := &GasScheduleChange{
+				Section: section,
+				Key:     key,
+			}
+			if inOld {
+				change.OldValue = &oldValue
+			}
+			if inNew {
+				change.NewValue = &newValue
+			}
+			diff = append(diff, change)
+		}
+	}
+
+	return diff
+}
+
+func unionKeys[T any](first, second map[string]T) []string {
+	keys := make([]string, 0, len(first)+len(second))
+	for key := range first {
+		keys = append(keys, key)
+	}
+	for key := range second {
+		if _, found := first[key]; !found {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,33 @@
+package gasSchedule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffGasSchedules(t *testing.T) {
+	t.Parallel()
+
+	oldSchedule := map[string]map[string]uint64{
+		"BuiltInCost":    {"DCTTransfer": 200, "DCTBurn": 100, "Removed": 5},
+		"WASMOpcodeCost": {"Unreachable": 5},
+		"OldSection":     {"Key": 1},
+	}
+	newSchedule := map[string]map[string]uint64{
+		"BuiltInCost":    {"DCTTransfer": 250, "DCTBurn": 100, "Added": 7},
+		"WASMOpcodeCost": {"Unreachable": 4},
+	}
+
+	assert.Empty(t, DiffGasSchedules(oldSchedule, oldSchedule))
+
+	expected := "BuiltInCost.Added: added 7\n" +
+		"BuiltInCost.DCTTransfer: 200 -> 250 (+25.00%)\n" +
+		"BuiltInCost.Removed: removed 5\n" +
+		"OldSection.Key: removed 1\n" +
+		"WASMOpcodeCost.Unreachable: 5 -> 4 (-20.00%)"
+	diff := DiffGasSchedules(oldSchedule, newSchedule)
+	assert.Equal(t, expected, diff.String())
+	assert.Equal(t, uint64(250), *diff[1].NewValue)
+	assert.Nil(t, diff[0].OldValue)
+}
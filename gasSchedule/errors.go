@@ -0,0 +1,39 @@
+package gasSchedule
+
+import "errors"
+
+// ErrMissingGasScheduleSection signals that a section needed by the built-in functions is missing from the gas schedule
+var ErrMissingGasScheduleSection = errors.New("missing gas schedule section")
+
+// ErrMissingGasScheduleKey signals that a cost is missing from the gas schedule
+var ErrMissingGasScheduleKey = errors.New("missing gas schedule key")
+
+// ErrUnknownGasScheduleKey signals that the gas schedule holds a cost that is not known
+var ErrUnknownGasScheduleKey = errors.New("unknown gas schedule key")
+
+// ErrZeroGasScheduleValue signals that the gas schedule holds a zero cost
+var ErrZeroGasScheduleValue = errors.New("zero gas schedule value")
+
+// ErrInvalidGasScheduleFile signals that the gas schedule file could not be read
+var ErrInvalidGasScheduleFile = errors.New("invalid gas schedule file")
+
+// ErrNoGasScheduleVersions signals that no gas schedule versions were provided
+var ErrNoGasScheduleVersions = errors.New("no gas schedule versions")
+
+// ErrMissingGenesisGasSchedule signals that no gas schedule version starts in epoch 0
+var ErrMissingGenesisGasSchedule = errors.New("missing gas schedule for epoch 0")
+
+// ErrDuplicatedGasScheduleEpoch signals that more gas schedule versions start in the same epoch
+var ErrDuplicatedGasScheduleEpoch = errors.New("duplicated gas schedule start epoch")
+
+// ErrNilGasScheduleSubscriber signals that a nil gas schedule subscriber was provided
+var ErrNilGasScheduleSubscriber = errors.New("nil gas schedule subscriber")
+
+// ErrNilVMExecutionHandler signals that a nil VM execution handler was provided
+var ErrNilVMExecutionHandler = errors.New("nil VM execution handler")
+
+// ErrGasScheduleChangeFailed signals that a subscriber could not apply the new gas schedule
+var ErrGasScheduleChangeFailed = errors.New("gas schedule change failed")
+
+// ErrGasScheduleRollbackFailed signals that a subscriber could not restore the previous gas schedule
+var ErrGasScheduleRollbackFailed = errors.New("gas schedule rollback failed")
@@ -0,0 +1,121 @@
+package gasSchedule
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+)
+
+// ArgsNewGasScheduleNotifier is the argument structure used to create a new gas schedule notifier
+type ArgsNewGasScheduleNotifier struct {
+	GasSchedule map[string]map[string]uint64
+}
+
+type gasScheduleNotifier struct {
+	mut         sync.RWMutex
+	gasSchedule map[string]map[string]uint64
+	gasCost     *vmcommon.GasCost
+	subscribers []GasScheduleSubscriberHandler
+}
+
+// NewGasScheduleNotifier creates a notifier that holds the validated gas schedule and applies its changes to all
+// the registered subscribers. The gas schedules are validated with ValidateGasScheduleLenient, as the subscribers
+// always read them.
+func NewGasScheduleNotifier(args ArgsNewGasScheduleNotifier) (*gasScheduleNotifier, error) {
+	gasCost, err := ValidateGasScheduleLenient(args.GasSchedule)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gasScheduleNotifier{
+		gasSchedule: copyGasSchedule(args.GasSchedule),
+		gasCost:     gasCost,
+		subscribers: make([]GasScheduleSubscriberHandler, 0),
+	}, nil
+}
+
+// RegisterNotifyHandler applies the current gas schedule to the subscriber and registers it for the next changes.
+// The subscriber is not registered if it fails to apply the current gas schedule.
+func (gsn *gasScheduleNotifier) RegisterNotifyHandler(subscriber GasScheduleSubscriberHandler) error {
+	if check.IfNil(subscriber) {
+		return ErrNilGasScheduleSubscriber
+	}
+
+	gsn.mut.Lock()
+	defer gsn.mut.Unlock()
+
+	err := subscriber.GasScheduleChange(copyGasSchedule(gsn.gasSchedule))
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrGasScheduleChangeFailed, err.Error())
+	}
+	gsn.subscribers = append(gsn.subscribers, subscriber)
+
+	return nil
+}
+
+// ChangeGasSchedule validates the new gas schedule and applies it to all the subscribers, in the registration
+// order. If a subscriber fails, the subscribers that already applied the new gas schedule are rolled back to the
+// previous one and the notifier keeps the previous gas schedule.
+func (gsn *gasScheduleNotifier) ChangeGasSchedule(gasSchedule map[string]map[string]uint64) error {
+	gasCost, err := ValidateGasScheduleLenient(gasSchedule)
+	if err != nil {
+		return err
+	}
+
+	gsn.mut.Lock()
+	defer gsn.mut.Unlock()
+
+	newGasSchedule := copyGasSchedule(gasSchedule)
+	for index, subscriber := range gsn.subscribers {
+		err = subscriber.GasScheduleChange(copyGasSchedule(newGasSchedule))
+		if err != nil {
+			errRollback := gsn.rollback(index)
+			if errRollback != nil {
+				return fmt.Errorf("%w: subscriber %d: %s, %s", ErrGasScheduleChangeFailed, index, err.Error(), errRollback.Error())
+			}
+
+			return fmt.Errorf("%w: subscriber %d: %s", ErrGasScheduleChangeFailed, index, err.Error())
+		}
+	}
+
+	gsn.gasSchedule = newGasSchedule
+	gsn.gasCost = gasCost
+
+	return nil
+}
+
+// rollback restores the current gas schedule on the first numSubscribers subscribers, in reverse order
+func (gsn *gasScheduleNotifier) rollback(numSubscribers int) error {
+	var rollbackErr error
+	for index := numSubscribers - 1; index >= 0; index-- {
+		err := gsn.subscribers[index].GasScheduleChange(copyGasSchedule(gsn.gasSchedule))
+		if err != nil && rollbackErr == nil {
+			rollbackErr = fmt.Errorf("%w: subscriber %d: %s", ErrGasScheduleRollbackFailed, index, err.Error())
+		}
+	}
+
+	return rollbackErr
+}
+
+// LatestGasSchedule returns a copy of the current gas schedule
+func (gsn *gasScheduleNotifier) LatestGasSchedule() map[string]map[string]uint64 {
+	gsn.mut.RLock()
+	defer gsn.mut.RUnlock()
+
+	return copyGasSchedule(gsn.gasSchedule)
+}
+
+// LatestGasCost returns the built-in functions gas cost read from the current gas schedule
+func (gsn *gasScheduleNotifier) LatestGasCost() vmcommon.GasCost {
+	gsn.mut.RLock()
+	defer gsn.mut.RUnlock()
+
+	return *gsn.gasCost
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (gsn *gasScheduleNotifier) IsInterfaceNil() bool {
+	return gsn == nil
+}
@@ -0,0 +1,168 @@
+package gasSchedule
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+)
+
+var errSubscriber = errors.New("subscriber error")
+
+type gasScheduleSubscriberStub struct {
+	GasScheduleChangeCalled func(gasSchedule map[string]map[string]uint64) error
+	applied                 []uint64
+}
+
+func (stub *gasScheduleSubscriberStub) GasScheduleChange(gasSchedule map[string]map[string]uint64) error {
+	if stub.GasScheduleChangeCalled != nil {
+		err := stub.GasScheduleChangeCalled(gasSchedule)
+		if err != nil {
+			return err
+		}
+	}
+
+	stub.applied = append(stub.applied, gasSchedule["BuiltInCost"]["DCTTransfer"])
+	return nil
+}
+
+func (stub *gasScheduleSubscriberStub) IsInterfaceNil() bool {
+	return stub == nil
+}
+
+func createGasScheduleWithTransferCost(transferCost uint64) map[string]map[string]uint64 {
+	gasSchedule := createGasScheduleForTests(1)
+	gasSchedule["BuiltInCost"]["DCTTransfer"] = transferCost
+
+	return gasSchedule
+}
+
+func TestNewGasScheduleNotifier(t *testing.T) {
+	t.Parallel()
+
+	gsn, err := NewGasScheduleNotifier(ArgsNewGasScheduleNotifier{GasSchedule: createGasScheduleWithTransferCost(0)})
+	assert.True(t, check.IfNil(gsn))
+	assert.True(t, errors.Is(err, ErrZeroGasScheduleValue))
+
+	gsn, err = NewGasScheduleNotifier(ArgsNewGasScheduleNotifier{GasSchedule: createGasScheduleWithTransferCost(2)})
+	require.Nil(t, err)
+	assert.False(t, check.IfNil(gsn))
+	assert.Equal(t, uint64(2), gsn.LatestGasCost().BuiltInCost.DCTTransfer)
+}
+
+func TestGasScheduleNotifier_RegisterNotifyHandler(t *testing.T) {
+	t.Parallel()
+
+	gsn, _ := NewGasScheduleNotifier(ArgsNewGasScheduleNotifier{GasSchedule: createGasScheduleWithTransferCost(2)})
+	assert.Equal(t, ErrNilGasScheduleSubscriber, gsn.RegisterNotifyHandler(nil))
+
+	failing := &gasScheduleSubscriberStub{
+		GasScheduleChangeCalled: func(_ map[string]map[string]uint64) error {
+			return errSubscriber
+		},
+	}
+	err := gsn.RegisterNotifyHandler(failing)
+	assert.True(t, errors.Is(err, ErrGasScheduleChangeFailed))
+	assert.Empty(t, gsn.subscribers)
+
+	subscriber := &gasScheduleSubscriberStub{}
+	err = gsn.RegisterNotifyHandler(subscriber)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{2}, subscriber.applied)
+}
+
+func TestGasScheduleNotifier_ChangeGasSchedule(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid schedule should not reach the subscribers", func(t *testing.T) {
+		t.Parallel()
+
+		gsn, _ := NewGasScheduleNotifier(ArgsNewGasScheduleNotifier{GasSchedule: createGasScheduleWithTransferCost(2)})
+		subscriber := &gasScheduleSubscriberStub{}
+		_ = gsn.RegisterNotifyHandler(subscriber)
+
+		invalid := createGasScheduleWithTransferCost(3)
+		delete(invalid["BuiltInCost"], "DCTBurn")
+		err := gsn.ChangeGasSchedule(invalid)
+		assert.True(t, errors.Is(err, ErrMissingGasScheduleKey))
+		assert.Equal(t, []uint64{2}, subscriber.applied)
+	})
+	t.Run("schedule should be read leniently", func(t *testing.T) {
+		t.Parallel()
+
+		gsn, _ := NewGasScheduleNotifier(ArgsNewGasScheduleNotifier{GasSchedule: createGasScheduleWithTransferCost(2)})
+
+		gasSchedule := createGasScheduleWithTransferCost(3)
+		delete(gasSchedule["BuiltInCost"], "DCTNFTAddURI")
+		gasSchedule["BuiltInCost"]["DCTNFTAddUri"] = 7
+		gasSchedule["BaseOperationCost"]["GetCode"] = 1
+		err := gsn.ChangeGasSchedule(gasSchedule)
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(7), gsn.LatestGasCost().BuiltInCost.DCTNFTAddURI)
+	})
+	t.Run("should apply to all subscribers", func(t *testing.T) {
+		t.Parallel()
+
+		gsn, _ := NewGasScheduleNotifier(ArgsNewGasScheduleNotifier{GasSchedule: createGasScheduleWithTransferCost(2)})
+		first := &gasScheduleSubscriberStub{}
+		second := &gasScheduleSubscriberStub{}
+		_ = gsn.RegisterNotifyHandler(first)
+		_ = gsn.RegisterNotifyHandler(second)
+
+		err := gsn.ChangeGasSchedule(createGasScheduleWithTransferCost(3))
+		assert.Nil(t, err)
+		assert.Equal(t, []uint64{2, 3}, first.applied)
+		assert.Equal(t, []uint64{2, 3}, second.applied)
+		assert.Equal(t, uint64(3), gsn.LatestGasSchedule()["BuiltInCost"]["DCTTransfer"])
+		assert.Equal(t, uint64(3), gsn.LatestGasCost().BuiltInCost.DCTTransfer)
+	})
+	t.Run("failing subscriber should roll back the previous ones", func(t *testing.T) {
+		t.Parallel()
+
+		gsn, _ := NewGasScheduleNotifier(ArgsNewGasScheduleNotifier{GasSchedule: createGasScheduleWithTransferCost(2)})
+		first := &gasScheduleSubscriberStub{}
+		second := &gasScheduleSubscriberStub{}
+		third := &gasScheduleSubscriberStub{}
+		_ = gsn.RegisterNotifyHandler(first)
+		_ = gsn.RegisterNotifyHandler(second)
+		_ = gsn.RegisterNotifyHandler(third)
+		second.GasScheduleChangeCalled = func(gasSchedule map[string]map[string]uint64) error {
+			if gasSchedule["BuiltInCost"]["DCTTransfer"] == 3 {
+				return errSubscriber
+			}
+			return nil
+		}
+
+		err := gsn.ChangeGasSchedule(createGasScheduleWithTransferCost(3))
+		assert.True(t, errors.Is(err, ErrGasScheduleChangeFailed))
+		assert.Contains(t, err.Error(), "subscriber 1: subscriber error")
+		assert.Equal(t, []uint64{2, 3, 2}, first.applied)
+		assert.Equal(t, []uint64{2}, second.applied)
+		assert.Equal(t, []uint64{2}, third.applied)
+		assert.Equal(t, uint64(2), gsn.LatestGasCost().BuiltInCost.DCTTransfer)
+	})
+	t.Run("failed rollback should be reported", func(t *testing.T) {
+		t.Parallel()
+
+		gsn, _ := NewGasScheduleNotifier(ArgsNewGasScheduleNotifier{GasSchedule: createGasScheduleWithTransferCost(2)})
+		first := &gasScheduleSubscriberStub{}
+		second := &gasScheduleSubscriberStub{}
+		_ = gsn.RegisterNotifyHandler(first)
+		_ = gsn.RegisterNotifyHandler(second)
+		first.GasScheduleChangeCalled = func(gasSchedule map[string]map[string]uint64) error {
+			if gasSchedule["BuiltInCost"]["DCTTransfer"] == 2 {
+				return errSubscriber
+			}
+			return nil
+		}
+		second.GasScheduleChangeCalled = func(_ map[string]map[string]uint64) error {
+			return errSubscriber
+		}
+
+		err := gsn.ChangeGasSchedule(createGasScheduleWithTransferCost(3))
+		assert.True(t, errors.Is(err, ErrGasScheduleChangeFailed))
+		assert.Contains(t, err.Error(), ErrGasScheduleRollbackFailed.Error()+": subscriber 0")
+	})
+}
@@ -0,0 +1,7 @@
+package gasSchedule
+
+// GasScheduleSubscriberHandler defines a component that is notified when the gas schedule changes
+type GasScheduleSubscriberHandler interface {
+	GasScheduleChange(gasSchedule map[string]map[string]uint64) error
+	IsInterfaceNil() bool
+}
@@ -0,0 +1,122 @@
+package gasSchedule
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/subrahamanyam341/andes-core-16/core"
+	logger "github.com/subrahamanyam341/andes-logger-123"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+)
+
+var log = logger.GetOrCreate("gasSchedule")
+
+// ValidateGasSchedule checks the BaseOperationCost and BuiltInCost sections of the gas schedule and returns the
+// gas cost read from them. The sections must hold exactly the fields of vmcommon.BaseOperationCost and
+// vmcommon.BuiltInCost, with non-zero values. The errors name all the offending keys, as Section.Key. The other
+// sections are used by the VMs and are not checked.
+func ValidateGasSchedule(gasSchedule map[string]map[string]uint64) (*vmcommon.GasCost, error) {
+	return validateGasSchedule(gasSchedule, false)
+}
+
+// ValidateGasScheduleLenient checks the gas schedule as ValidateGasSchedule does, but reads the keys the way the
+// gas schedules applied at runtime always were: the keys are matched case-insensitively, an exact match being
+// preferred, and the keys that match no field are ignored with a warning.
+func ValidateGasScheduleLenient(gasSchedule map[string]map[string]uint64) (*vmcommon.GasCost, error) {
+	return validateGasSchedule(gasSchedule, true)
+}
+
+func validateGasSchedule(gasSchedule map[string]map[string]uint64, lenient bool) (*vmcommon.GasCost, error) {
+	gasCost := &vmcommon.GasCost{}
+
+	err := fillSection(gasSchedule, core.BaseOperationCostString, &gasCost.BaseOperationCost, lenient)
+	if err != nil {
+		return nil, err
+	}
+
+	err = fillSection(gasSchedule, core.BuiltInCostString, &gasCost.BuiltInCost, lenient)
+	if err != nil {
+		return nil, err
+	}
+
+	return gasCost, nil
+}
+
+func fillSection(gasSchedule map[string]map[string]uint64, section string, dest interface{}, lenient bool) error {
+	values, found := gasSchedule[section]
+	if !found {
+		return fmt.Errorf("%w: %s", ErrMissingGasScheduleSection, section)
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	structValue := reflect.ValueOf(dest).Elem()
+	structType := structValue.Type()
+	usedKeys := make(map[string]struct{}, structType.NumField())
+	missingKeys := make([]string, 0)
+	zeroKeys := make([]string, 0)
+	for i := 0; i < structType.NumField(); i++ {
+		name := structType.Field(i).Name
+		key, exists := findKey(values, keys, name, lenient)
+		if !exists {
+			missingKeys = append(missingKeys, section+"."+name)
+			continue
+		}
+
+		usedKeys[key] = struct{}{}
+		value := values[key]
+		if value == 0 {
+			zeroKeys = append(zeroKeys, section+"."+name)
+			continue
+		}
+
+		structValue.Field(i).SetUint(value)
+	}
+
+	unknownKeys := make([]string, 0)
+	for _, key := range keys {
+		if _, used := usedKeys[key]; !used {
+			unknownKeys = append(unknownKeys, section+"."+key)
+		}
+	}
+	if lenient {
+		for _, key := range unknownKeys {
+			log.Warn("unknown gas schedule key ignored", "key", key)
+		}
+		unknownKeys = unknownKeys[:0]
+	}
+
+	switch {
+	case len(missingKeys) > 0:
+		return fmt.Errorf("%w: %s", ErrMissingGasScheduleKey, strings.Join(missingKeys, ", "))
+	case len(unknownKeys) > 0:
+		return fmt.Errorf("%w: %s", ErrUnknownGasScheduleKey, strings.Join(unknownKeys, ", "))
+	case len(zeroKeys) > 0:
+		return fmt.Errorf("%w: %s", ErrZeroGasScheduleValue, strings.Join(zeroKeys, ", "))
+	default:
+		return nil
+	}
+}
+
+// findKey returns the key equal to the field name or, in lenient mode, the first of the sorted keys equal to it
+// case-insensitively
+func findKey(values map[string]uint64, sortedKeys []string, name string, lenient bool) (string, bool) {
+	_, exists := values[name]
+	if exists || !lenient {
+		return name, exists
+	}
+
+	for _, key := range sortedKeys {
+		if strings.EqualFold(key, name) {
+			return key, true
+		}
+	}
+
+	return "", false
+}
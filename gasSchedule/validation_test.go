@@ -0,0 +1,118 @@
+package gasSchedule
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+)
+
+func createSectionForTests(structValue interface{}, value uint64) map[string]uint64 {
+	section := make(map[string]uint64)
+	structType := reflect.TypeOf(structValue)
+	for i := 0; i < structType.NumField(); i++ {
+		section[structType.Field(i).Name] = value
+	}
+
+	return section
+}
+
+func createGasScheduleForTests(value uint64) map[string]map[string]uint64 {
+	return map[string]map[string]uint64{
+		core.BaseOperationCostString: createSectionForTests(vmcommon.BaseOperationCost{}, value),
+		core.BuiltInCostString:       createSectionForTests(vmcommon.BuiltInCost{}, value),
+		"WASMOpcodeCost":             {"Unreachable": 5},
+	}
+}
+
+func TestValidateGasSchedule(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid schedule should return the gas cost", func(t *testing.T) {
+		t.Parallel()
+
+		gasSchedule := createGasScheduleForTests(3)
+		gasSchedule[core.BuiltInCostString]["DCTTransfer"] = 7
+
+		gasCost, err := ValidateGasSchedule(gasSchedule)
+		require.Nil(t, err)
+		assert.Equal(t, uint64(7), gasCost.BuiltInCost.DCTTransfer)
+		assert.Equal(t, uint64(3), gasCost.BuiltInCost.TrieLoadPerNode)
+		assert.Equal(t, uint64(3), gasCost.BaseOperationCost.StorePerByte)
+	})
+	t.Run("missing section should error", func(t *testing.T) {
+		t.Parallel()
+
+		gasSchedule := createGasScheduleForTests(1)
+		delete(gasSchedule, core.BuiltInCostString)
+
+		_, err := ValidateGasSchedule(gasSchedule)
+		assert.True(t, errors.Is(err, ErrMissingGasScheduleSection))
+		assert.True(t, strings.Contains(err.Error(), core.BuiltInCostString))
+	})
+	t.Run("invalid keys should be named in the error", func(t *testing.T) {
+		t.Parallel()
+
+		gasSchedule := createGasScheduleForTests(1)
+		delete(gasSchedule[core.BuiltInCostString], "SetGuardian")
+		delete(gasSchedule[core.BuiltInCostString], "DCTBurn")
+		_, err := ValidateGasSchedule(gasSchedule)
+		assert.True(t, errors.Is(err, ErrMissingGasScheduleKey))
+		assert.True(t, strings.HasSuffix(err.Error(), ": BuiltInCost.DCTBurn, BuiltInCost.SetGuardian"), err.Error())
+
+		gasSchedule = createGasScheduleForTests(1)
+		gasSchedule[core.BaseOperationCostString]["StorePerByt"] = 1
+		_, err = ValidateGasSchedule(gasSchedule)
+		assert.True(t, errors.Is(err, ErrUnknownGasScheduleKey))
+		assert.True(t, strings.HasSuffix(err.Error(), ": BaseOperationCost.StorePerByt"), err.Error())
+
+		gasSchedule = createGasScheduleForTests(1)
+		gasSchedule[core.BaseOperationCostString]["PersistPerByte"] = 0
+		_, err = ValidateGasSchedule(gasSchedule)
+		assert.True(t, errors.Is(err, ErrZeroGasScheduleValue))
+		assert.True(t, strings.HasSuffix(err.Error(), ": BaseOperationCost.PersistPerByte"), err.Error())
+	})
+}
+
+func TestValidateGasScheduleLenient(t *testing.T) {
+	t.Parallel()
+
+	t.Run("keys should be matched case-insensitively and unknown keys ignored", func(t *testing.T) {
+		t.Parallel()
+
+		gasSchedule := createGasScheduleForTests(3)
+		delete(gasSchedule[core.BuiltInCostString], "DCTNFTAddURI")
+		gasSchedule[core.BuiltInCostString]["DCTNFTAddUri"] = 7
+		gasSchedule[core.BuiltInCostString]["dcttransfer"] = 0
+		gasSchedule[core.BaseOperationCostString]["GetCode"] = 1
+
+		_, err := ValidateGasSchedule(gasSchedule)
+		assert.True(t, errors.Is(err, ErrUnknownGasScheduleKey))
+
+		gasCost, err := ValidateGasScheduleLenient(gasSchedule)
+		require.Nil(t, err)
+		assert.Equal(t, uint64(7), gasCost.BuiltInCost.DCTNFTAddURI)
+		assert.Equal(t, uint64(3), gasCost.BuiltInCost.DCTTransfer)
+	})
+	t.Run("missing and zero keys should error", func(t *testing.T) {
+		t.Parallel()
+
+		gasSchedule := createGasScheduleForTests(1)
+		delete(gasSchedule[core.BuiltInCostString], "SetGuardian")
+		_, err := ValidateGasScheduleLenient(gasSchedule)
+		assert.True(t, errors.Is(err, ErrMissingGasScheduleKey))
+		assert.True(t, strings.HasSuffix(err.Error(), ": BuiltInCost.SetGuardian"), err.Error())
+
+		gasSchedule = createGasScheduleForTests(1)
+		gasSchedule[core.BaseOperationCostString]["persistPerByte"] = 3
+		gasSchedule[core.BaseOperationCostString]["PersistPerByte"] = 0
+		_, err = ValidateGasScheduleLenient(gasSchedule)
+		assert.True(t, errors.Is(err, ErrZeroGasScheduleValue))
+		assert.True(t, strings.HasSuffix(err.Error(), ": BaseOperationCost.PersistPerByte"), err.Error())
+	})
+}
@@ -0,0 +1,156 @@
+package gasSchedule
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/subrahamanyam341/andes-core-16/core"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+)
+
+// GasScheduleByEpochs names the gas schedule file that is active starting with the epoch
+type GasScheduleByEpochs struct {
+	StartEpoch uint32
+	FileName   string
+}
+
+// ArgsNewVersionedGasSchedule is the argument structure used to create a new versioned gas schedule
+type ArgsNewVersionedGasSchedule struct {
+	Directory           string
+	GasScheduleByEpochs []GasScheduleByEpochs
+}
+
+type gasScheduleVersion struct {
+	startEpoch  uint32
+	fileName    string
+	gasSchedule map[string]map[string]uint64
+	gasCost     *vmcommon.GasCost
+}
+
+type versionedGasSchedule struct {
+	versions []*gasScheduleVersion
+}
+
+// NewVersionedGasSchedule loads and validates all the gas schedule versions from the directory. One of the
+// versions must start in epoch 0, so that every epoch has an active gas schedule.
+func NewVersionedGasSchedule(args ArgsNewVersionedGasSchedule) (*versionedGasSchedule, error) {
+	if len(args.GasScheduleByEpochs) == 0 {
+		return nil, ErrNoGasScheduleVersions
+	}
+
+	versions := make([]*gasScheduleVersion, 0, len(args.GasScheduleByEpochs))
+	startEpochs := make(map[uint32]struct{}, len(args.GasScheduleByEpochs))
+	for _, byEpoch := range args.GasScheduleByEpochs {
+		if _, found := startEpochs[byEpoch.StartEpoch]; found {
+			return nil, fmt.Errorf("%w: %d", ErrDuplicatedGasScheduleEpoch, byEpoch.StartEpoch)
+		}
+		startEpochs[byEpoch.StartEpoch] = struct{}{}
+
+		gasSchedule, err := LoadGasScheduleFile(filepath.Join(args.Directory, byEpoch.FileName))
+		if err != nil {
+			return nil, err
+		}
+		gasCost, err := ValidateGasSchedule(gasSchedule)
+		if err != nil {
+			return nil, fmt.Errorf("%w in %s", err, byEpoch.FileName)
+		}
+
+		versions = append(versions, &gasScheduleVersion{
+			startEpoch:  byEpoch.StartEpoch,
+			fileName:    byEpoch.FileName,
+			gasSchedule: gasSchedule,
+			gasCost:     gasCost,
+		})
+	}
+	if _, found := startEpochs[0]; !found {
+		return nil, ErrMissingGenesisGasSchedule
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].startEpoch < versions[j].startEpoch
+	})
+
+	return &versionedGasSchedule{
+		versions: versions,
+	}, nil
+}
+
+// LoadGasScheduleFile reads a gas schedule from a TOML file, in which each table is a section of costs
+func LoadGasScheduleFile(path string) (map[string]map[string]uint64, error) {
+	loaded, err := core.LoadTomlFileToMap(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: %s", ErrInvalidGasScheduleFile, path, err.Error())
+	}
+
+	gasSchedule := make(map[string]map[string]uint64, len(loaded))
+	for section, sectionValue := range loaded {
+		values, ok := sectionValue.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w %s: %s is not a table", ErrInvalidGasScheduleFile, path, section)
+		}
+
+		gasSchedule[section] = make(map[string]uint64, len(values))
+		for key, value := range values {
+			cost, isInt := value.(int64)
+			if !isInt || cost < 0 {
+				return nil, fmt.Errorf("%w %s: %s.%s is not a positive integer", ErrInvalidGasScheduleFile, path, section, key)
+			}
+			gasSchedule[section][key] = uint64(cost)
+		}
+	}
+
+	return gasSchedule, nil
+}
+
+// GasScheduleForEpoch returns a copy of the gas schedule active in the epoch
+func (vgs *versionedGasSchedule) GasScheduleForEpoch(epoch uint32) map[string]map[string]uint64 {
+	return copyGasSchedule(vgs.versionForEpoch(epoch).gasSchedule)
+}
+
+// GasCostForEpoch returns the built-in functions gas cost active in the epoch
+func (vgs *versionedGasSchedule) GasCostForEpoch(epoch uint32) vmcommon.GasCost {
+	return *vgs.versionForEpoch(epoch).gasCost
+}
+
+// FileNameForEpoch returns the name of the gas schedule file active in the epoch
+func (vgs *versionedGasSchedule) FileNameForEpoch(epoch uint32) string {
+	return vgs.versionForEpoch(epoch).fileName
+}
+
+// DiffForEpoch returns the changes activated in the epoch, compared with the previous epoch. The diff is empty if
+// no new version starts in the epoch.
+func (vgs *versionedGasSchedule) DiffForEpoch(epoch uint32) GasScheduleDiff {
+	current := vgs.versionForEpoch(epoch)
+	if epoch == 0 || current.startEpoch != epoch {
+		return make(GasScheduleDiff, 0)
+	}
+
+	return DiffGasSchedules(vgs.versionForEpoch(epoch-1).gasSchedule, current.gasSchedule)
+}
+
+func (vgs *versionedGasSchedule) versionForEpoch(epoch uint32) *gasScheduleVersion {
+	// the first version starts in epoch 0, so the search never returns 0
+	index := sort.Search(len(vgs.versions), func(i int) bool {
+		return vgs.versions[i].startEpoch > epoch
+	})
+
+	return vgs.versions[index-1]
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (vgs *versionedGasSchedule) IsInterfaceNil() bool {
+	return vgs == nil
+}
+
+func copyGasSchedule(gasSchedule map[string]map[string]uint64) map[string]map[string]uint64 {
+	copied := make(map[string]map[string]uint64, len(gasSchedule))
+	for section, values := range gasSchedule {
+		copied[section] = make(map[string]uint64, len(values))
+		for key, value := range values {
+			copied[section][key] = value
+		}
+	}
+
+	return copied
+}
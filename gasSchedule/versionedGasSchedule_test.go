@@ -0,0 +1,102 @@
+package gasSchedule
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+)
+
+func writeGasScheduleFile(t *testing.T, directory string, fileName string, gasSchedule map[string]map[string]uint64) {
+	builder := strings.Builder{}
+	for section, values := range gasSchedule {
+		builder.WriteString(fmt.Sprintf("[%s]\n", section))
+		keys := make([]string, 0, len(values))
+		for key := range values {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			builder.WriteString(fmt.Sprintf("    %s = %d\n", key, values[key]))
+		}
+	}
+
+	err := os.WriteFile(filepath.Join(directory, fileName), []byte(builder.String()), 0644)
+	require.Nil(t, err)
+}
+
+func TestNewVersionedGasSchedule(t *testing.T) {
+	t.Parallel()
+
+	directory := t.TempDir()
+	writeGasScheduleFile(t, directory, "gasScheduleV1.toml", createGasScheduleForTests(1))
+	invalid := createGasScheduleForTests(1)
+	invalid["BuiltInCost"]["Unknown"] = 1
+	writeGasScheduleFile(t, directory, "invalid.toml", invalid)
+	require.Nil(t, os.WriteFile(filepath.Join(directory, "negative.toml"), []byte("[BuiltInCost]\nDCTTransfer = -1\n"), 0644))
+
+	testCases := map[string]struct {
+		byEpochs    []GasScheduleByEpochs
+		expectedErr error
+	}{
+		"no versions":       {byEpochs: nil, expectedErr: ErrNoGasScheduleVersions},
+		"missing file":      {byEpochs: []GasScheduleByEpochs{{FileName: "missing.toml"}}, expectedErr: ErrInvalidGasScheduleFile},
+		"negative value":    {byEpochs: []GasScheduleByEpochs{{FileName: "negative.toml"}}, expectedErr: ErrInvalidGasScheduleFile},
+		"invalid schedule":  {byEpochs: []GasScheduleByEpochs{{FileName: "invalid.toml"}}, expectedErr: ErrUnknownGasScheduleKey},
+		"no genesis":        {byEpochs: []GasScheduleByEpochs{{StartEpoch: 1, FileName: "gasScheduleV1.toml"}}, expectedErr: ErrMissingGenesisGasSchedule},
+		"duplicated epochs": {byEpochs: []GasScheduleByEpochs{{FileName: "gasScheduleV1.toml"}, {FileName: "gasScheduleV1.toml"}}, expectedErr: ErrDuplicatedGasScheduleEpoch},
+	}
+	for name, tc := range testCases {
+		vgs, err := NewVersionedGasSchedule(ArgsNewVersionedGasSchedule{
+			Directory:           directory,
+			GasScheduleByEpochs: tc.byEpochs,
+		})
+		assert.True(t, check.IfNil(vgs), name)
+		assert.True(t, errors.Is(err, tc.expectedErr), name)
+	}
+}
+
+func TestVersionedGasSchedule_ForEpoch(t *testing.T) {
+	t.Parallel()
+
+	directory := t.TempDir()
+	writeGasScheduleFile(t, directory, "gasScheduleV1.toml", createGasScheduleForTests(1))
+	second := createGasScheduleForTests(1)
+	second["BuiltInCost"]["DCTTransfer"] = 2
+	writeGasScheduleFile(t, directory, "gasScheduleV2.toml", second)
+	writeGasScheduleFile(t, directory, "gasScheduleV3.toml", createGasScheduleForTests(3))
+
+	vgs, err := NewVersionedGasSchedule(ArgsNewVersionedGasSchedule{
+		Directory: directory,
+		GasScheduleByEpochs: []GasScheduleByEpochs{
+			{StartEpoch: 10, FileName: "gasScheduleV3.toml"},
+			{StartEpoch: 0, FileName: "gasScheduleV1.toml"},
+			{StartEpoch: 5, FileName: "gasScheduleV2.toml"},
+		},
+	})
+	require.Nil(t, err)
+	assert.False(t, check.IfNil(vgs))
+
+	assert.Equal(t, "gasScheduleV1.toml", vgs.FileNameForEpoch(0))
+	assert.Equal(t, "gasScheduleV1.toml", vgs.FileNameForEpoch(4))
+	assert.Equal(t, "gasScheduleV2.toml", vgs.FileNameForEpoch(5))
+	assert.Equal(t, "gasScheduleV3.toml", vgs.FileNameForEpoch(1000))
+	assert.Equal(t, uint64(2), vgs.GasCostForEpoch(7).BuiltInCost.DCTTransfer)
+	assert.Equal(t, second, vgs.GasScheduleForEpoch(5))
+
+	// the returned schedule is a copy
+	vgs.GasScheduleForEpoch(5)["BuiltInCost"]["DCTTransfer"] = 100
+	assert.Equal(t, uint64(2), vgs.GasScheduleForEpoch(5)["BuiltInCost"]["DCTTransfer"])
+
+	assert.Empty(t, vgs.DiffForEpoch(0))
+	assert.Empty(t, vgs.DiffForEpoch(6))
+	assert.Equal(t, "BuiltInCost.DCTTransfer: 1 -> 2 (+100.00%)", vgs.DiffForEpoch(5).String())
+	assert.Equal(t, len(second["BuiltInCost"])+len(second["BaseOperationCost"]), len(vgs.DiffForEpoch(10)))
+}
@@ -0,0 +1,32 @@
+package gasSchedule
+
+import (
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+)
+
+type vmGasScheduleSubscriber struct {
+	vm vmcommon.VMExecutionHandler
+}
+
+// NewVMGasScheduleSubscriber wraps a VM, so that it can be registered to the gas schedule notifier
+func NewVMGasScheduleSubscriber(vm vmcommon.VMExecutionHandler) (*vmGasScheduleSubscriber, error) {
+	if check.IfNil(vm) {
+		return nil, ErrNilVMExecutionHandler
+	}
+
+	return &vmGasScheduleSubscriber{
+		vm: vm,
+	}, nil
+}
+
+// GasScheduleChange forwards the gas schedule to the VM, which does not report errors
+func (subscriber *vmGasScheduleSubscriber) GasScheduleChange(gasSchedule map[string]map[string]uint64) error {
+	subscriber.vm.GasScheduleChange(gasSchedule)
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (subscriber *vmGasScheduleSubscriber) IsInterfaceNil() bool {
+	return subscriber == nil
+}
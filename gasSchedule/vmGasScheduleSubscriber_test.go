@@ -0,0 +1,41 @@
+package gasSchedule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+)
+
+type vmExecutionHandlerStub struct {
+	vmcommon.VMExecutionHandler
+	gasSchedule map[string]map[string]uint64
+}
+
+func (stub *vmExecutionHandlerStub) GasScheduleChange(gasSchedule map[string]map[string]uint64) {
+	stub.gasSchedule = gasSchedule
+}
+
+func (stub *vmExecutionHandlerStub) IsInterfaceNil() bool {
+	return stub == nil
+}
+
+func TestVMGasScheduleSubscriber(t *testing.T) {
+	t.Parallel()
+
+	subscriber, err := NewVMGasScheduleSubscriber(nil)
+	assert.True(t, check.IfNil(subscriber))
+	assert.Equal(t, ErrNilVMExecutionHandler, err)
+
+	vm := &vmExecutionHandlerStub{}
+	subscriber, err = NewVMGasScheduleSubscriber(vm)
+	require.Nil(t, err)
+	assert.False(t, check.IfNil(subscriber))
+
+	gsn, _ := NewGasScheduleNotifier(ArgsNewGasScheduleNotifier{GasSchedule: createGasScheduleForTests(4)})
+	err = gsn.RegisterNotifyHandler(subscriber)
+	assert.Nil(t, err)
+	assert.Equal(t, gsn.LatestGasSchedule(), vm.gasSchedule)
+}
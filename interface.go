@@ -260,6 +260,11 @@ type BuiltinFunction interface {
 	IsInterfaceNil() bool
 }
 
+// ExecutableChecker defines the built-in functions that can check if a call is executable without changing the state
+type ExecutableChecker interface {
+	CheckIsExecutable(acntSnd, acntDst UserAccountHandler, vmInput *ContractCallInput) error
+}
+
 // BuiltInFunctionContainer defines the methods for the built-in protocol container
 type BuiltInFunctionContainer interface {
 	Get(key string) (BuiltinFunction, error)
@@ -271,6 +276,13 @@ type BuiltInFunctionContainer interface {
 	IsInterfaceNil() bool
 }
 
+// BuiltInFunctionsProcessor defines the component that executes built-in functions on the accounts state
+type BuiltInFunctionsProcessor interface {
+	ProcessBuiltInFunction(input *ContractCallInput) (*VMOutput, error)
+	CheckIsExecutable(input *ContractCallInput) error
+	IsInterfaceNil() bool
+}
+
 // EpochSubscriberHandler defines the behavior of a component that can be notified if a new epoch was confirmed
 type EpochSubscriberHandler interface {
 	EpochConfirmed(epoch uint32, timestamp uint64)
@@ -408,6 +420,16 @@ type EnableEpochsHandler interface {
 	IsInterfaceNil() bool
 }
 
+// Flag is the name of a protocol feature flag
+type Flag string
+
+// FlagRegistryHandler tells if the named protocol feature flags are enabled
+type FlagRegistryHandler interface {
+	IsFlagEnabled(flag Flag) bool
+	GetActivationEpoch(flag Flag) uint32
+	IsInterfaceNil() bool
+}
+
 // GuardedAccountHandler allows setting and getting the configured account guardian
 type GuardedAccountHandler interface {
 	GetActiveGuardian(handler UserAccountHandler) ([]byte, error)
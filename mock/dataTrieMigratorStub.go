@@ -0,0 +1,41 @@
+package mock
+
+import (
+	"github.com/subrahamanyam341/andes-core-16/core"
+)
+
+// DataTrieMigratorStub -
+type DataTrieMigratorStub struct {
+	ConsumeStorageLoadGasCalled   func() bool
+	AddLeafToMigrationQueueCalled func(leafData core.TrieData, newLeafVersion core.TrieNodeVersion) (bool, error)
+	GetLeavesToBeMigratedCalled   func() []core.TrieData
+}
+
+// ConsumeStorageLoadGas -
+func (d *DataTrieMigratorStub) ConsumeStorageLoadGas() bool {
+	if d.ConsumeStorageLoadGasCalled != nil {
+		return d.ConsumeStorageLoadGasCalled()
+	}
+	return true
+}
+
+// AddLeafToMigrationQueue -
+func (d *DataTrieMigratorStub) AddLeafToMigrationQueue(leafData core.TrieData, newLeafVersion core.TrieNodeVersion) (bool, error) {
+	if d.AddLeafToMigrationQueueCalled != nil {
+		return d.AddLeafToMigrationQueueCalled(leafData, newLeafVersion)
+	}
+	return true, nil
+}
+
+// GetLeavesToBeMigrated -
+func (d *DataTrieMigratorStub) GetLeavesToBeMigrated() []core.TrieData {
+	if d.GetLeavesToBeMigratedCalled != nil {
+		return d.GetLeavesToBeMigratedCalled()
+	}
+	return nil
+}
+
+// IsInterfaceNil -
+func (d *DataTrieMigratorStub) IsInterfaceNil() bool {
+	return d == nil
+}
@@ -0,0 +1,20 @@
+package mock
+
+import vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+
+// EpochNotifierStub -
+type EpochNotifierStub struct {
+	RegisterNotifyHandlerCalled func(handler vmcommon.EpochSubscriberHandler)
+}
+
+// RegisterNotifyHandler -
+func (stub *EpochNotifierStub) RegisterNotifyHandler(handler vmcommon.EpochSubscriberHandler) {
+	if stub.RegisterNotifyHandlerCalled != nil {
+		stub.RegisterNotifyHandlerCalled(handler)
+	}
+}
+
+// IsInterfaceNil -
+func (stub *EpochNotifierStub) IsInterfaceNil() bool {
+	return stub == nil
+}
@@ -0,0 +1,20 @@
+package mock
+
+import vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+
+// RoundNotifierStub -
+type RoundNotifierStub struct {
+	RegisterNotifyHandlerCalled func(handler vmcommon.RoundSubscriberHandler)
+}
+
+// RegisterNotifyHandler -
+func (stub *RoundNotifierStub) RegisterNotifyHandler(handler vmcommon.RoundSubscriberHandler) {
+	if stub.RegisterNotifyHandlerCalled != nil {
+		stub.RegisterNotifyHandlerCalled(handler)
+	}
+}
+
+// IsInterfaceNil -
+func (stub *RoundNotifierStub) IsInterfaceNil() bool {
+	return stub == nil
+}
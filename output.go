@@ -163,6 +163,11 @@ type VMOutput struct {
 	// The logs should be accessible to the UI.
 	// The logs are part of the transaction receipt.
 	Logs []*LogEntry
+
+	// StorageTrace holds the storage accesses of the call, if it was executed with storage tracing enabled.
+	// It is kept in memory only, for the debugging tools: the canonical encoding, the hash, the JSON codec and the diff
+	// all ignore it.
+	StorageTrace *StorageTrace
 }
 
 // GetFirstReturnData is a helper function that returns the first ReturnData of VMOutput, interpreted as specified.
@@ -3,6 +3,7 @@ package datafield
 import (
 	"github.com/subrahamanyam341/andes-core-16/core"
 	"github.com/subrahamanyam341/andes-core-16/core/sharding"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
 )
 
 func (odp *operationDataFieldParser) parseMultiDCTNFTTransfer(args [][]byte, function string, sender, receiver []byte, numOfShards uint32) *ResponseParseData {
@@ -22,10 +23,10 @@ func (odp *operationDataFieldParser) parseMultiDCTNFTTransfer(args [][]byte, fun
 			}
 		}
 
-		token := string(dctTransferData.DCTTokenName)
-		if dctTransferData.DCTTokenNonce != 0 {
-			token = computeTokenIdentifier(token, dctTransferData.DCTTokenNonce)
-		}
+		token := vmcommon.TokenIdentifier{
+			TokenID: dctTransferData.DCTTokenName,
+			Nonce:   dctTransferData.DCTTokenNonce,
+		}.String()
 
 		responseParse.Tokens = append(responseParse.Tokens, token)
 		responseParse.DCTValues = append(responseParse.DCTValues, dctTransferData.DCTValue.String())
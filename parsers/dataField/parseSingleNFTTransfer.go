@@ -5,6 +5,7 @@ import (
 
 	"github.com/subrahamanyam341/andes-core-16/core"
 	"github.com/subrahamanyam341/andes-core-16/core/sharding"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
 )
 
 func (odp *operationDataFieldParser) parseSingleDCTNFTTransfer(args [][]byte, function string, sender, receiver []byte, numOfShards uint32) *ResponseParseData {
@@ -28,7 +29,10 @@ func (odp *operationDataFieldParser) parseSingleDCTNFTTransfer(args [][]byte, fu
 
 	dctNFTTransfer := parsedDCTTransfers.DCTTransfers[0]
 	receiverShardID := sharding.ComputeShardID(rcvAddr, numOfShards)
-	token := computeTokenIdentifier(string(dctNFTTransfer.DCTTokenName), dctNFTTransfer.DCTTokenNonce)
+	token := ""
+	if len(dctNFTTransfer.DCTTokenName) > 0 && dctNFTTransfer.DCTTokenNonce != 0 {
+		token = vmcommon.TokenIdentifier{TokenID: dctNFTTransfer.DCTTokenName, Nonce: dctNFTTransfer.DCTTokenNonce}.String()
+	}
 
 	responseParse.Tokens = append(responseParse.Tokens, token)
 	responseParse.DCTValues = append(responseParse.DCTValues, dctNFTTransfer.DCTValue.String())
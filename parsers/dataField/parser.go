@@ -184,16 +184,12 @@ func parseBlockingOperationDCT(args [][]byte, funcName string) *ResponseParseDat
 		return responseData
 	}
 
-	token, nonce := extractTokenAndNonce(args[argsTokenPosition])
-	if !isASCIIString(token) {
+	tokenIdentifier := vmcommon.ParseTokenIdentifierWithLegacySplit(args[argsTokenPosition])
+	if !isASCIIString(string(tokenIdentifier.TokenID)) {
 		return responseData
 	}
 
-	if nonce != 0 {
-		token = computeTokenIdentifier(token, nonce)
-	}
-
-	responseData.Tokens = append(responseData.Tokens, token)
+	responseData.Tokens = append(responseData.Tokens, tokenIdentifier.String())
 	return responseData
 }
 
@@ -232,7 +228,10 @@ func parseQuantityOperationNFT(args [][]byte, funcName string) *ResponseParseDat
 	}
 
 	nonce := big.NewInt(0).SetBytes(args[argsNoncePosition]).Uint64()
-	tokenIdentifier := computeTokenIdentifier(token, nonce)
+	tokenIdentifier := ""
+	if nonce != 0 {
+		tokenIdentifier = vmcommon.TokenIdentifier{TokenID: []byte(token), Nonce: nonce}.String()
+	}
 
 	value := big.NewInt(0).SetBytes(args[argsValuePositionNonAndSemiFungible]).String()
 	if funcName == core.BuiltInFunctionDCTNFTCreate {
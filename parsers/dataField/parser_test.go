@@ -24,7 +24,6 @@ func TestNewOperationDataFieldParser(t *testing.T) {
 
 		arguments := createMockArgumentsOperationParser()
 		arguments.Marshalizer = nil
-		7b226e6f6e6365223a362c2276616c7565223a302c227265636569766572223a2241414141414141414141414641436e626331733351534939726e6d697a69684d7a3631665539446a71786b3d222c2273656e646572223a2248714b386459464a43474144346a756d4e4e742b314530745a6579736376714c7a38624c47574e774177453d222c226761735072696365223a313030303030303030302c226761734c696d6974223a31353030303030302c2264617461223a2252454e5556484a68626e4e6d5a584a414e444d304e7a526a4e4451795a444d354d7a497a4f444d304d7a6b7a4d6b41774d325534514459794e7a55334f54517a4e6a67324e54637a4e7a5241595441774d4441774d44413d222c22636861696e4944223a2252413d3d222c2276657273696f6e223a312c227369676e6174757265223a2262367331755349396f6d4b63514448344337624f534a632f62343166577a3961584d777334526966552b71343870486d315430636f72744b727443484a4258724f67536b3651333254546f7a6e4e2b7074324f4644413d3d227d
 		_, err := NewOperationDataFieldParser(arguments)
 		require.Equal(t, core.ErrNilMarshalizer, err)
 	})
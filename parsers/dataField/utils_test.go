@@ -1,30 +1,13 @@
 package datafield
 
 import (
-	"encoding/hex"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core"
+	"github.com/subrahamanyam341/andes-vm-common-123/builtInFunctions"
 )
 
-func TestExtractTokenAndNonce(t *testing.T) {
-	t.Parallel()
-
-	hexArg := "534b4537592d37336262636404"
-	args, _ := hex.DecodeString(hexArg)
-
-	token, nonce := extractTokenAndNonce(args)
-	require.Equal(t, uint64(4), nonce)
-	require.Equal(t, "SKE7Y-73bbcd", token)
-}
-
-func TestComputeTokenIdentifier(t *testing.T) {
-	t.Parallel()
-
-	identifier := computeTokenIdentifier("MYTOKEN-abcd", 10)
-	require.Equal(t, "MYTOKEN-abcd-0a", identifier)
-}
-
 func TestIsASCIIString(t *testing.T) {
 	t.Parallel()
 
@@ -33,3 +16,14 @@ func TestIsASCIIString(t *testing.T) {
 	require.False(t, isASCIIString(string([]byte{12, 255})))
 	require.False(t, isASCIIString(string([]byte{12, 188})))
 }
+
+func TestGetAllBuiltInFunctions(t *testing.T) {
+	t.Parallel()
+
+	allBuiltInFunctions := getAllBuiltInFunctions()
+	for _, name := range builtInFunctions.BuiltInFunctionNames() {
+		require.True(t, isBuiltInFunction(allBuiltInFunctions, name), name)
+	}
+	require.True(t, isBuiltInFunction(allBuiltInFunctions, core.DCTRoleNFTCreate))
+	require.False(t, isBuiltInFunction(allBuiltInFunctions, "unknownFunction"))
+}
@@ -88,7 +88,11 @@ func (parser *deployArgsParser) parseCodeMetadata(tokens []string) (vmcommon.Cod
 		return vmcommon.CodeMetadata{}, ErrInvalidCodeMetadata
 	}
 
-	codeMetadata := vmcommon.CodeMetadataFromBytes(codeMetadataBytes)
+	codeMetadata, err := vmcommon.ParseCodeMetadataBytes(codeMetadataBytes)
+	if err != nil {
+		return vmcommon.CodeMetadata{}, ErrInvalidCodeMetadata
+	}
+
 	return codeMetadata, nil
 }
 
@@ -63,6 +63,10 @@ func TestDeployArgsParser_ParseDataWhenErrorneousInput(t *testing.T) {
 	require.Equal(t, ErrInvalidCodeMetadata, err)
 	require.Nil(t, parsed)
 
+	parsed, err = parser.ParseData("ABBA@ABBA@01")
+	require.Equal(t, ErrInvalidCodeMetadata, err)
+	require.Nil(t, parsed)
+
 	parsed, err = parser.ParseData("ABBA@ABBA@ABBA@A")
 	require.Equal(t, ErrTokenizeFailed, err)
 	require.Nil(t, parsed)
@@ -29,6 +29,10 @@ func (rc ReturnCode) String() string {
 		return "contract invalid"
 	case ExecutionFailed:
 		return "execution failed"
+	case UpgradeFailed:
+		return "upgrade failed"
+	case SimulateFailed:
+		return "simulate failed"
 	default:
 		return fmt.Sprintf("unknown error, code: %d", rc)
 	}
@@ -0,0 +1,22 @@
+package vmcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReturnCode_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "ok", Ok.String())
+	assert.Equal(t, "out of funds", OutOfFunds.String())
+	assert.Equal(t, "execution failed", ExecutionFailed.String())
+	assert.Equal(t, "upgrade failed", UpgradeFailed.String())
+	assert.Equal(t, "simulate failed", SimulateFailed.String())
+	assert.Equal(t, "unknown error, code: 13", ReturnCode(13).String())
+
+	for returnCode := Ok; returnCode <= SimulateFailed; returnCode++ {
+		assert.NotContains(t, returnCode.String(), "unknown")
+	}
+}
@@ -0,0 +1,241 @@
+package state
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	"github.com/subrahamanyam341/andes-core-16/hashing"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+)
+
+var _ vmcommon.AccountsAdapter = (*accountsAdapter)(nil)
+
+// ArgsNewAccountsAdapter is the argument structure used to create a new in-memory accounts adapter
+type ArgsNewAccountsAdapter struct {
+	Hasher              hashing.Hasher
+	EnableEpochsHandler vmcommon.EnableEpochsHandler
+}
+
+// accountsAdapter is an in-memory accounts adapter. Loaded accounts are copies of the saved ones, so
+// changes become visible only after SaveAccount is called. Every save, including the saved code, and every
+// remove is journaled and can be reverted until the next Commit.
+type accountsAdapter struct {
+	hasher              hashing.Hasher
+	enableEpochsHandler vmcommon.EnableEpochsHandler
+	accounts            map[string]*userAccount
+	codes               map[string][]byte
+	journal             []journalEntry
+	lastRootHash        []byte
+	mutOperation        sync.RWMutex
+}
+
+// NewAccountsAdapter creates a new in-memory accounts adapter
+func NewAccountsAdapter(args ArgsNewAccountsAdapter) (*accountsAdapter, error) {
+	if check.IfNil(args.Hasher) {
+		return nil, ErrNilHasher
+	}
+	if check.IfNil(args.EnableEpochsHandler) {
+		return nil, ErrNilEnableEpochsHandler
+	}
+
+	adapter := &accountsAdapter{
+		hasher:              args.Hasher,
+		enableEpochsHandler: args.EnableEpochsHandler,
+		accounts:            make(map[string]*userAccount),
+		codes:               make(map[string][]byte),
+		journal:             make([]journalEntry, 0),
+	}
+	adapter.lastRootHash = adapter.computeRootHash()
+
+	return adapter, nil
+}
+
+// GetExistingAccount returns a copy of the saved account. It errors if the account does not exist.
+func (adb *accountsAdapter) GetExistingAccount(address []byte) (vmcommon.AccountHandler, error) {
+	if len(address) == 0 {
+		return nil, ErrNilAddress
+	}
+
+	adb.mutOperation.RLock()
+	defer adb.mutOperation.RUnlock()
+
+	account, found := adb.accounts[string(address)]
+	if !found {
+		return nil, fmt.Errorf("%w for address %x", ErrAccNotFound, address)
+	}
+
+	return account.clone(), nil
+}
+
+// LoadAccount returns a copy of the saved account or a new, empty, account if it does not exist
+func (adb *accountsAdapter) LoadAccount(address []byte) (vmcommon.AccountHandler, error) {
+	if len(address) == 0 {
+		return nil, ErrNilAddress
+	}
+
+	adb.mutOperation.RLock()
+	defer adb.mutOperation.RUnlock()
+
+	account, found := adb.accounts[string(address)]
+	if !found {
+		return NewUserAccount(ArgsNewUserAccount{
+			Address:             address,
+			Hasher:              adb.hasher,
+			EnableEpochsHandler: adb.enableEpochsHandler,
+		})
+	}
+
+	return account.clone(), nil
+}
+
+// SaveAccount commits the account's data changes and stores a copy of the account
+func (adb *accountsAdapter) SaveAccount(account vmcommon.AccountHandler) error {
+	if check.IfNil(account) {
+		return ErrNilAccountHandler
+	}
+	userAcc, ok := account.(*userAccount)
+	if !ok {
+		return ErrWrongTypeAssertion
+	}
+
+	adb.mutOperation.Lock()
+	defer adb.mutOperation.Unlock()
+
+	userAcc.commitData()
+	if len(userAcc.codeHash) > 0 {
+		adb.saveCode(string(userAcc.codeHash), userAcc.code)
+	}
+
+	address := string(userAcc.address)
+	adb.journalAccountChange(address)
+	adb.accounts[address] = userAcc.clone()
+
+	return nil
+}
+
+// RemoveAccount removes the account from the state, if it exists
+func (adb *accountsAdapter) RemoveAccount(address []byte) error {
+	if len(address) == 0 {
+		return ErrNilAddress
+	}
+
+	adb.mutOperation.Lock()
+	defer adb.mutOperation.Unlock()
+
+	_, found := adb.accounts[string(address)]
+	if !found {
+		return nil
+	}
+
+	adb.journalAccountChange(string(address))
+	delete(adb.accounts, string(address))
+
+	return nil
+}
+
+func (adb *accountsAdapter) saveCode(codeHash string, code []byte) {
+	previous, found := adb.codes[codeHash]
+	if found && bytes.Equal(previous, code) {
+		return
+	}
+
+	adb.journal = append(adb.journal, &codeChangedEntry{
+		codeHash: codeHash,
+		previous: previous,
+	})
+	adb.codes[codeHash] = cloneBytes(code)
+}
+
+func (adb *accountsAdapter) journalAccountChange(address string) {
+	adb.journal = append(adb.journal, &accountChangedEntry{
+		address:  address,
+		previous: adb.accounts[address],
+	})
+}
+
+// JournalLen returns the number of entries in the journal
+func (adb *accountsAdapter) JournalLen() int {
+	adb.mutOperation.RLock()
+	defer adb.mutOperation.RUnlock()
+
+	return len(adb.journal)
+}
+
+// RevertToSnapshot reverts all the changes journaled after the provided snapshot. Snapshots can be nested,
+// reverting to an older snapshot also discards all the newer ones.
+func (adb *accountsAdapter) RevertToSnapshot(snapshot int) error {
+	adb.mutOperation.Lock()
+	defer adb.mutOperation.Unlock()
+
+	if snapshot < 0 || snapshot > len(adb.journal) {
+		return fmt.Errorf("%w: snapshot %d, journal length %d", ErrSnapshotValueOutOfBounds, snapshot, len(adb.journal))
+	}
+
+	for i := len(adb.journal) - 1; i >= snapshot; i-- {
+		adb.journal[i].revert(adb)
+	}
+	adb.journal = adb.journal[:snapshot]
+
+	return nil
+}
+
+// Commit clears the journal and returns the new state root hash
+func (adb *accountsAdapter) Commit() ([]byte, error) {
+	adb.mutOperation.Lock()
+	defer adb.mutOperation.Unlock()
+
+	adb.journal = make([]journalEntry, 0)
+	adb.lastRootHash = adb.computeRootHash()
+
+	return adb.lastRootHash, nil
+}
+
+// RootHash returns the root hash of the current state, including the uncommitted changes
+func (adb *accountsAdapter) RootHash() ([]byte, error) {
+	adb.mutOperation.RLock()
+	defer adb.mutOperation.RUnlock()
+
+	return adb.computeRootHash(), nil
+}
+
+// LastCommittedRootHash returns the root hash computed on the last commit
+func (adb *accountsAdapter) LastCommittedRootHash() []byte {
+	adb.mutOperation.RLock()
+	defer adb.mutOperation.RUnlock()
+
+	return adb.lastRootHash
+}
+
+// computeRootHash hashes all the accounts, sorted by address, so that the same state always yields the same root hash
+func (adb *accountsAdapter) computeRootHash() []byte {
+	addresses := make([]string, 0, len(adb.accounts))
+	for address := range adb.accounts {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	buff := make([]byte, 0)
+	for _, address := range addresses {
+		accountHash := adb.hasher.Compute(string(adb.accounts[address].serialize()))
+		buff = appendWithLength(buff, []byte(address))
+		buff = appendWithLength(buff, accountHash)
+	}
+
+	return adb.hasher.Compute(string(buff))
+}
+
+// GetCode returns the code saved under the provided code hash
+func (adb *accountsAdapter) GetCode(codeHash []byte) []byte {
+	adb.mutOperation.RLock()
+	defer adb.mutOperation.RUnlock()
+
+	return cloneBytes(adb.codes[string(codeHash)])
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (adb *accountsAdapter) IsInterfaceNil() bool {
+	return adb == nil
+}
@@ -0,0 +1,299 @@
+package state
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	"github.com/subrahamanyam341/andes-core-16/hashing/sha256"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+	"github.com/subrahamanyam341/andes-vm-common-123/mock"
+)
+
+func createAccountsAdapter() *accountsAdapter {
+	adapter, _ := NewAccountsAdapter(ArgsNewAccountsAdapter{
+		Hasher:              sha256.NewSha256(),
+		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+	})
+
+	return adapter
+}
+
+func loadUserAccount(t *testing.T, adapter vmcommon.AccountsAdapter, address []byte) *userAccount {
+	account, err := adapter.LoadAccount(address)
+	require.Nil(t, err)
+
+	return account.(*userAccount)
+}
+
+func saveAccountWithBalance(t *testing.T, adapter *accountsAdapter, address []byte, balance int64) {
+	account := loadUserAccount(t, adapter, address)
+	require.Nil(t, account.AddToBalance(big.NewInt(balance)))
+	require.Nil(t, adapter.SaveAccount(account))
+}
+
+func TestNewAccountsAdapter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil hasher should error", func(t *testing.T) {
+		t.Parallel()
+
+		adapter, err := NewAccountsAdapter(ArgsNewAccountsAdapter{EnableEpochsHandler: &mock.EnableEpochsHandlerStub{}})
+		assert.Equal(t, ErrNilHasher, err)
+		assert.True(t, check.IfNil(adapter))
+	})
+	t.Run("nil enable epochs handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		adapter, err := NewAccountsAdapter(ArgsNewAccountsAdapter{Hasher: sha256.NewSha256()})
+		assert.Equal(t, ErrNilEnableEpochsHandler, err)
+		assert.True(t, check.IfNil(adapter))
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		adapter, err := NewAccountsAdapter(ArgsNewAccountsAdapter{
+			Hasher:              sha256.NewSha256(),
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		})
+		assert.Nil(t, err)
+		assert.False(t, check.IfNil(adapter))
+		assert.Equal(t, 0, adapter.JournalLen())
+	})
+}
+
+func TestAccountsAdapter_LoadAndGetExistingAccount(t *testing.T) {
+	t.Parallel()
+
+	adapter := createAccountsAdapter()
+	address := []byte("address")
+
+	_, err := adapter.LoadAccount(nil)
+	assert.Equal(t, ErrNilAddress, err)
+
+	_, err = adapter.GetExistingAccount(address)
+	assert.True(t, errors.Is(err, ErrAccNotFound))
+
+	account := loadUserAccount(t, adapter, address)
+	assert.Equal(t, address, account.AddressBytes())
+	assert.Equal(t, big.NewInt(0), account.GetBalance())
+
+	// changes are not visible before saving the account
+	_ = account.AddToBalance(big.NewInt(10))
+	_, err = adapter.GetExistingAccount(address)
+	assert.True(t, errors.Is(err, ErrAccNotFound))
+
+	require.Nil(t, adapter.SaveAccount(account))
+	existing, err := adapter.GetExistingAccount(address)
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(10), existing.(*userAccount).GetBalance())
+
+	// the loaded account is a copy
+	_ = existing.(*userAccount).AddToBalance(big.NewInt(5))
+	reloaded := loadUserAccount(t, adapter, address)
+	assert.Equal(t, big.NewInt(10), reloaded.GetBalance())
+}
+
+func TestAccountsAdapter_SaveAccount(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil account should error", func(t *testing.T) {
+		t.Parallel()
+
+		adapter := createAccountsAdapter()
+		assert.Equal(t, ErrNilAccountHandler, adapter.SaveAccount(nil))
+	})
+	t.Run("wrong account type should error", func(t *testing.T) {
+		t.Parallel()
+
+		adapter := createAccountsAdapter()
+		assert.Equal(t, ErrWrongTypeAssertion, adapter.SaveAccount(mock.NewUserAccount([]byte("address"))))
+	})
+	t.Run("should commit data and save code", func(t *testing.T) {
+		t.Parallel()
+
+		adapter := createAccountsAdapter()
+		account := loadUserAccount(t, adapter, []byte("address"))
+		_ = account.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("value"))
+		account.SetCode([]byte("code"))
+		assert.Nil(t, account.GetRootHash())
+
+		require.Nil(t, adapter.SaveAccount(account))
+		assert.NotNil(t, account.GetRootHash())
+		assert.Equal(t, []byte("code"), adapter.GetCode(account.GetCodeHash()))
+
+		reloaded := loadUserAccount(t, adapter, []byte("address"))
+		value, _, err := reloaded.AccountDataHandler().RetrieveValue([]byte("key"))
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("value"), value)
+		assert.Equal(t, account.GetRootHash(), reloaded.GetRootHash())
+	})
+}
+
+func TestAccountsAdapter_RemoveAccount(t *testing.T) {
+	t.Parallel()
+
+	adapter := createAccountsAdapter()
+	address := []byte("address")
+	assert.Equal(t, ErrNilAddress, adapter.RemoveAccount(nil))
+	assert.Nil(t, adapter.RemoveAccount(address))
+	assert.Equal(t, 0, adapter.JournalLen())
+
+	saveAccountWithBalance(t, adapter, address, 10)
+	assert.Nil(t, adapter.RemoveAccount(address))
+	assert.Equal(t, 2, adapter.JournalLen())
+
+	_, err := adapter.GetExistingAccount(address)
+	assert.True(t, errors.Is(err, ErrAccNotFound))
+
+	assert.Nil(t, adapter.RevertToSnapshot(1))
+	existing, err := adapter.GetExistingAccount(address)
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(10), existing.(*userAccount).GetBalance())
+}
+
+func TestAccountsAdapter_RevertToSnapshot(t *testing.T) {
+	t.Parallel()
+
+	t.Run("out of bounds snapshot should error", func(t *testing.T) {
+		t.Parallel()
+
+		adapter := createAccountsAdapter()
+		err := adapter.RevertToSnapshot(1)
+		assert.True(t, errors.Is(err, ErrSnapshotValueOutOfBounds))
+		err = adapter.RevertToSnapshot(-1)
+		assert.True(t, errors.Is(err, ErrSnapshotValueOutOfBounds))
+	})
+	t.Run("nested snapshots should work", func(t *testing.T) {
+		t.Parallel()
+
+		adapter := createAccountsAdapter()
+		addr1 := []byte("address1")
+		addr2 := []byte("address2")
+
+		saveAccountWithBalance(t, adapter, addr1, 10)
+		outerSnapshot := adapter.JournalLen()
+		rootHashAtOuterSnapshot, _ := adapter.RootHash()
+
+		saveAccountWithBalance(t, adapter, addr1, 5)
+		innerSnapshot := adapter.JournalLen()
+		rootHashAtInnerSnapshot, _ := adapter.RootHash()
+
+		saveAccountWithBalance(t, adapter, addr2, 7)
+		assert.Equal(t, 3, adapter.JournalLen())
+
+		require.Nil(t, adapter.RevertToSnapshot(innerSnapshot))
+		_, err := adapter.GetExistingAccount(addr2)
+		assert.True(t, errors.Is(err, ErrAccNotFound))
+		assert.Equal(t, big.NewInt(15), loadUserAccount(t, adapter, addr1).GetBalance())
+		rootHash, _ := adapter.RootHash()
+		assert.Equal(t, rootHashAtInnerSnapshot, rootHash)
+
+		require.Nil(t, adapter.RevertToSnapshot(outerSnapshot))
+		assert.Equal(t, big.NewInt(10), loadUserAccount(t, adapter, addr1).GetBalance())
+		rootHash, _ = adapter.RootHash()
+		assert.Equal(t, rootHashAtOuterSnapshot, rootHash)
+
+		require.Nil(t, adapter.RevertToSnapshot(0))
+		_, err = adapter.GetExistingAccount(addr1)
+		assert.True(t, errors.Is(err, ErrAccNotFound))
+	})
+	t.Run("data trie changes should be reverted", func(t *testing.T) {
+		t.Parallel()
+
+		adapter := createAccountsAdapter()
+		address := []byte("address")
+		account := loadUserAccount(t, adapter, address)
+		_ = account.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("value1"))
+		require.Nil(t, adapter.SaveAccount(account))
+
+		snapshot := adapter.JournalLen()
+		account = loadUserAccount(t, adapter, address)
+		_ = account.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("value2"))
+		require.Nil(t, adapter.SaveAccount(account))
+
+		require.Nil(t, adapter.RevertToSnapshot(snapshot))
+		value, _, _ := loadUserAccount(t, adapter, address).AccountDataHandler().RetrieveValue([]byte("key"))
+		assert.Equal(t, []byte("value1"), value)
+	})
+	t.Run("contract deploy should be reverted", func(t *testing.T) {
+		t.Parallel()
+
+		adapter := createAccountsAdapter()
+		address := []byte("contract")
+		snapshot := adapter.JournalLen()
+
+		account := loadUserAccount(t, adapter, address)
+		account.SetCode([]byte("code"))
+		require.Nil(t, adapter.SaveAccount(account))
+		codeHash := account.GetCodeHash()
+		require.Equal(t, []byte("code"), adapter.GetCode(codeHash))
+
+		require.Nil(t, adapter.RevertToSnapshot(snapshot))
+		_, err := adapter.GetExistingAccount(address)
+		assert.True(t, errors.Is(err, ErrAccNotFound))
+		assert.Empty(t, adapter.GetCode(codeHash))
+	})
+	t.Run("saving the same code again should not be journaled", func(t *testing.T) {
+		t.Parallel()
+
+		adapter := createAccountsAdapter()
+		account := loadUserAccount(t, adapter, []byte("contract"))
+		account.SetCode([]byte("code"))
+		require.Nil(t, adapter.SaveAccount(account))
+		assert.Equal(t, 2, adapter.JournalLen())
+
+		snapshot := adapter.JournalLen()
+		require.Nil(t, adapter.SaveAccount(loadUserAccount(t, adapter, []byte("contract"))))
+		assert.Equal(t, 3, adapter.JournalLen())
+
+		require.Nil(t, adapter.RevertToSnapshot(snapshot))
+		assert.Equal(t, []byte("code"), adapter.GetCode(account.GetCodeHash()))
+	})
+}
+
+func TestAccountsAdapter_CommitAndRootHash(t *testing.T) {
+	t.Parallel()
+
+	t.Run("commit should clear the journal", func(t *testing.T) {
+		t.Parallel()
+
+		adapter := createAccountsAdapter()
+		emptyRootHash := adapter.LastCommittedRootHash()
+		saveAccountWithBalance(t, adapter, []byte("address"), 10)
+
+		rootHash, _ := adapter.RootHash()
+		assert.NotEqual(t, emptyRootHash, rootHash)
+		assert.Equal(t, emptyRootHash, adapter.LastCommittedRootHash())
+
+		committedRootHash, err := adapter.Commit()
+		assert.Nil(t, err)
+		assert.Equal(t, rootHash, committedRootHash)
+		assert.Equal(t, committedRootHash, adapter.LastCommittedRootHash())
+		assert.Equal(t, 0, adapter.JournalLen())
+		assert.True(t, errors.Is(adapter.RevertToSnapshot(1), ErrSnapshotValueOutOfBounds))
+	})
+	t.Run("root hash should not depend on the order of operations", func(t *testing.T) {
+		t.Parallel()
+
+		adapter1 := createAccountsAdapter()
+		saveAccountWithBalance(t, adapter1, []byte("address1"), 10)
+		saveAccountWithBalance(t, adapter1, []byte("address2"), 20)
+
+		adapter2 := createAccountsAdapter()
+		saveAccountWithBalance(t, adapter2, []byte("address2"), 20)
+		saveAccountWithBalance(t, adapter2, []byte("address1"), 5)
+		saveAccountWithBalance(t, adapter2, []byte("address1"), 5)
+
+		rootHash1, _ := adapter1.Commit()
+		rootHash2, _ := adapter2.Commit()
+		assert.Equal(t, rootHash1, rootHash2)
+
+		saveAccountWithBalance(t, adapter2, []byte("address1"), 1)
+		rootHash2, _ = adapter2.Commit()
+		assert.NotEqual(t, rootHash1, rootHash2)
+	})
+}
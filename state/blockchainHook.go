@@ -0,0 +1,433 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/subrahamanyam341/andes-core-16/core"
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	"github.com/subrahamanyam341/andes-core-16/data/dct"
+	"github.com/subrahamanyam341/andes-core-16/hashing"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+)
+
+var _ vmcommon.BlockchainHook = (*blockchainHook)(nil)
+
+// BlockInfo holds the header fields exposed by the blockchain hook for a block
+type BlockInfo struct {
+	Hash       []byte
+	Nonce      uint64
+	Round      uint64
+	TimeStamp  uint64
+	RandomSeed []byte
+	Epoch      uint32
+	RootHash   []byte
+}
+
+// ArgsNewBlockchainHook is the argument structure used to create a new blockchain hook
+type ArgsNewBlockchainHook struct {
+	Accounts                  vmcommon.AccountsAdapter
+	ShardCoordinator          vmcommon.Coordinator
+	BuiltInFunctions          vmcommon.BuiltInFunctionContainer
+	BuiltInFunctionsProcessor vmcommon.BuiltInFunctionsProcessor
+	NFTStorageHandler         vmcommon.SimpleDCTNFTStorageHandler
+	GlobalSettingsHandler     vmcommon.DCTGlobalSettingsHandler
+	Hasher                    hashing.Hasher
+}
+
+// blockchainHook is a reference blockchain hook implementation that works on top of an accounts adapter.
+// The block information is not read from a chain, it is set from outside through SetCurrentBlockInfo and SetLastBlockInfo.
+type blockchainHook struct {
+	accounts                  vmcommon.AccountsAdapter
+	shardCoordinator          vmcommon.Coordinator
+	builtInFunctions          vmcommon.BuiltInFunctionContainer
+	nftStorageHandler         vmcommon.SimpleDCTNFTStorageHandler
+	globalSettingsHandler     vmcommon.DCTGlobalSettingsHandler
+	hasher                    hashing.Hasher
+	builtInFunctionsProcessor vmcommon.BuiltInFunctionsProcessor
+
+	mutBlockInfo sync.RWMutex
+	currentBlock BlockInfo
+	lastBlock    BlockInfo
+	blockHashes  map[uint64][]byte
+
+	mutCompiledCode sync.RWMutex
+	compiledCodes   map[string][]byte
+}
+
+// NewBlockchainHook creates a new blockchain hook
+func NewBlockchainHook(args ArgsNewBlockchainHook) (*blockchainHook, error) {
+	if check.IfNil(args.Accounts) {
+		return nil, ErrNilAccountsAdapter
+	}
+	if check.IfNil(args.ShardCoordinator) {
+		return nil, ErrNilShardCoordinator
+	}
+	if check.IfNil(args.BuiltInFunctions) {
+		return nil, ErrNilBuiltInFunctionsContainer
+	}
+	if check.IfNil(args.BuiltInFunctionsProcessor) {
+		return nil, ErrNilBuiltInFunctionsProcessor
+	}
+	if check.IfNil(args.NFTStorageHandler) {
+		return nil, ErrNilDCTNFTStorageHandler
+	}
+	if check.IfNil(args.GlobalSettingsHandler) {
+		return nil, ErrNilGlobalSettingsHandler
+	}
+	if check.IfNil(args.Hasher) {
+		return nil, ErrNilHasher
+	}
+
+	return &blockchainHook{
+		accounts:                  args.Accounts,
+		shardCoordinator:          args.ShardCoordinator,
+		builtInFunctions:          args.BuiltInFunctions,
+		nftStorageHandler:         args.NFTStorageHandler,
+		globalSettingsHandler:     args.GlobalSettingsHandler,
+		hasher:                    args.Hasher,
+		builtInFunctionsProcessor: args.BuiltInFunctionsProcessor,
+		blockHashes:               make(map[uint64][]byte),
+		compiledCodes:             make(map[string][]byte),
+	}, nil
+}
+
+// SetCurrentBlockInfo sets the information of the block being processed
+func (bh *blockchainHook) SetCurrentBlockInfo(info BlockInfo) {
+	bh.mutBlockInfo.Lock()
+	bh.currentBlock = cloneBlockInfo(info)
+	bh.mutBlockInfo.Unlock()
+}
+
+// SetLastBlockInfo sets the information of the last committed block. Its hash is remembered and can be later
+// retrieved through GetBlockhash.
+func (bh *blockchainHook) SetLastBlockInfo(info BlockInfo) {
+	bh.mutBlockInfo.Lock()
+	bh.lastBlock = cloneBlockInfo(info)
+	bh.blockHashes[info.Nonce] = cloneBytes(info.Hash)
+	bh.mutBlockInfo.Unlock()
+}
+
+func cloneBlockInfo(info BlockInfo) BlockInfo {
+	return BlockInfo{
+		Hash:       cloneBytes(info.Hash),
+		Nonce:      info.Nonce,
+		Round:      info.Round,
+		TimeStamp:  info.TimeStamp,
+		RandomSeed: cloneBytes(info.RandomSeed),
+		Epoch:      info.Epoch,
+		RootHash:   cloneBytes(info.RootHash),
+	}
+}
+
+// NewAddress computes the address of a new smart contract from the creator address and nonce, as defined by
+// vmcommon.ComputeSmartContractAddress
+func (bh *blockchainHook) NewAddress(creatorAddress []byte, creatorNonce uint64, vmType []byte) ([]byte, error) {
+	return vmcommon.ComputeSmartContractAddress(bh.hasher, creatorAddress, creatorNonce, vmType)
+}
+
+// GetStorageData returns the value saved under the provided key in the account's data trie, along with the
+// depth of the leaf. A missing account yields an empty value.
+func (bh *blockchainHook) GetStorageData(accountAddress []byte, index []byte) ([]byte, uint32, error) {
+	userAcc, err := bh.GetUserAccount(accountAddress)
+	if errors.Is(err, ErrAccNotFound) {
+		return make([]byte, 0), 0, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return userAcc.AccountDataHandler().RetrieveValue(index)
+}
+
+// GetBlockhash returns the hash of the block with the provided nonce
+func (bh *blockchainHook) GetBlockhash(nonce uint64) ([]byte, error) {
+	bh.mutBlockInfo.RLock()
+	defer bh.mutBlockInfo.RUnlock()
+
+	if nonce > bh.currentBlock.Nonce {
+		return nil, fmt.Errorf("%w: requested %d, current %d", ErrInvalidNonce, nonce, bh.currentBlock.Nonce)
+	}
+	if nonce == bh.currentBlock.Nonce && len(bh.currentBlock.Hash) > 0 {
+		return cloneBytes(bh.currentBlock.Hash), nil
+	}
+
+	hash, found := bh.blockHashes[nonce]
+	if !found {
+		return nil, fmt.Errorf("%w for nonce %d", ErrBlockHashNotFound, nonce)
+	}
+
+	return cloneBytes(hash), nil
+}
+
+// LastNonce returns the nonce of the last committed block
+func (bh *blockchainHook) LastNonce() uint64 {
+	bh.mutBlockInfo.RLock()
+	defer bh.mutBlockInfo.RUnlock()
+
+	return bh.lastBlock.Nonce
+}
+
+// LastRound returns the round of the last committed block
+func (bh *blockchainHook) LastRound() uint64 {
+	bh.mutBlockInfo.RLock()
+	defer bh.mutBlockInfo.RUnlock()
+
+	return bh.lastBlock.Round
+}
+
+// LastTimeStamp returns the timestamp of the last committed block
+func (bh *blockchainHook) LastTimeStamp() uint64 {
+	bh.mutBlockInfo.RLock()
+	defer bh.mutBlockInfo.RUnlock()
+
+	return bh.lastBlock.TimeStamp
+}
+
+// LastRandomSeed returns the random seed of the last committed block
+func (bh *blockchainHook) LastRandomSeed() []byte {
+	bh.mutBlockInfo.RLock()
+	defer bh.mutBlockInfo.RUnlock()
+
+	return cloneBytes(bh.lastBlock.RandomSeed)
+}
+
+// LastEpoch returns the epoch of the last committed block
+func (bh *blockchainHook) LastEpoch() uint32 {
+	bh.mutBlockInfo.RLock()
+	defer bh.mutBlockInfo.RUnlock()
+
+	return bh.lastBlock.Epoch
+}
+
+// GetStateRootHash returns the state root hash of the last committed block
+func (bh *blockchainHook) GetStateRootHash() []byte {
+	bh.mutBlockInfo.RLock()
+	defer bh.mutBlockInfo.RUnlock()
+
+	return cloneBytes(bh.lastBlock.RootHash)
+}
+
+// CurrentNonce returns the nonce of the current block
+func (bh *blockchainHook) CurrentNonce() uint64 {
+	bh.mutBlockInfo.RLock()
+	defer bh.mutBlockInfo.RUnlock()
+
+	return bh.currentBlock.Nonce
+}
+
+// CurrentRound returns the round of the current block
+func (bh *blockchainHook) CurrentRound() uint64 {
+	bh.mutBlockInfo.RLock()
+	defer bh.mutBlockInfo.RUnlock()
+
+	return bh.currentBlock.Round
+}
+
+// CurrentTimeStamp returns the timestamp of the current block
+func (bh *blockchainHook) CurrentTimeStamp() uint64 {
+	bh.mutBlockInfo.RLock()
+	defer bh.mutBlockInfo.RUnlock()
+
+	return bh.currentBlock.TimeStamp
+}
+
+// CurrentRandomSeed returns the random seed of the current block
+func (bh *blockchainHook) CurrentRandomSeed() []byte {
+	bh.mutBlockInfo.RLock()
+	defer bh.mutBlockInfo.RUnlock()
+
+	return cloneBytes(bh.currentBlock.RandomSeed)
+}
+
+// CurrentEpoch returns the epoch of the current block
+func (bh *blockchainHook) CurrentEpoch() uint32 {
+	bh.mutBlockInfo.RLock()
+	defer bh.mutBlockInfo.RUnlock()
+
+	return bh.currentBlock.Epoch
+}
+
+// ProcessBuiltInFunction runs the built-in function through the built-in functions processor, which loads the
+// accounts found in the self shard, saves them back and reverts them if the call fails. A nil account is passed
+// to the function for an address that belongs to another shard.
+func (bh *blockchainHook) ProcessBuiltInFunction(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	if input == nil {
+		return nil, ErrNilVmInput
+	}
+	if input.CallValue == nil {
+		return nil, ErrNilValue
+	}
+
+	return bh.builtInFunctionsProcessor.ProcessBuiltInFunction(input)
+}
+
+func (bh *blockchainHook) isInSelfShard(address []byte) bool {
+	return bh.shardCoordinator.ComputeId(address) == bh.shardCoordinator.SelfId()
+}
+
+// GetBuiltinFunctionNames returns the names of the functions found in the built-in functions container
+func (bh *blockchainHook) GetBuiltinFunctionNames() vmcommon.FunctionNames {
+	return bh.builtInFunctions.Keys()
+}
+
+// GetAllState returns all the key-value pairs saved in the account's data trie
+func (bh *blockchainHook) GetAllState(address []byte) (map[string][]byte, error) {
+	account, err := bh.accounts.GetExistingAccount(address)
+	if err != nil {
+		return nil, err
+	}
+
+	userAcc, ok := account.(*userAccount)
+	if !ok {
+		return nil, ErrWrongTypeAssertion
+	}
+
+	allState := make(map[string][]byte, len(userAcc.dataTrie.leaves))
+	for key, leaf := range userAcc.dataTrie.leaves {
+		allState[key] = cloneBytes(leaf.Value)
+	}
+
+	return allState, nil
+}
+
+// GetUserAccount returns the existing user account for the provided address
+func (bh *blockchainHook) GetUserAccount(address []byte) (vmcommon.UserAccountHandler, error) {
+	account, err := bh.accounts.GetExistingAccount(address)
+	if err != nil {
+		return nil, err
+	}
+
+	userAcc, ok := account.(vmcommon.UserAccountHandler)
+	if !ok {
+		return nil, ErrWrongTypeAssertion
+	}
+
+	return userAcc, nil
+}
+
+// GetCode returns the code of the provided account
+func (bh *blockchainHook) GetCode(account vmcommon.UserAccountHandler) []byte {
+	if check.IfNil(account) {
+		return nil
+	}
+
+	return bh.accounts.GetCode(account.GetCodeHash())
+}
+
+// GetShardOfAddress returns the shard of the provided address
+func (bh *blockchainHook) GetShardOfAddress(address []byte) uint32 {
+	return bh.shardCoordinator.ComputeId(address)
+}
+
+// IsSmartContract returns true if the provided address is a smart contract address
+func (bh *blockchainHook) IsSmartContract(address []byte) bool {
+	return vmcommon.IsSmartContractAddress(address)
+}
+
+// IsPayable returns true if the receiver can accept MOA. Only contracts in the self shard are checked, the
+// other addresses are considered payable.
+func (bh *blockchainHook) IsPayable(sndAddress []byte, recvAddress []byte) (bool, error) {
+	if !bh.IsSmartContract(recvAddress) {
+		return true, nil
+	}
+	if !bh.isInSelfShard(recvAddress) {
+		return true, nil
+	}
+
+	userAcc, err := bh.GetUserAccount(recvAddress)
+	if errors.Is(err, ErrAccNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	metadata := vmcommon.CodeMetadataFromBytes(userAcc.GetCodeMetadata())
+	if metadata.Payable {
+		return true, nil
+	}
+
+	return metadata.PayableBySC && bh.IsSmartContract(sndAddress), nil
+}
+
+// SaveCompiledCode keeps the compiled code in memory
+func (bh *blockchainHook) SaveCompiledCode(codeHash []byte, code []byte) {
+	bh.mutCompiledCode.Lock()
+	bh.compiledCodes[string(codeHash)] = cloneBytes(code)
+	bh.mutCompiledCode.Unlock()
+}
+
+// GetCompiledCode returns the compiled code saved under the provided code hash
+func (bh *blockchainHook) GetCompiledCode(codeHash []byte) (bool, []byte) {
+	bh.mutCompiledCode.RLock()
+	defer bh.mutCompiledCode.RUnlock()
+
+	code, found := bh.compiledCodes[string(codeHash)]
+
+	return found, cloneBytes(code)
+}
+
+// ClearCompiledCodes removes all the compiled codes
+func (bh *blockchainHook) ClearCompiledCodes() {
+	bh.mutCompiledCode.Lock()
+	bh.compiledCodes = make(map[string][]byte)
+	bh.mutCompiledCode.Unlock()
+}
+
+// GetDCTToken returns the DCT token held by the account, read through the nft storage handler. A missing
+// account yields an empty fungible token.
+func (bh *blockchainHook) GetDCTToken(address []byte, tokenID []byte, nonce uint64) (*dct.DCToken, error) {
+	dctData := &dct.DCToken{
+		Value: big.NewInt(0),
+		Type:  uint32(core.Fungible),
+	}
+
+	userAcc, err := bh.GetUserAccount(address)
+	if errors.Is(err, ErrAccNotFound) {
+		return dctData, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	dctData, _, err = bh.nftStorageHandler.GetDCTNFTTokenOnDestination(userAcc, createDCTTokenKey(tokenID), nonce)
+
+	return dctData, err
+}
+
+// IsPaused returns true if the token is globally paused
+func (bh *blockchainHook) IsPaused(tokenID []byte) bool {
+	return bh.globalSettingsHandler.IsPaused(createDCTTokenKey(tokenID))
+}
+
+// IsLimitedTransfer returns true if the token has limited transfers
+func (bh *blockchainHook) IsLimitedTransfer(tokenID []byte) bool {
+	return bh.globalSettingsHandler.IsLimitedTransfer(createDCTTokenKey(tokenID))
+}
+
+func createDCTTokenKey(tokenID []byte) []byte {
+	return []byte(core.ProtectedKeyPrefix + core.DCTKeyIdentifier + string(tokenID))
+}
+
+// GetSnapshot returns the current length of the accounts journal
+func (bh *blockchainHook) GetSnapshot() int {
+	return bh.accounts.JournalLen()
+}
+
+// RevertToSnapshot reverts the accounts journal to the provided snapshot
+func (bh *blockchainHook) RevertToSnapshot(snapshot int) error {
+	return bh.accounts.RevertToSnapshot(snapshot)
+}
+
+// ExecuteSmartContractCallOnOtherVM is not supported, as the blockchain hook does not hold any VM
+func (bh *blockchainHook) ExecuteSmartContractCallOnOtherVM(_ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	return nil, ErrOperationNotSupported
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (bh *blockchainHook) IsInterfaceNil() bool {
+	return bh == nil
+}
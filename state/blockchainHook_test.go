@@ -0,0 +1,490 @@
+package state
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core"
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	"github.com/subrahamanyam341/andes-core-16/data/dct"
+	"github.com/subrahamanyam341/andes-core-16/hashing/sha256"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+	"github.com/subrahamanyam341/andes-vm-common-123/builtInFunctions"
+	"github.com/subrahamanyam341/andes-vm-common-123/mock"
+)
+
+func createMockBlockchainHookArgs() ArgsNewBlockchainHook {
+	args := ArgsNewBlockchainHook{
+		Accounts:              createAccountsAdapter(),
+		ShardCoordinator:      mock.NewMultiShardsCoordinatorMock(1),
+		BuiltInFunctions:      builtInFunctions.NewBuiltInFunctionContainer(),
+		NFTStorageHandler:     &mock.DCTNFTStorageHandlerStub{},
+		GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+		Hasher:                sha256.NewSha256(),
+	}
+	setBuiltInFunctionsProcessor(&args)
+
+	return args
+}
+
+// setBuiltInFunctionsProcessor creates the processor on top of the accounts, shard coordinator and container from args
+func setBuiltInFunctionsProcessor(args *ArgsNewBlockchainHook) {
+	args.BuiltInFunctionsProcessor, _ = builtInFunctions.NewBuiltInFunctionsProcessor(builtInFunctions.ArgsNewBuiltInFunctionsProcessor{
+		Accounts:         args.Accounts,
+		ShardCoordinator: args.ShardCoordinator,
+		BuiltInFunctions: args.BuiltInFunctions,
+	})
+}
+
+func TestNewBlockchainHook(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil accounts should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockBlockchainHookArgs()
+		args.Accounts = nil
+		bh, err := NewBlockchainHook(args)
+		assert.Nil(t, bh)
+		assert.Equal(t, ErrNilAccountsAdapter, err)
+	})
+	t.Run("nil shard coordinator should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockBlockchainHookArgs()
+		args.ShardCoordinator = nil
+		bh, err := NewBlockchainHook(args)
+		assert.Nil(t, bh)
+		assert.Equal(t, ErrNilShardCoordinator, err)
+	})
+	t.Run("nil built-in functions container should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockBlockchainHookArgs()
+		args.BuiltInFunctions = nil
+		bh, err := NewBlockchainHook(args)
+		assert.Nil(t, bh)
+		assert.Equal(t, ErrNilBuiltInFunctionsContainer, err)
+	})
+	t.Run("nil built-in functions processor should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockBlockchainHookArgs()
+		args.BuiltInFunctionsProcessor = nil
+		bh, err := NewBlockchainHook(args)
+		assert.Nil(t, bh)
+		assert.Equal(t, ErrNilBuiltInFunctionsProcessor, err)
+	})
+	t.Run("nil nft storage handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockBlockchainHookArgs()
+		args.NFTStorageHandler = nil
+		bh, err := NewBlockchainHook(args)
+		assert.Nil(t, bh)
+		assert.Equal(t, ErrNilDCTNFTStorageHandler, err)
+	})
+	t.Run("nil global settings handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockBlockchainHookArgs()
+		args.GlobalSettingsHandler = nil
+		bh, err := NewBlockchainHook(args)
+		assert.Nil(t, bh)
+		assert.Equal(t, ErrNilGlobalSettingsHandler, err)
+	})
+	t.Run("nil hasher should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockBlockchainHookArgs()
+		args.Hasher = nil
+		bh, err := NewBlockchainHook(args)
+		assert.Nil(t, bh)
+		assert.Equal(t, ErrNilHasher, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		bh, err := NewBlockchainHook(createMockBlockchainHookArgs())
+		assert.Nil(t, err)
+		assert.False(t, check.IfNil(bh))
+	})
+}
+
+func TestBlockchainHook_BlockInfo(t *testing.T) {
+	t.Parallel()
+
+	bh, _ := NewBlockchainHook(createMockBlockchainHookArgs())
+	bh.SetLastBlockInfo(BlockInfo{
+		Hash:       []byte("hash9"),
+		Nonce:      9,
+		Round:      11,
+		TimeStamp:  100,
+		RandomSeed: []byte("seed9"),
+		Epoch:      2,
+		RootHash:   []byte("root9"),
+	})
+	bh.SetCurrentBlockInfo(BlockInfo{
+		Hash:       []byte("hash10"),
+		Nonce:      10,
+		Round:      12,
+		TimeStamp:  106,
+		RandomSeed: []byte("seed10"),
+		Epoch:      3,
+	})
+
+	assert.Equal(t, uint64(9), bh.LastNonce())
+	assert.Equal(t, uint64(11), bh.LastRound())
+	assert.Equal(t, uint64(100), bh.LastTimeStamp())
+	assert.Equal(t, []byte("seed9"), bh.LastRandomSeed())
+	assert.Equal(t, uint32(2), bh.LastEpoch())
+	assert.Equal(t, []byte("root9"), bh.GetStateRootHash())
+	assert.Equal(t, uint64(10), bh.CurrentNonce())
+	assert.Equal(t, uint64(12), bh.CurrentRound())
+	assert.Equal(t, uint64(106), bh.CurrentTimeStamp())
+	assert.Equal(t, []byte("seed10"), bh.CurrentRandomSeed())
+	assert.Equal(t, uint32(3), bh.CurrentEpoch())
+
+	hash, err := bh.GetBlockhash(10)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("hash10"), hash)
+
+	hash, err = bh.GetBlockhash(9)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("hash9"), hash)
+
+	_, err = bh.GetBlockhash(11)
+	assert.True(t, errors.Is(err, ErrInvalidNonce))
+
+	_, err = bh.GetBlockhash(5)
+	assert.True(t, errors.Is(err, ErrBlockHashNotFound))
+}
+
+func TestBlockchainHook_NewAddress(t *testing.T) {
+	t.Parallel()
+
+	bh, _ := NewBlockchainHook(createMockBlockchainHookArgs())
+	creator := bytes.Repeat([]byte{7}, 32)
+	vmType := []byte{5, 0}
+
+	_, err := bh.NewAddress(creator[:5], 0, vmType)
+	assert.Equal(t, ErrAddressLengthNotCorrect, err)
+
+	_, err = bh.NewAddress(creator, 0, []byte{5})
+	assert.Equal(t, ErrVMTypeLengthIsNotCorrect, err)
+
+	address, err := bh.NewAddress(creator, 1, vmType)
+	require.Nil(t, err)
+	assert.Equal(t, 32, len(address))
+	assert.True(t, vmcommon.IsSmartContractAddress(address))
+	assert.Equal(t, vmType, address[vmcommon.NumInitCharactersForScAddress-vmcommon.VMTypeLen:vmcommon.NumInitCharactersForScAddress])
+	assert.Equal(t, creator[30:], address[30:])
+
+	otherAddress, _ := bh.NewAddress(creator, 2, vmType)
+	assert.NotEqual(t, address, otherAddress)
+}
+
+func TestBlockchainHook_StorageAndAccounts(t *testing.T) {
+	t.Parallel()
+
+	args := createMockBlockchainHookArgs()
+	bh, _ := NewBlockchainHook(args)
+	address := bytes.Repeat([]byte{1}, 32)
+
+	value, depth, err := bh.GetStorageData(address, []byte("key"))
+	assert.Nil(t, err)
+	assert.Equal(t, make([]byte, 0), value)
+	assert.Equal(t, uint32(0), depth)
+
+	_, err = bh.GetAllState(address)
+	assert.True(t, errors.Is(err, ErrAccNotFound))
+
+	account := loadUserAccount(t, args.Accounts, address)
+	_ = account.AccountDataHandler().SaveKeyValue([]byte("key1"), []byte("value1"))
+	_ = account.AccountDataHandler().SaveKeyValue([]byte("key2"), []byte("value2"))
+	account.SetCode([]byte("code"))
+	require.Nil(t, args.Accounts.SaveAccount(account))
+
+	value, depth, err = bh.GetStorageData(address, []byte("key1"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value1"), value)
+	assert.True(t, depth > 0)
+
+	allState, err := bh.GetAllState(address)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]byte{"key1": []byte("value1"), "key2": []byte("value2")}, allState)
+
+	userAcc, err := bh.GetUserAccount(address)
+	require.Nil(t, err)
+	assert.Equal(t, []byte("code"), bh.GetCode(userAcc))
+	assert.Nil(t, bh.GetCode(nil))
+}
+
+func TestBlockchainHook_IsPayable(t *testing.T) {
+	t.Parallel()
+
+	args := createMockBlockchainHookArgs()
+	bh, _ := NewBlockchainHook(args)
+	userAddress := bytes.Repeat([]byte{1}, 32)
+	scAddress := append(make([]byte, vmcommon.NumInitCharactersForScAddress), bytes.Repeat([]byte{1}, 22)...)
+
+	isPayable, err := bh.IsPayable(userAddress, userAddress)
+	assert.Nil(t, err)
+	assert.True(t, isPayable)
+
+	isPayable, err = bh.IsPayable(userAddress, scAddress)
+	assert.Nil(t, err)
+	assert.False(t, isPayable)
+
+	account := loadUserAccount(t, args.Accounts, scAddress)
+	account.SetCodeMetadata([]byte{0, vmcommon.MetadataPayableBySC})
+	require.Nil(t, args.Accounts.SaveAccount(account))
+
+	isPayable, _ = bh.IsPayable(userAddress, scAddress)
+	assert.False(t, isPayable)
+	isPayable, _ = bh.IsPayable(scAddress, scAddress)
+	assert.True(t, isPayable)
+
+	account.SetCodeMetadata([]byte{0, vmcommon.MetadataPayable})
+	require.Nil(t, args.Accounts.SaveAccount(account))
+	isPayable, _ = bh.IsPayable(userAddress, scAddress)
+	assert.True(t, isPayable)
+}
+
+func TestBlockchainHook_CompiledCode(t *testing.T) {
+	t.Parallel()
+
+	bh, _ := NewBlockchainHook(createMockBlockchainHookArgs())
+	found, _ := bh.GetCompiledCode([]byte("hash"))
+	assert.False(t, found)
+
+	bh.SaveCompiledCode([]byte("hash"), []byte("compiled"))
+	found, code := bh.GetCompiledCode([]byte("hash"))
+	assert.True(t, found)
+	assert.Equal(t, []byte("compiled"), code)
+
+	bh.ClearCompiledCodes()
+	found, _ = bh.GetCompiledCode([]byte("hash"))
+	assert.False(t, found)
+}
+
+func TestBlockchainHook_DCTQueries(t *testing.T) {
+	t.Parallel()
+
+	tokenID := []byte("TOKEN-abcdef")
+	expectedKey := []byte(core.ProtectedKeyPrefix + core.DCTKeyIdentifier + "TOKEN-abcdef")
+	address := bytes.Repeat([]byte{1}, 32)
+
+	args := createMockBlockchainHookArgs()
+	args.GlobalSettingsHandler = &mock.GlobalSettingsHandlerStub{
+		IsPausedCalled: func(token []byte) bool {
+			return bytes.Equal(token, expectedKey)
+		},
+		IsLimiterTransferCalled: func(token []byte) bool {
+			return bytes.Equal(token, expectedKey)
+		},
+	}
+	args.NFTStorageHandler = &mock.DCTNFTStorageHandlerStub{
+		GetDCTNFTTokenOnDestinationCalled: func(acnt vmcommon.UserAccountHandler, dctTokenKey []byte, nonce uint64) (*dct.DCToken, bool, error) {
+			assert.Equal(t, address, acnt.AddressBytes())
+			assert.Equal(t, expectedKey, dctTokenKey)
+			return &dct.DCToken{Value: big.NewInt(int64(nonce))}, false, nil
+		},
+	}
+	bh, _ := NewBlockchainHook(args)
+
+	assert.True(t, bh.IsPaused(tokenID))
+	assert.True(t, bh.IsLimitedTransfer(tokenID))
+	assert.False(t, bh.IsPaused([]byte("OTHER-abcdef")))
+
+	token, err := bh.GetDCTToken(address, tokenID, 5)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(0), token.Value)
+	assert.Equal(t, uint32(core.Fungible), token.Type)
+
+	require.Nil(t, args.Accounts.SaveAccount(loadUserAccount(t, args.Accounts, address)))
+	token, err = bh.GetDCTToken(address, tokenID, 5)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(5), token.Value)
+}
+
+func TestBlockchainHook_ProcessBuiltInFunction(t *testing.T) {
+	t.Parallel()
+
+	sender := bytes.Repeat([]byte{1}, 32)
+	receiver := bytes.Repeat([]byte{2}, 32)
+	createInput := func(function string, recipient []byte) *vmcommon.ContractCallInput {
+		return &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr: sender,
+				CallValue:  big.NewInt(0),
+			},
+			RecipientAddr: recipient,
+			Function:      function,
+		}
+	}
+
+	t.Run("invalid input should error", func(t *testing.T) {
+		t.Parallel()
+
+		bh, _ := NewBlockchainHook(createMockBlockchainHookArgs())
+		_, err := bh.ProcessBuiltInFunction(nil)
+		assert.Equal(t, ErrNilVmInput, err)
+
+		input := createInput("func", receiver)
+		input.CallValue = nil
+		_, err = bh.ProcessBuiltInFunction(input)
+		assert.Equal(t, ErrNilValue, err)
+
+		_, err = bh.ProcessBuiltInFunction(createInput("missing", receiver))
+		assert.True(t, errors.Is(err, builtInFunctions.ErrInvalidContainerKey))
+	})
+	t.Run("inactive function should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockBlockchainHookArgs()
+		_ = args.BuiltInFunctions.Add("func", &mock.BuiltInFunctionStub{
+			IsActiveCalled: func() bool {
+				return false
+			},
+			ProcessBuiltinFunctionCalled: func(_, _ vmcommon.UserAccountHandler, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				assert.Fail(t, "should not have been called")
+				return nil, nil
+			},
+		})
+		bh, _ := NewBlockchainHook(args)
+
+		_, err := bh.ProcessBuiltInFunction(createInput("func", receiver))
+		assert.Equal(t, builtInFunctions.ErrBuiltInFunctionIsNotActive, err)
+	})
+	t.Run("failed save should revert the accounts", func(t *testing.T) {
+		t.Parallel()
+
+		adapter := createAccountsAdapter()
+		expectedErr := errors.New("expected error")
+		args := createMockBlockchainHookArgs()
+		args.Accounts = &mock.AccountsStub{
+			LoadAccountCalled: adapter.LoadAccount,
+			SaveAccountCalled: func(account vmcommon.AccountHandler) error {
+				if bytes.Equal(account.AddressBytes(), receiver) {
+					return expectedErr
+				}
+				return adapter.SaveAccount(account)
+			},
+			JournalLenCalled:       adapter.JournalLen,
+			RevertToSnapshotCalled: adapter.RevertToSnapshot,
+		}
+		setBuiltInFunctionsProcessor(&args)
+		_ = args.BuiltInFunctions.Add("func", &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				_ = acntSnd.AddToBalance(big.NewInt(10))
+				_ = acntDst.AddToBalance(big.NewInt(20))
+				return &vmcommon.VMOutput{}, nil
+			},
+		})
+		bh, _ := NewBlockchainHook(args)
+
+		_, err := bh.ProcessBuiltInFunction(createInput("func", receiver))
+		assert.Equal(t, expectedErr, err)
+		assert.Equal(t, 0, adapter.JournalLen())
+		assert.Equal(t, big.NewInt(0), loadUserAccount(t, adapter, sender).GetBalance())
+	})
+	t.Run("function error should not save the accounts", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockBlockchainHookArgs()
+		expectedErr := errors.New("expected error")
+		_ = args.BuiltInFunctions.Add("func", &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				_ = acntSnd.AddToBalance(big.NewInt(10))
+				return nil, expectedErr
+			},
+		})
+		bh, _ := NewBlockchainHook(args)
+
+		_, err := bh.ProcessBuiltInFunction(createInput("func", receiver))
+		assert.Equal(t, expectedErr, err)
+		assert.Equal(t, 0, bh.GetSnapshot())
+	})
+	t.Run("should save both accounts in self shard", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockBlockchainHookArgs()
+		_ = args.BuiltInFunctions.Add("func", &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				_ = acntSnd.AddToBalance(big.NewInt(10))
+				_ = acntDst.AddToBalance(big.NewInt(20))
+				return &vmcommon.VMOutput{ReturnCode: vmcommon.Ok}, nil
+			},
+		})
+		bh, _ := NewBlockchainHook(args)
+
+		vmOutput, err := bh.ProcessBuiltInFunction(createInput("func", receiver))
+		require.Nil(t, err)
+		assert.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+		assert.Equal(t, big.NewInt(10), loadUserAccount(t, args.Accounts, sender).GetBalance())
+		assert.Equal(t, big.NewInt(20), loadUserAccount(t, args.Accounts, receiver).GetBalance())
+
+		require.Nil(t, bh.RevertToSnapshot(0))
+		assert.Equal(t, big.NewInt(0), loadUserAccount(t, args.Accounts, sender).GetBalance())
+	})
+	t.Run("same sender and receiver should be loaded once", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockBlockchainHookArgs()
+		_ = args.BuiltInFunctions.Add("func", &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				assert.True(t, acntSnd == acntDst)
+				_ = acntSnd.AddToBalance(big.NewInt(10))
+				_ = acntDst.AddToBalance(big.NewInt(20))
+				return &vmcommon.VMOutput{}, nil
+			},
+		})
+		bh, _ := NewBlockchainHook(args)
+
+		_, err := bh.ProcessBuiltInFunction(createInput("func", sender))
+		require.Nil(t, err)
+		assert.Equal(t, big.NewInt(30), loadUserAccount(t, args.Accounts, sender).GetBalance())
+		assert.Equal(t, 1, bh.GetSnapshot())
+	})
+	t.Run("accounts from other shards should be nil", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockBlockchainHookArgs()
+		args.ShardCoordinator = &mock.ShardCoordinatorStub{
+			ComputeIdCalled: func(address []byte) uint32 {
+				if bytes.Equal(address, receiver) {
+					return 1
+				}
+				return 0
+			},
+		}
+		setBuiltInFunctionsProcessor(&args)
+		_ = args.BuiltInFunctions.Add("func", &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				assert.False(t, check.IfNil(acntSnd))
+				assert.True(t, check.IfNil(acntDst))
+				return &vmcommon.VMOutput{}, nil
+			},
+		})
+		bh, _ := NewBlockchainHook(args)
+
+		_, err := bh.ProcessBuiltInFunction(createInput("func", receiver))
+		require.Nil(t, err)
+		assert.Equal(t, uint32(1), bh.GetShardOfAddress(receiver))
+		assert.Equal(t, 1, bh.GetSnapshot())
+	})
+}
+
+func TestBlockchainHook_ExecuteSmartContractCallOnOtherVM(t *testing.T) {
+	t.Parallel()
+
+	bh, _ := NewBlockchainHook(createMockBlockchainHookArgs())
+	vmOutput, err := bh.ExecuteSmartContractCallOnOtherVM(&vmcommon.ContractCallInput{})
+	assert.Nil(t, vmOutput)
+	assert.Equal(t, ErrOperationNotSupported, err)
+}
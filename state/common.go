@@ -0,0 +1,42 @@
+package state
+
+import (
+	"encoding/binary"
+	"math/big"
+)
+
+func cloneBytes(data []byte) []byte {
+	if data == nil {
+		return nil
+	}
+
+	clone := make([]byte, len(data))
+	copy(clone, data)
+
+	return clone
+}
+
+func cloneBigInt(value *big.Int) *big.Int {
+	if value == nil {
+		return big.NewInt(0)
+	}
+
+	return big.NewInt(0).Set(value)
+}
+
+// appendWithLength appends the data prefixed by its length, so that the concatenation of
+// several fields can not be ambiguous
+func appendWithLength(buff []byte, data []byte) []byte {
+	lenBuff := make([]byte, 4)
+	binary.BigEndian.PutUint32(lenBuff, uint32(len(data)))
+
+	buff = append(buff, lenBuff...)
+	return append(buff, data...)
+}
+
+func appendUint64(buff []byte, value uint64) []byte {
+	valueBuff := make([]byte, 8)
+	binary.BigEndian.PutUint64(valueBuff, value)
+
+	return append(buff, valueBuff...)
+}
@@ -0,0 +1,269 @@
+package state
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/subrahamanyam341/andes-core-16/core"
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	"github.com/subrahamanyam341/andes-core-16/hashing"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+)
+
+var _ vmcommon.AccountDataHandler = (*dataTrie)(nil)
+
+// dataTrie is an in-memory key-value store that keeps the uncommitted changes apart from
+// the committed leaves, the same way a trackable data trie does on top of a patricia merkle trie.
+// Every leaf keeps its trie node version: leaves with the AutoBalanceEnabled version are placed in the
+// trie under the hash of their key, while the NotSpecified ones are placed under the key itself.
+type dataTrie struct {
+	leaves              map[string]core.TrieData
+	dirtyData           map[string]core.TrieData
+	hasher              hashing.Hasher
+	enableEpochsHandler vmcommon.EnableEpochsHandler
+}
+
+func newDataTrie(hasher hashing.Hasher, enableEpochsHandler vmcommon.EnableEpochsHandler) *dataTrie {
+	return &dataTrie{
+		leaves:              make(map[string]core.TrieData),
+		dirtyData:           make(map[string]core.TrieData),
+		hasher:              hasher,
+		enableEpochsHandler: enableEpochsHandler,
+	}
+}
+
+// RetrieveValue returns the value saved under the provided key and the depth of the leaf in the trie.
+// The dirty data has priority over the committed leaves and, as it is not yet part of the trie, it has a zero depth.
+func (dt *dataTrie) RetrieveValue(key []byte) ([]byte, uint32, error) {
+	leaf, found := dt.dirtyData[string(key)]
+	if found {
+		return cloneBytes(leaf.Value), 0, nil
+	}
+
+	leaf, found = dt.leaves[string(key)]
+	if !found {
+		return nil, 0, nil
+	}
+
+	return cloneBytes(leaf.Value), dt.computeDepth(leaf), nil
+}
+
+// SaveKeyValue saves the value under the provided key. An empty value marks the key for removal.
+// The leaf will be saved with the trie node version used for new data in the current epoch.
+func (dt *dataTrie) SaveKeyValue(key []byte, value []byte) error {
+	dt.dirtyData[string(key)] = core.TrieData{
+		Key:     cloneBytes(key),
+		Value:   cloneBytes(value),
+		Version: core.GetVersionForNewData(dt.enableEpochsHandler),
+	}
+
+	return nil
+}
+
+// MigrateDataTrieLeaves walks the committed leaves in trie order and hands the ones that have the old version
+// to the trie migrator. It stops when the migrator runs out of gas. The leaves selected by the migrator are
+// rewritten with the new version as dirty data, to be committed on the next account save.
+func (dt *dataTrie) MigrateDataTrieLeaves(args vmcommon.ArgsMigrateDataTrieLeaves) error {
+	if check.IfNil(args.TrieMigrator) {
+		return ErrNilTrieMigrator
+	}
+
+	for _, leaf := range dt.sortedLeaves() {
+		shouldContinue := args.TrieMigrator.ConsumeStorageLoadGas()
+		if !shouldContinue {
+			break
+		}
+		if leaf.Version != args.OldVersion {
+			continue
+		}
+
+		shouldContinue, err := args.TrieMigrator.AddLeafToMigrationQueue(cloneTrieData(leaf), args.NewVersion)
+		if err != nil {
+			return err
+		}
+		if !shouldContinue {
+			break
+		}
+	}
+
+	for _, leaf := range args.TrieMigrator.GetLeavesToBeMigrated() {
+		_, isDirty := dt.dirtyData[string(leaf.Key)]
+		if isDirty {
+			continue
+		}
+
+		migratedLeaf := cloneTrieData(leaf)
+		migratedLeaf.Version = args.NewVersion
+		dt.dirtyData[string(leaf.Key)] = migratedLeaf
+	}
+
+	return nil
+}
+
+// GetLeafVersion returns the trie node version of the leaf saved under the provided key
+func (dt *dataTrie) GetLeafVersion(key []byte) (core.TrieNodeVersion, bool) {
+	leaf, found := dt.dirtyData[string(key)]
+	if !found {
+		leaf, found = dt.leaves[string(key)]
+	}
+	if !found || len(leaf.Value) == 0 {
+		return core.NotSpecified, false
+	}
+
+	return leaf.Version, true
+}
+
+// commit moves the dirty data into the leaves, removing the keys with empty values
+func (dt *dataTrie) commit() {
+	for key, leaf := range dt.dirtyData {
+		if len(leaf.Value) == 0 {
+			delete(dt.leaves, key)
+			continue
+		}
+
+		dt.leaves[key] = leaf
+	}
+
+	dt.dirtyData = make(map[string]core.TrieData)
+}
+
+// rootHash computes the hash over the committed leaves, in trie order. An empty data trie has a nil root hash.
+func (dt *dataTrie) rootHash() []byte {
+	if len(dt.leaves) == 0 {
+		return nil
+	}
+
+	buff := make([]byte, 0)
+	for _, leaf := range dt.sortedLeaves() {
+		buff = appendWithLength(buff, leaf.Key)
+		buff = appendWithLength(buff, leaf.Value)
+		buff = append(buff, byte(leaf.Version))
+	}
+
+	return dt.hasher.Compute(string(buff))
+}
+
+// leafPath returns the path of the leaf in the trie, as a slice of nibbles
+func (dt *dataTrie) leafPath(leaf core.TrieData) []byte {
+	key := leaf.Key
+	if leaf.Version == core.AutoBalanceEnabled {
+		key = dt.hasher.Compute(string(leaf.Key))
+	}
+
+	return keyBytesToNibbles(key)
+}
+
+func (dt *dataTrie) sortedLeaves() []core.TrieData {
+	leaves := make([]core.TrieData, 0, len(dt.leaves))
+	paths := make(map[string][]byte, len(dt.leaves))
+	for key, leaf := range dt.leaves {
+		leaves = append(leaves, leaf)
+		paths[key] = dt.leafPath(leaf)
+	}
+
+	sort.Slice(leaves, func(i, j int) bool {
+		return bytes.Compare(paths[string(leaves[i].Key)], paths[string(leaves[j].Key)]) < 0
+	})
+
+	return leaves
+}
+
+// computeDepth returns the number of nodes that have to be loaded from the root in order to reach the leaf,
+// as in a patricia merkle trie made of branch, extension and leaf nodes
+func (dt *dataTrie) computeDepth(leaf core.TrieData) uint32 {
+	path := dt.leafPath(leaf)
+	otherPaths := make([][]byte, 0, len(dt.leaves))
+	for key, otherLeaf := range dt.leaves {
+		if key == string(leaf.Key) {
+			continue
+		}
+		otherPaths = append(otherPaths, dt.leafPath(otherLeaf))
+	}
+
+	depth := uint32(0)
+	position := 0
+	for len(otherPaths) > 0 {
+		commonPrefixLen := longestCommonPrefixLen(path[position:], otherPaths, position)
+		if commonPrefixLen > 0 {
+			// extension node
+			depth++
+			position += commonPrefixLen
+		}
+
+		// branch node
+		depth++
+		if position >= len(path) {
+			break
+		}
+
+		otherPaths = filterPathsByNibble(otherPaths, position, path[position])
+		position++
+	}
+
+	// leaf node
+	return depth + 1
+}
+
+func longestCommonPrefixLen(path []byte, otherPaths [][]byte, offset int) int {
+	prefixLen := len(path)
+	for _, otherPath := range otherPaths {
+		current := 0
+		for current < prefixLen && offset+current < len(otherPath) && otherPath[offset+current] == path[current] {
+			current++
+		}
+		prefixLen = current
+	}
+
+	return prefixLen
+}
+
+func filterPathsByNibble(paths [][]byte, position int, nibble byte) [][]byte {
+	filtered := make([][]byte, 0, len(paths))
+	for _, path := range paths {
+		if position < len(path) && path[position] == nibble {
+			filtered = append(filtered, path)
+		}
+	}
+
+	return filtered
+}
+
+func keyBytesToNibbles(key []byte) []byte {
+	nibbles := make([]byte, 0, len(key)*2)
+	for _, b := range key {
+		nibbles = append(nibbles, b>>4, b&0x0f)
+	}
+
+	return nibbles
+}
+
+func cloneTrieData(leaf core.TrieData) core.TrieData {
+	return core.TrieData{
+		Key:     cloneBytes(leaf.Key),
+		Value:   cloneBytes(leaf.Value),
+		Version: leaf.Version,
+	}
+}
+
+func cloneTrieDataMap(data map[string]core.TrieData) map[string]core.TrieData {
+	clone := make(map[string]core.TrieData, len(data))
+	for key, leaf := range data {
+		clone[key] = cloneTrieData(leaf)
+	}
+
+	return clone
+}
+
+func (dt *dataTrie) clone() *dataTrie {
+	return &dataTrie{
+		leaves:              cloneTrieDataMap(dt.leaves),
+		dirtyData:           cloneTrieDataMap(dt.dirtyData),
+		hasher:              dt.hasher,
+		enableEpochsHandler: dt.enableEpochsHandler,
+	}
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (dt *dataTrie) IsInterfaceNil() bool {
+	return dt == nil
+}
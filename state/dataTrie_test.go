@@ -0,0 +1,245 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core"
+	"github.com/subrahamanyam341/andes-core-16/hashing/sha256"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+	"github.com/subrahamanyam341/andes-vm-common-123/dataTrieMigrator"
+	"github.com/subrahamanyam341/andes-vm-common-123/mock"
+)
+
+func createDataTrie(autoBalanceEnabled bool) (*dataTrie, *mock.EnableEpochsHandlerStub) {
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{
+		IsAutoBalanceDataTriesEnabledField: autoBalanceEnabled,
+	}
+
+	return newDataTrie(sha256.NewSha256(), enableEpochsHandler), enableEpochsHandler
+}
+
+func TestDataTrie_SaveAndRetrieve(t *testing.T) {
+	t.Parallel()
+
+	dt, _ := createDataTrie(false)
+	value, depth, err := dt.RetrieveValue([]byte("key"))
+	assert.Nil(t, err)
+	assert.Nil(t, value)
+	assert.Equal(t, uint32(0), depth)
+
+	_ = dt.SaveKeyValue([]byte("key"), []byte("value"))
+	value, depth, _ = dt.RetrieveValue([]byte("key"))
+	assert.Equal(t, []byte("value"), value)
+	assert.Equal(t, uint32(0), depth)
+	assert.Equal(t, 0, len(dt.leaves))
+
+	dt.commit()
+	assert.Equal(t, 0, len(dt.dirtyData))
+	value, depth, _ = dt.RetrieveValue([]byte("key"))
+	assert.Equal(t, []byte("value"), value)
+	assert.Equal(t, uint32(1), depth)
+
+	_ = dt.SaveKeyValue([]byte("key"), nil)
+	dt.commit()
+	_, found := dt.leaves["key"]
+	assert.False(t, found)
+}
+
+func TestDataTrie_LeafVersions(t *testing.T) {
+	t.Parallel()
+
+	dt, enableEpochsHandler := createDataTrie(false)
+	_ = dt.SaveKeyValue([]byte("old"), []byte("value"))
+
+	enableEpochsHandler.IsAutoBalanceDataTriesEnabledField = true
+	_ = dt.SaveKeyValue([]byte("new"), []byte("value"))
+	dt.commit()
+
+	version, found := dt.GetLeafVersion([]byte("old"))
+	assert.True(t, found)
+	assert.Equal(t, core.NotSpecified, version)
+
+	version, found = dt.GetLeafVersion([]byte("new"))
+	assert.True(t, found)
+	assert.Equal(t, core.AutoBalanceEnabled, version)
+
+	_, found = dt.GetLeafVersion([]byte("missing"))
+	assert.False(t, found)
+
+	// rewriting a leaf after the activation upgrades its version
+	_ = dt.SaveKeyValue([]byte("old"), []byte("value2"))
+	version, _ = dt.GetLeafVersion([]byte("old"))
+	assert.Equal(t, core.AutoBalanceEnabled, version)
+}
+
+func TestDataTrie_RetrieveValueDepth(t *testing.T) {
+	t.Parallel()
+
+	dt, _ := createDataTrie(false)
+	// nibbles: 6 1 | 6 2 | 6 3 ...
+	_ = dt.SaveKeyValue([]byte("a"), []byte("1"))
+	_ = dt.SaveKeyValue([]byte("b"), []byte("2"))
+	_ = dt.SaveKeyValue([]byte("q"), []byte("3"))
+	dt.commit()
+
+	// root extension (6) -> branch -> leaf for "a" and "b", "q" is under a different first nibble (7)
+	_, depth, _ := dt.RetrieveValue([]byte("q"))
+	assert.Equal(t, uint32(2), depth)
+	_, depth, _ = dt.RetrieveValue([]byte("a"))
+	assert.Equal(t, uint32(3), depth)
+	_, depth, _ = dt.RetrieveValue([]byte("b"))
+	assert.Equal(t, uint32(3), depth)
+
+	// the depth grows with the number of leaves
+	for i := 0; i < 100; i++ {
+		_ = dt.SaveKeyValue([]byte(fmt.Sprintf("a%d", i)), []byte("value"))
+	}
+	dt.commit()
+	_, depth, _ = dt.RetrieveValue([]byte("a1"))
+	assert.True(t, depth > 3)
+}
+
+func TestDataTrie_RootHash(t *testing.T) {
+	t.Parallel()
+
+	dt1, _ := createDataTrie(false)
+	assert.Nil(t, dt1.rootHash())
+
+	_ = dt1.SaveKeyValue([]byte("a"), []byte("1"))
+	_ = dt1.SaveKeyValue([]byte("b"), []byte("2"))
+	dt1.commit()
+
+	dt2, _ := createDataTrie(false)
+	_ = dt2.SaveKeyValue([]byte("b"), []byte("2"))
+	dt2.commit()
+	_ = dt2.SaveKeyValue([]byte("a"), []byte("1"))
+	dt2.commit()
+	assert.Equal(t, dt1.rootHash(), dt2.rootHash())
+
+	// same data, different version
+	dt3, _ := createDataTrie(true)
+	_ = dt3.SaveKeyValue([]byte("a"), []byte("1"))
+	_ = dt3.SaveKeyValue([]byte("b"), []byte("2"))
+	dt3.commit()
+	assert.NotEqual(t, dt1.rootHash(), dt3.rootHash())
+}
+
+func TestDataTrie_MigrateDataTrieLeaves(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil trie migrator should error", func(t *testing.T) {
+		t.Parallel()
+
+		dt, _ := createDataTrie(false)
+		err := dt.MigrateDataTrieLeaves(vmcommon.ArgsMigrateDataTrieLeaves{})
+		assert.Equal(t, ErrNilTrieMigrator, err)
+	})
+	t.Run("migrator error should be returned", func(t *testing.T) {
+		t.Parallel()
+
+		dt, _ := createDataTrie(false)
+		_ = dt.SaveKeyValue([]byte("key"), []byte("value"))
+		dt.commit()
+
+		expectedErr := errors.New("expected error")
+		err := dt.MigrateDataTrieLeaves(vmcommon.ArgsMigrateDataTrieLeaves{
+			OldVersion: core.NotSpecified,
+			NewVersion: core.AutoBalanceEnabled,
+			TrieMigrator: &mock.DataTrieMigratorStub{
+				AddLeafToMigrationQueueCalled: func(_ core.TrieData, _ core.TrieNodeVersion) (bool, error) {
+					return false, expectedErr
+				},
+			},
+		})
+		assert.Equal(t, expectedErr, err)
+	})
+	t.Run("should migrate all leaves", func(t *testing.T) {
+		t.Parallel()
+
+		dt, _ := createDataTrie(false)
+		for i := 0; i < 10; i++ {
+			_ = dt.SaveKeyValue([]byte(fmt.Sprintf("key%d", i)), []byte("value"))
+		}
+		dt.commit()
+		rootHashBefore := dt.rootHash()
+
+		migrator := dataTrieMigrator.NewDataTrieMigrator(dataTrieMigrator.ArgsNewDataTrieMigrator{
+			GasProvided: 1000,
+			DataTrieGasCost: dataTrieMigrator.DataTrieGasCost{
+				TrieLoadPerNode:  1,
+				TrieStorePerNode: 1,
+			},
+		})
+		err := dt.MigrateDataTrieLeaves(vmcommon.ArgsMigrateDataTrieLeaves{
+			OldVersion:   core.NotSpecified,
+			NewVersion:   core.AutoBalanceEnabled,
+			TrieMigrator: migrator,
+		})
+		require.Nil(t, err)
+		assert.Equal(t, 10, len(migrator.GetLeavesToBeMigrated()))
+		assert.Equal(t, uint64(980), migrator.GetGasRemaining())
+
+		dt.commit()
+		for i := 0; i < 10; i++ {
+			version, _ := dt.GetLeafVersion([]byte(fmt.Sprintf("key%d", i)))
+			assert.Equal(t, core.AutoBalanceEnabled, version)
+			value, _, _ := dt.RetrieveValue([]byte(fmt.Sprintf("key%d", i)))
+			assert.Equal(t, []byte("value"), value)
+		}
+		assert.NotEqual(t, rootHashBefore, dt.rootHash())
+	})
+	t.Run("should stop when running out of gas", func(t *testing.T) {
+		t.Parallel()
+
+		dt, _ := createDataTrie(false)
+		for i := 0; i < 10; i++ {
+			_ = dt.SaveKeyValue([]byte(fmt.Sprintf("key%d", i)), []byte("value"))
+		}
+		dt.commit()
+
+		migrator := dataTrieMigrator.NewDataTrieMigrator(dataTrieMigrator.ArgsNewDataTrieMigrator{
+			GasProvided: 10,
+			DataTrieGasCost: dataTrieMigrator.DataTrieGasCost{
+				TrieLoadPerNode:  1,
+				TrieStorePerNode: 1,
+			},
+		})
+		err := dt.MigrateDataTrieLeaves(vmcommon.ArgsMigrateDataTrieLeaves{
+			OldVersion:   core.NotSpecified,
+			NewVersion:   core.AutoBalanceEnabled,
+			TrieMigrator: migrator,
+		})
+		require.Nil(t, err)
+
+		numMigrated := len(migrator.GetLeavesToBeMigrated())
+		assert.True(t, numMigrated > 0 && numMigrated < 10)
+		assert.Equal(t, numMigrated, len(dt.dirtyData))
+	})
+	t.Run("already migrated leaves should be skipped", func(t *testing.T) {
+		t.Parallel()
+
+		dt, _ := createDataTrie(true)
+		_ = dt.SaveKeyValue([]byte("key"), []byte("value"))
+		dt.commit()
+
+		migrator := dataTrieMigrator.NewDataTrieMigrator(dataTrieMigrator.ArgsNewDataTrieMigrator{
+			GasProvided: 1000,
+			DataTrieGasCost: dataTrieMigrator.DataTrieGasCost{
+				TrieLoadPerNode:  1,
+				TrieStorePerNode: 1,
+			},
+		})
+		err := dt.MigrateDataTrieLeaves(vmcommon.ArgsMigrateDataTrieLeaves{
+			OldVersion:   core.NotSpecified,
+			NewVersion:   core.AutoBalanceEnabled,
+			TrieMigrator: migrator,
+		})
+		require.Nil(t, err)
+		assert.Equal(t, 0, len(migrator.GetLeavesToBeMigrated()))
+		assert.Equal(t, 0, len(dt.dirtyData))
+	})
+}
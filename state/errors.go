@@ -0,0 +1,79 @@
+package state
+
+import (
+	"errors"
+
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+)
+
+// ErrNilHasher signals that a nil hasher has been provided
+var ErrNilHasher = errors.New("nil hasher")
+
+// ErrNilAddress signals that an operation has been attempted with a nil or empty address
+var ErrNilAddress = errors.New("nil address")
+
+// ErrNilAccountHandler signals that a nil account handler has been provided
+var ErrNilAccountHandler = errors.New("nil account handler")
+
+// ErrWrongTypeAssertion signals that a type assertion failed
+var ErrWrongTypeAssertion = errors.New("wrong type assertion")
+
+// ErrAccNotFound signals that the account was not found for the provided address
+var ErrAccNotFound = errors.New("account was not found")
+
+// ErrSnapshotValueOutOfBounds signals that the snapshot value is out of bounds
+var ErrSnapshotValueOutOfBounds = errors.New("snapshot value out of bounds")
+
+// ErrNilValue signals that a nil value has been provided
+var ErrNilValue = errors.New("nil value")
+
+// ErrInsufficientFunds signals that the balance is insufficient for the requested operation
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+// ErrOperationNotPermitted signals that the operation is not permitted
+var ErrOperationNotPermitted = errors.New("operation in account not permitted")
+
+// ErrInvalidAddressLength signals that the provided address has an invalid length
+var ErrInvalidAddressLength = errors.New("invalid address length")
+
+// ErrNilEnableEpochsHandler signals that a nil enable epochs handler has been provided
+var ErrNilEnableEpochsHandler = errors.New("nil enable epochs handler")
+
+// ErrNilTrieMigrator signals that a nil trie migrator has been provided
+var ErrNilTrieMigrator = errors.New("nil trie migrator")
+
+// ErrNilAccountsAdapter signals that a nil accounts adapter has been provided
+var ErrNilAccountsAdapter = errors.New("nil accounts adapter")
+
+// ErrNilShardCoordinator signals that a nil shard coordinator has been provided
+var ErrNilShardCoordinator = errors.New("nil shard coordinator")
+
+// ErrNilBuiltInFunctionsContainer signals that a nil built-in functions container has been provided
+var ErrNilBuiltInFunctionsContainer = errors.New("nil built-in functions container")
+
+// ErrNilBuiltInFunctionsProcessor signals that a nil built-in functions processor has been provided
+var ErrNilBuiltInFunctionsProcessor = errors.New("nil built-in functions processor")
+
+// ErrNilDCTNFTStorageHandler signals that a nil dct nft storage handler has been provided
+var ErrNilDCTNFTStorageHandler = errors.New("nil dct nft storage handler")
+
+// ErrNilGlobalSettingsHandler signals that a nil global settings handler has been provided
+var ErrNilGlobalSettingsHandler = errors.New("nil global settings handler")
+
+// ErrNilVmInput signals that a nil vm input has been provided
+var ErrNilVmInput = errors.New("nil vm input")
+
+// ErrInvalidNonce signals that the requested nonce is higher than the current block nonce
+var ErrInvalidNonce = errors.New("invalid nonce")
+
+// ErrBlockHashNotFound signals that the hash of the requested block is not known
+var ErrBlockHashNotFound = errors.New("block hash not found")
+
+// ErrAddressLengthNotCorrect signals that the provided address has an incorrect length
+var ErrAddressLengthNotCorrect = vmcommon.ErrInvalidAddressLength
+
+// ErrVMTypeLengthIsNotCorrect signals that the provided vm type has an incorrect length
+var ErrVMTypeLengthIsNotCorrect = vmcommon.ErrInvalidVMTypeLength
+
+// ErrOperationNotSupported signals that the operation is not supported by the in-memory blockchain hook
+var ErrOperationNotSupported = errors.New("operation not supported")
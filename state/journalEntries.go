@@ -0,0 +1,38 @@
+package state
+
+// journalEntry is a reversible change applied on the accounts adapter
+type journalEntry interface {
+	revert(adb *accountsAdapter)
+}
+
+// accountChangedEntry holds the state of an account before it was saved or removed.
+// A nil previous state means that the account did not exist.
+type accountChangedEntry struct {
+	address  string
+	previous *userAccount
+}
+
+func (entry *accountChangedEntry) revert(adb *accountsAdapter) {
+	if entry.previous == nil {
+		delete(adb.accounts, entry.address)
+		return
+	}
+
+	adb.accounts[entry.address] = entry.previous
+}
+
+// codeChangedEntry holds the code stored under a code hash before it was saved.
+// A nil previous code means that there was no code under that hash.
+type codeChangedEntry struct {
+	codeHash string
+	previous []byte
+}
+
+func (entry *codeChangedEntry) revert(adb *accountsAdapter) {
+	if entry.previous == nil {
+		delete(adb.codes, entry.codeHash)
+		return
+	}
+
+	adb.codes[entry.codeHash] = entry.previous
+}
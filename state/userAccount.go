@@ -0,0 +1,234 @@
+package state
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	"github.com/subrahamanyam341/andes-core-16/hashing"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-123"
+)
+
+var _ vmcommon.UserAccountHandler = (*userAccount)(nil)
+
+type userAccount struct {
+	address         []byte
+	nonce           uint64
+	balance         *big.Int
+	developerReward *big.Int
+	code            []byte
+	codeHash        []byte
+	codeMetadata    []byte
+	rootHash        []byte
+	ownerAddress    []byte
+	userName        []byte
+
+	hasher   hashing.Hasher
+	dataTrie *dataTrie
+}
+
+// ArgsNewUserAccount is the argument structure used to create a new in-memory user account
+type ArgsNewUserAccount struct {
+	Address             []byte
+	Hasher              hashing.Hasher
+	EnableEpochsHandler vmcommon.EnableEpochsHandler
+}
+
+// NewUserAccount creates a new, empty, in-memory user account
+func NewUserAccount(args ArgsNewUserAccount) (*userAccount, error) {
+	if len(args.Address) == 0 {
+		return nil, ErrNilAddress
+	}
+	if check.IfNil(args.Hasher) {
+		return nil, ErrNilHasher
+	}
+	if check.IfNil(args.EnableEpochsHandler) {
+		return nil, ErrNilEnableEpochsHandler
+	}
+
+	return &userAccount{
+		address:         cloneBytes(args.Address),
+		balance:         big.NewInt(0),
+		developerReward: big.NewInt(0),
+		hasher:          args.Hasher,
+		dataTrie:        newDataTrie(args.Hasher, args.EnableEpochsHandler),
+	}, nil
+}
+
+// AddressBytes returns the address of the account
+func (ua *userAccount) AddressBytes() []byte {
+	return ua.address
+}
+
+// IncreaseNonce adds the provided value to the current nonce
+func (ua *userAccount) IncreaseNonce(value uint64) {
+	ua.nonce += value
+}
+
+// GetNonce returns the account's nonce
+func (ua *userAccount) GetNonce() uint64 {
+	return ua.nonce
+}
+
+// AddToBalance adds the provided value to the balance. It errors if the resulting balance is negative.
+func (ua *userAccount) AddToBalance(value *big.Int) error {
+	if value == nil {
+		return ErrNilValue
+	}
+
+	newBalance := big.NewInt(0).Add(ua.balance, value)
+	if newBalance.Sign() < 0 {
+		return ErrInsufficientFunds
+	}
+
+	ua.balance = newBalance
+	return nil
+}
+
+// GetBalance returns the account's balance
+func (ua *userAccount) GetBalance() *big.Int {
+	return big.NewInt(0).Set(ua.balance)
+}
+
+// AddToDeveloperReward adds the provided value to the developer reward
+func (ua *userAccount) AddToDeveloperReward(value *big.Int) {
+	if value == nil {
+		return
+	}
+
+	ua.developerReward = big.NewInt(0).Add(ua.developerReward, value)
+}
+
+// ClaimDeveloperRewards returns the accumulated developer rewards and resets them. Only the owner can claim them.
+func (ua *userAccount) ClaimDeveloperRewards(sender []byte) (*big.Int, error) {
+	if !bytes.Equal(sender, ua.ownerAddress) {
+		return nil, ErrOperationNotPermitted
+	}
+
+	oldValue := ua.developerReward
+	ua.developerReward = big.NewInt(0)
+
+	return oldValue, nil
+}
+
+// GetDeveloperReward returns the accumulated developer reward
+func (ua *userAccount) GetDeveloperReward() *big.Int {
+	return big.NewInt(0).Set(ua.developerReward)
+}
+
+// ChangeOwnerAddress sets the new owner address. Only the current owner can do this.
+func (ua *userAccount) ChangeOwnerAddress(sender []byte, newAddress []byte) error {
+	if !bytes.Equal(sender, ua.ownerAddress) {
+		return ErrOperationNotPermitted
+	}
+	if len(newAddress) != len(ua.address) {
+		return ErrInvalidAddressLength
+	}
+
+	ua.ownerAddress = cloneBytes(newAddress)
+
+	return nil
+}
+
+// SetOwnerAddress sets the owner address without any check
+func (ua *userAccount) SetOwnerAddress(address []byte) {
+	ua.ownerAddress = cloneBytes(address)
+}
+
+// GetOwnerAddress returns the owner address
+func (ua *userAccount) GetOwnerAddress() []byte {
+	return ua.ownerAddress
+}
+
+// SetUserName sets the user name
+func (ua *userAccount) SetUserName(userName []byte) {
+	ua.userName = cloneBytes(userName)
+}
+
+// GetUserName returns the user name
+func (ua *userAccount) GetUserName() []byte {
+	return ua.userName
+}
+
+// SetCode sets the code of the account, also updating the code hash
+func (ua *userAccount) SetCode(code []byte) {
+	ua.code = cloneBytes(code)
+	ua.codeHash = nil
+	if len(code) > 0 {
+		ua.codeHash = ua.hasher.Compute(string(code))
+	}
+}
+
+// GetCode returns the code of the account
+func (ua *userAccount) GetCode() []byte {
+	return ua.code
+}
+
+// GetCodeHash returns the code hash
+func (ua *userAccount) GetCodeHash() []byte {
+	return ua.codeHash
+}
+
+// SetCodeMetadata sets the code metadata
+func (ua *userAccount) SetCodeMetadata(codeMetadata []byte) {
+	ua.codeMetadata = cloneBytes(codeMetadata)
+}
+
+// GetCodeMetadata returns the code metadata
+func (ua *userAccount) GetCodeMetadata() []byte {
+	return ua.codeMetadata
+}
+
+// GetRootHash returns the root hash of the data trie, as computed on the last account save
+func (ua *userAccount) GetRootHash() []byte {
+	return ua.rootHash
+}
+
+// AccountDataHandler returns the handler of the account's data trie
+func (ua *userAccount) AccountDataHandler() vmcommon.AccountDataHandler {
+	return ua.dataTrie
+}
+
+// commitData applies the dirty data on the data trie and recomputes the root hash
+func (ua *userAccount) commitData() {
+	ua.dataTrie.commit()
+	ua.rootHash = ua.dataTrie.rootHash()
+}
+
+// serialize returns a canonical encoding of the account fields, used when computing the state root hash
+func (ua *userAccount) serialize() []byte {
+	buff := make([]byte, 0)
+	buff = appendWithLength(buff, ua.address)
+	buff = appendUint64(buff, ua.nonce)
+	buff = appendWithLength(buff, ua.balance.Bytes())
+	buff = appendWithLength(buff, ua.developerReward.Bytes())
+	buff = appendWithLength(buff, ua.codeHash)
+	buff = appendWithLength(buff, ua.codeMetadata)
+	buff = appendWithLength(buff, ua.rootHash)
+	buff = appendWithLength(buff, ua.ownerAddress)
+	buff = appendWithLength(buff, ua.userName)
+
+	return buff
+}
+
+func (ua *userAccount) clone() *userAccount {
+	return &userAccount{
+		address:         cloneBytes(ua.address),
+		nonce:           ua.nonce,
+		balance:         cloneBigInt(ua.balance),
+		developerReward: cloneBigInt(ua.developerReward),
+		code:            cloneBytes(ua.code),
+		codeHash:        cloneBytes(ua.codeHash),
+		codeMetadata:    cloneBytes(ua.codeMetadata),
+		rootHash:        cloneBytes(ua.rootHash),
+		ownerAddress:    cloneBytes(ua.ownerAddress),
+		userName:        cloneBytes(ua.userName),
+		hasher:          ua.hasher,
+		dataTrie:        ua.dataTrie.clone(),
+	}
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (ua *userAccount) IsInterfaceNil() bool {
+	return ua == nil
+}
@@ -0,0 +1,127 @@
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	"github.com/subrahamanyam341/andes-core-16/hashing/sha256"
+	"github.com/subrahamanyam341/andes-vm-common-123/mock"
+)
+
+func createMockUserAccountArgs(address []byte) ArgsNewUserAccount {
+	return ArgsNewUserAccount{
+		Address:             address,
+		Hasher:              sha256.NewSha256(),
+		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+	}
+}
+
+func createUserAccount(address []byte) *userAccount {
+	account, _ := NewUserAccount(createMockUserAccountArgs(address))
+	return account
+}
+
+func TestNewUserAccount(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty address should error", func(t *testing.T) {
+		t.Parallel()
+
+		account, err := NewUserAccount(createMockUserAccountArgs(nil))
+		assert.Equal(t, ErrNilAddress, err)
+		assert.True(t, check.IfNil(account))
+	})
+	t.Run("nil hasher should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockUserAccountArgs([]byte("address"))
+		args.Hasher = nil
+		account, err := NewUserAccount(args)
+		assert.Equal(t, ErrNilHasher, err)
+		assert.True(t, check.IfNil(account))
+	})
+	t.Run("nil enable epochs handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockUserAccountArgs([]byte("address"))
+		args.EnableEpochsHandler = nil
+		account, err := NewUserAccount(args)
+		assert.Equal(t, ErrNilEnableEpochsHandler, err)
+		assert.True(t, check.IfNil(account))
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		account, err := NewUserAccount(createMockUserAccountArgs([]byte("address")))
+		assert.Nil(t, err)
+		assert.False(t, check.IfNil(account))
+		assert.Equal(t, []byte("address"), account.AddressBytes())
+		assert.Equal(t, big.NewInt(0), account.GetBalance())
+		assert.Equal(t, big.NewInt(0), account.GetDeveloperReward())
+	})
+}
+
+func TestUserAccount_AddToBalance(t *testing.T) {
+	t.Parallel()
+
+	account := createUserAccount([]byte("address"))
+	assert.Equal(t, ErrNilValue, account.AddToBalance(nil))
+	assert.Nil(t, account.AddToBalance(big.NewInt(10)))
+	assert.Equal(t, ErrInsufficientFunds, account.AddToBalance(big.NewInt(-11)))
+	assert.Nil(t, account.AddToBalance(big.NewInt(-10)))
+	assert.Equal(t, big.NewInt(0), account.GetBalance())
+}
+
+func TestUserAccount_OwnerOperations(t *testing.T) {
+	t.Parallel()
+
+	owner := []byte("owner00")
+	account := createUserAccount([]byte("address"))
+	account.SetOwnerAddress(owner)
+	account.AddToDeveloperReward(big.NewInt(100))
+
+	_, err := account.ClaimDeveloperRewards([]byte("other00"))
+	assert.Equal(t, ErrOperationNotPermitted, err)
+
+	reward, err := account.ClaimDeveloperRewards(owner)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(100), reward)
+	assert.Equal(t, big.NewInt(0), account.GetDeveloperReward())
+
+	assert.Equal(t, ErrOperationNotPermitted, account.ChangeOwnerAddress([]byte("other00"), []byte("newOwnr")))
+	assert.Equal(t, ErrInvalidAddressLength, account.ChangeOwnerAddress(owner, []byte("short")))
+	assert.Nil(t, account.ChangeOwnerAddress(owner, []byte("newOwnr")))
+	assert.Equal(t, []byte("newOwnr"), account.GetOwnerAddress())
+}
+
+func TestUserAccount_SetCode(t *testing.T) {
+	t.Parallel()
+
+	account := createUserAccount([]byte("address"))
+	account.SetCode([]byte("code"))
+	assert.Equal(t, []byte("code"), account.GetCode())
+	assert.Equal(t, sha256.NewSha256().Compute("code"), account.GetCodeHash())
+
+	account.SetCode(nil)
+	assert.Nil(t, account.GetCodeHash())
+}
+
+func TestUserAccount_Clone(t *testing.T) {
+	t.Parallel()
+
+	account := createUserAccount([]byte("address"))
+	account.IncreaseNonce(2)
+	account.SetUserName([]byte("name"))
+	_ = account.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("value"))
+
+	clone := account.clone()
+	assert.Equal(t, account.serialize(), clone.serialize())
+
+	_ = clone.AddToBalance(big.NewInt(1))
+	_ = clone.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("other"))
+	assert.Equal(t, big.NewInt(0), account.GetBalance())
+	value, _, _ := account.AccountDataHandler().RetrieveValue([]byte("key"))
+	assert.Equal(t, []byte("value"), value)
+}
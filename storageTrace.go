@@ -0,0 +1,207 @@
+package vmcommon
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/subrahamanyam341/andes-core-16/core"
+)
+
+const dctTransferRoleIdentifier = "transfer"
+
+var (
+	dctBalanceKeyPrefix             = []byte(core.ProtectedKeyPrefix + core.DCTKeyIdentifier)
+	dctRoleKeyPrefix                = []byte(core.ProtectedKeyPrefix + core.DCTRoleIdentifier + core.DCTKeyIdentifier)
+	dctTransferRoleAddressKeyPrefix = []byte(core.ProtectedKeyPrefix + dctTransferRoleIdentifier + core.DCTKeyIdentifier)
+	dctNFTLatestNonceKeyPrefix      = []byte(core.ProtectedKeyPrefix + core.DCTNFTLatestNonceIdentifier)
+	guardiansKey                    = []byte(core.ProtectedKeyPrefix + core.GuardiansKeyIdentifier)
+)
+
+// StorageAccessKind tells which accounts or account data operation was traced
+type StorageAccessKind int
+
+const (
+	// RetrieveValueAccess is a read of an account data key
+	RetrieveValueAccess StorageAccessKind = iota
+	// SaveKeyValueAccess is a write of an account data key
+	SaveKeyValueAccess
+	// LoadAccountAccess is a load of an account from the accounts adapter
+	LoadAccountAccess
+	// SaveAccountAccess is a save of an account in the accounts adapter
+	SaveAccountAccess
+)
+
+// String returns the human-readable name of the storage access kind
+func (kind StorageAccessKind) String() string {
+	switch kind {
+	case RetrieveValueAccess:
+		return "retrieve value"
+	case SaveKeyValueAccess:
+		return "save key value"
+	case LoadAccountAccess:
+		return "load account"
+	case SaveAccountAccess:
+		return "save account"
+	default:
+		return fmt.Sprintf("unknown storage access kind: %d", int(kind))
+	}
+}
+
+// StorageKeyKind tells what an account data key holds
+type StorageKeyKind int
+
+const (
+	// UnknownStorageKey is a key that was not recognized
+	UnknownStorageKey StorageKeyKind = iota
+	// DCTBalanceStorageKey holds the DCT balance of a user account, for a token and nonce
+	DCTBalanceStorageKey
+	// DCTGlobalSettingsStorageKey holds the global settings of a token, such as paused, on the system account
+	DCTGlobalSettingsStorageKey
+	// DCTMetadataStorageKey holds the NFT metadata and the liquidity of a token and nonce, on the system account
+	DCTMetadataStorageKey
+	// DCTRoleStorageKey holds the roles of an account for a token
+	DCTRoleStorageKey
+	// DCTTransferRoleAddressesStorageKey holds the addresses with the transfer role of a token, on the system account
+	DCTTransferRoleAddressesStorageKey
+	// DCTNFTLatestNonceStorageKey holds the latest nonce created by an account for a token
+	DCTNFTLatestNonceStorageKey
+	// GuardiansStorageKey holds the guardians of an account
+	GuardiansStorageKey
+)
+
+// String returns the human-readable name of the storage key kind
+func (kind StorageKeyKind) String() string {
+	switch kind {
+	case UnknownStorageKey:
+		return "unknown"
+	case DCTBalanceStorageKey:
+		return "DCT balance"
+	case DCTGlobalSettingsStorageKey:
+		return "DCT global settings"
+	case DCTMetadataStorageKey:
+		return "DCT metadata"
+	case DCTRoleStorageKey:
+		return "DCT roles"
+	case DCTTransferRoleAddressesStorageKey:
+		return "DCT transfer role addresses"
+	case DCTNFTLatestNonceStorageKey:
+		return "DCT NFT latest nonce"
+	case GuardiansStorageKey:
+		return "guardians"
+	default:
+		return fmt.Sprintf("unknown storage key kind: %d", int(kind))
+	}
+}
+
+// DecodedStorageKey is an account data key split into its kind and the token it refers to, if any
+type DecodedStorageKey struct {
+	Kind  StorageKeyKind
+	Token TokenIdentifier
+}
+
+// DecodeStorageKey recognizes the protected keys written by the built-in functions. The address is needed to tell
+// apart the keys with the same layout, as the DCT keys of the system account hold the global settings and the
+// metadata instead of a balance.
+func DecodeStorageKey(address []byte, key []byte) DecodedStorageKey {
+	switch {
+	case bytes.Equal(key, guardiansKey):
+		return DecodedStorageKey{Kind: GuardiansStorageKey}
+	case bytes.HasPrefix(key, dctRoleKeyPrefix):
+		return decodeTokenStorageKey(DCTRoleStorageKey, key[len(dctRoleKeyPrefix):])
+	case bytes.HasPrefix(key, dctTransferRoleAddressKeyPrefix):
+		return decodeTokenStorageKey(DCTTransferRoleAddressesStorageKey, key[len(dctTransferRoleAddressKeyPrefix):])
+	case bytes.HasPrefix(key, dctNFTLatestNonceKeyPrefix):
+		return decodeTokenStorageKey(DCTNFTLatestNonceStorageKey, key[len(dctNFTLatestNonceKeyPrefix):])
+	case bytes.HasPrefix(key, dctBalanceKeyPrefix):
+		token := ParseTokenIdentifierWithBinaryNonce(key[len(dctBalanceKeyPrefix):])
+		if !IsSystemAccountAddress(address) {
+			return DecodedStorageKey{Kind: DCTBalanceStorageKey, Token: token}
+		}
+		if token.Nonce == 0 {
+			return DecodedStorageKey{Kind: DCTGlobalSettingsStorageKey, Token: token}
+		}
+
+		return DecodedStorageKey{Kind: DCTMetadataStorageKey, Token: token}
+	default:
+		return DecodedStorageKey{Kind: UnknownStorageKey}
+	}
+}
+
+func decodeTokenStorageKey(kind StorageKeyKind, tokenID []byte) DecodedStorageKey {
+	return DecodedStorageKey{
+		Kind:  kind,
+		Token: TokenIdentifier{TokenID: tokenID},
+	}
+}
+
+// String returns the decoded key in a human-readable form
+func (decoded DecodedStorageKey) String() string {
+	if len(decoded.Token.TokenID) == 0 {
+		return decoded.Kind.String()
+	}
+
+	return fmt.Sprintf("%s %s", decoded.Kind, decoded.Token)
+}
+
+// StorageAccess is a single traced accounts or account data operation. The key, the decoded key and the value are
+// only set for the account data operations.
+type StorageAccess struct {
+	Kind       StorageAccessKind
+	Address    []byte
+	Key        []byte
+	DecodedKey DecodedStorageKey
+	Value      []byte
+	Err        error
+}
+
+// String returns the storage access in a human-readable form
+func (access *StorageAccess) String() string {
+	description := fmt.Sprintf("%s account %s", access.Kind, formatDiffBytes(access.Address))
+	if access.Kind == RetrieveValueAccess || access.Kind == SaveKeyValueAccess {
+		description += fmt.Sprintf(" key %s (%s) value %s", formatDiffBytes(access.Key), access.DecodedKey, formatDiffBytes(access.Value))
+	}
+	if access.Err != nil {
+		description += " error: " + access.Err.Error()
+	}
+
+	return description
+}
+
+// StorageTrace holds, in order, the storage accesses made by a built-in function call
+type StorageTrace struct {
+	Function string
+	Accesses []*StorageAccess
+}
+
+// Reads returns the account data reads of the trace
+func (trace *StorageTrace) Reads() []*StorageAccess {
+	return trace.filter(RetrieveValueAccess)
+}
+
+// Writes returns the account data writes of the trace
+func (trace *StorageTrace) Writes() []*StorageAccess {
+	return trace.filter(SaveKeyValueAccess)
+}
+
+func (trace *StorageTrace) filter(kind StorageAccessKind) []*StorageAccess {
+	filtered := make([]*StorageAccess, 0)
+	for _, access := range trace.Accesses {
+		if access.Kind == kind {
+			filtered = append(filtered, access)
+		}
+	}
+
+	return filtered
+}
+
+// String returns the trace in a human-readable form, one access per line
+func (trace *StorageTrace) String() string {
+	lines := make([]string, 0, len(trace.Accesses)+1)
+	lines = append(lines, fmt.Sprintf("storage trace of %s: %d accesses", trace.Function, len(trace.Accesses)))
+	for _, access := range trace.Accesses {
+		lines = append(lines, "  "+access.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,84 @@
+package vmcommon
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/subrahamanyam341/andes-core-16/core"
+)
+
+func TestDecodeStorageKey(t *testing.T) {
+	t.Parallel()
+
+	userAddress := bytes.Repeat([]byte{1}, 32)
+	token := TokenIdentifier{TokenID: []byte("NFT-abcdef"), Nonce: 10}
+	fungibleToken := TokenIdentifier{TokenID: []byte("TOKEN-abcdef")}
+	tokenID := TokenIdentifier{TokenID: token.TokenID}
+
+	testCases := []struct {
+		address  []byte
+		key      []byte
+		expected DecodedStorageKey
+	}{
+		{userAddress, token.StorageKey(), DecodedStorageKey{Kind: DCTBalanceStorageKey, Token: token}},
+		{userAddress, fungibleToken.StorageKey(), DecodedStorageKey{Kind: DCTBalanceStorageKey, Token: fungibleToken}},
+		{SystemAccountAddress, token.StorageKey(), DecodedStorageKey{Kind: DCTMetadataStorageKey, Token: token}},
+		{SystemAccountAddress, fungibleToken.StorageKey(), DecodedStorageKey{Kind: DCTGlobalSettingsStorageKey, Token: fungibleToken}},
+		{userAddress, []byte(core.ProtectedKeyPrefix + core.DCTRoleIdentifier + core.DCTKeyIdentifier + "NFT-abcdef"), DecodedStorageKey{Kind: DCTRoleStorageKey, Token: tokenID}},
+		{SystemAccountAddress, []byte(core.ProtectedKeyPrefix + "transfer" + core.DCTKeyIdentifier + "NFT-abcdef"), DecodedStorageKey{Kind: DCTTransferRoleAddressesStorageKey, Token: tokenID}},
+		{userAddress, []byte(core.ProtectedKeyPrefix + core.DCTNFTLatestNonceIdentifier + "NFT-abcdef"), DecodedStorageKey{Kind: DCTNFTLatestNonceStorageKey, Token: tokenID}},
+		{userAddress, []byte(core.ProtectedKeyPrefix + core.GuardiansKeyIdentifier), DecodedStorageKey{Kind: GuardiansStorageKey}},
+		{userAddress, []byte("key"), DecodedStorageKey{Kind: UnknownStorageKey}},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, DecodeStorageKey(testCase.address, testCase.key), string(testCase.key))
+	}
+}
+
+func TestStorageKindsString(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "retrieve value", RetrieveValueAccess.String())
+	assert.Equal(t, "save account", SaveAccountAccess.String())
+	assert.Equal(t, "unknown storage access kind: 100", StorageAccessKind(100).String())
+	assert.Equal(t, "DCT metadata", DCTMetadataStorageKey.String())
+	assert.Equal(t, "unknown storage key kind: 100", StorageKeyKind(100).String())
+}
+
+func TestStorageTrace(t *testing.T) {
+	t.Parallel()
+
+	token := TokenIdentifier{TokenID: []byte("NFT-abcdef"), Nonce: 10}
+	read := &StorageAccess{
+		Kind:       RetrieveValueAccess,
+		Address:    []byte("address"),
+		Key:        []byte("key"),
+		DecodedKey: DecodedStorageKey{Kind: DCTBalanceStorageKey, Token: token},
+		Value:      []byte{1, 2},
+	}
+	write := &StorageAccess{
+		Kind:    SaveKeyValueAccess,
+		Address: []byte("address"),
+		Key:     []byte("key"),
+		Value:   []byte("value"),
+	}
+	load := &StorageAccess{
+		Kind:    LoadAccountAccess,
+		Address: []byte("address"),
+		Err:     errors.New("load error"),
+	}
+	trace := &StorageTrace{
+		Function: "function",
+		Accesses: []*StorageAccess{load, read, write},
+	}
+
+	assert.Equal(t, []*StorageAccess{read}, trace.Reads())
+	assert.Equal(t, []*StorageAccess{write}, trace.Writes())
+	expected := `storage trace of function: 3 accesses
+  load account account "address" error: load error
+  retrieve value account "address" key "key" (DCT balance NFT-abcdef-0a) value 0x0102
+  save key value account "address" key "key" (unknown) value "value"`
+	assert.Equal(t, expected, trace.String())
+}
@@ -0,0 +1,159 @@
+package vmcommon
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/subrahamanyam341/andes-core-16/core"
+)
+
+const tokenIdentifierSeparator = '-'
+const maxNonceLength = 8
+
+// TokenIdentifier is a DCT token identifier: the token ID, as in TICKER-abcdef, and the nonce of the NFT or SFT,
+// which is 0 for the fungible tokens
+type TokenIdentifier struct {
+	TokenID []byte
+	Nonce   uint64
+}
+
+// ParseTokenIdentifier parses and validates the human-readable form of a token identifier: TICKER-abcdef for the
+// fungible tokens and TICKER-abcdef-0a for the NFTs, where the nonce is hex encoded, without leading zeros
+func ParseTokenIdentifier(identifier string) (TokenIdentifier, error) {
+	tokenIDLen := len(identifier)
+	separatorIndex := bytes.IndexByte([]byte(identifier), tokenIdentifierSeparator)
+	if separatorIndex >= 0 && separatorIndex+additionalRandomCharsLength+1 < len(identifier) {
+		tokenIDLen = separatorIndex + additionalRandomCharsLength + 1
+	}
+
+	tokenIdentifier := TokenIdentifier{
+		TokenID: []byte(identifier[:tokenIDLen]),
+	}
+	err := tokenIdentifier.Validate()
+	if err != nil {
+		return TokenIdentifier{}, err
+	}
+	if tokenIDLen == len(identifier) {
+		return tokenIdentifier, nil
+	}
+
+	encodedNonce := identifier[tokenIDLen:]
+	if encodedNonce[0] != tokenIdentifierSeparator {
+		return TokenIdentifier{}, fmt.Errorf("%w: missing nonce separator", ErrInvalidTokenIdentifier)
+	}
+	nonceBytes, err := hex.DecodeString(encodedNonce[1:])
+	if err != nil || len(nonceBytes) == 0 || len(nonceBytes) > maxNonceLength || nonceBytes[0] == 0 {
+		return TokenIdentifier{}, fmt.Errorf("%w: invalid nonce %s", ErrInvalidTokenIdentifier, encodedNonce[1:])
+	}
+	tokenIdentifier.Nonce = big.NewInt(0).SetBytes(nonceBytes).Uint64()
+
+	return tokenIdentifier, nil
+}
+
+// ParseTokenIdentifierWithBinaryNonce splits the raw form of a token identifier: the token ID directly followed by the
+// big endian nonce bytes. The token ID is not validated, and the whole argument is returned as the token ID, with 0
+// nonce, if it does not contain a nonce or if the random suffix contains another separator. Unlike
+// ParseTokenIdentifierWithLegacySplit, a nonce containing the separator byte is kept whole.
+func ParseTokenIdentifierWithBinaryNonce(raw []byte) TokenIdentifier {
+	separatorIndex := bytes.IndexByte(raw, tokenIdentifierSeparator)
+	tokenIDLen := separatorIndex + additionalRandomCharsLength + 1
+	if separatorIndex < 0 || tokenIDLen >= len(raw) {
+		return TokenIdentifier{TokenID: raw}
+	}
+	if bytes.IndexByte(raw[separatorIndex+1:tokenIDLen], tokenIdentifierSeparator) >= 0 {
+		return TokenIdentifier{TokenID: raw}
+	}
+
+	return TokenIdentifier{
+		TokenID: raw[:tokenIDLen],
+		Nonce:   big.NewInt(0).SetBytes(raw[tokenIDLen:]).Uint64(),
+	}
+}
+
+// ParseTokenIdentifierWithLegacySplit splits the raw form of a token identifier the way the wipe built-in function
+// always did: the argument is split on every separator and, if the second part is longer than the random suffix, the
+// token ID is made of the first part and the random suffix while the rest of the second part is the big endian nonce.
+// Any other part is dropped. The whole argument is returned as the token ID, with 0 nonce, otherwise.
+func ParseTokenIdentifierWithLegacySplit(raw []byte) TokenIdentifier {
+	parts := bytes.Split(raw, []byte{tokenIdentifierSeparator})
+	if len(parts) < 2 || len(parts[1]) <= additionalRandomCharsLength {
+		return TokenIdentifier{TokenID: raw}
+	}
+
+	tokenID := make([]byte, 0, len(parts[0])+additionalRandomCharsLength+1)
+	tokenID = append(tokenID, parts[0]...)
+	tokenID = append(tokenID, tokenIdentifierSeparator)
+	tokenID = append(tokenID, parts[1][:additionalRandomCharsLength]...)
+
+	return TokenIdentifier{
+		TokenID: tokenID,
+		Nonce:   big.NewInt(0).SetBytes(parts[1][additionalRandomCharsLength:]).Uint64(),
+	}
+}
+
+// Ticker returns the part of the token ID before the separator
+func (ti TokenIdentifier) Ticker() []byte {
+	separatorIndex := bytes.IndexByte(ti.TokenID, tokenIdentifierSeparator)
+	if separatorIndex < 0 {
+		return ti.TokenID
+	}
+
+	return ti.TokenID[:separatorIndex]
+}
+
+// RandomSuffix returns the part of the token ID after the separator
+func (ti TokenIdentifier) RandomSuffix() []byte {
+	separatorIndex := bytes.IndexByte(ti.TokenID, tokenIdentifierSeparator)
+	if separatorIndex < 0 {
+		return nil
+	}
+
+	return ti.TokenID[separatorIndex+1:]
+}
+
+// Validate checks that the ticker has between 3 and 10 uppercase alphanumeric characters and that the random
+// suffix has 6 lowercase hex characters
+func (ti TokenIdentifier) Validate() error {
+	if bytes.IndexByte(ti.TokenID, tokenIdentifierSeparator) < 0 {
+		return fmt.Errorf("%w: missing separator in %s", ErrInvalidTokenIdentifier, ti.TokenID)
+	}
+	if !isTickerValid(ti.Ticker()) {
+		return fmt.Errorf("%w: invalid ticker in %s", ErrInvalidTokenIdentifier, ti.TokenID)
+	}
+	if !randomCharsAreValid(ti.RandomSuffix()) {
+		return fmt.Errorf("%w: invalid random suffix in %s", ErrInvalidTokenIdentifier, ti.TokenID)
+	}
+
+	return nil
+}
+
+// String returns the human-readable form of the token identifier, with the hex encoded nonce for the NFTs
+func (ti TokenIdentifier) String() string {
+	if ti.Nonce == 0 {
+		return string(ti.TokenID)
+	}
+
+	nonceBytes := big.NewInt(0).SetUint64(ti.Nonce).Bytes()
+	return fmt.Sprintf("%s%c%s", ti.TokenID, tokenIdentifierSeparator, hex.EncodeToString(nonceBytes))
+}
+
+// BinaryNonceForm returns the raw form of the token identifier, parsed by ParseTokenIdentifierWithBinaryNonce
+func (ti TokenIdentifier) BinaryNonceForm() []byte {
+	raw := make([]byte, 0, len(ti.TokenID)+maxNonceLength)
+	raw = append(raw, ti.TokenID...)
+	return append(raw, big.NewInt(0).SetUint64(ti.Nonce).Bytes()...)
+}
+
+// StorageKeyPrefix returns the account storage key of the token, shared by all its nonces
+func (ti TokenIdentifier) StorageKeyPrefix() []byte {
+	key := make([]byte, 0, len(core.ProtectedKeyPrefix)+len(core.DCTKeyIdentifier)+len(ti.TokenID)+maxNonceLength)
+	key = append(key, core.ProtectedKeyPrefix+core.DCTKeyIdentifier...)
+	return append(key, ti.TokenID...)
+}
+
+// StorageKey returns the account storage key of the token with the nonce
+func (ti TokenIdentifier) StorageKey() []byte {
+	return append(ti.StorageKeyPrefix(), big.NewInt(0).SetUint64(ti.Nonce).Bytes()...)
+}
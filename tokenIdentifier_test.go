@@ -0,0 +1,112 @@
+package vmcommon
+
+import (
+	"encoding/hex"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core"
+)
+
+func TestParseTokenIdentifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid identifiers should parse and format back", func(t *testing.T) {
+		t.Parallel()
+
+		validIdentifiers := map[string]TokenIdentifier{
+			"ALC-6258d2":                  {TokenID: []byte("ALC-6258d2")},
+			"MOAXRIDEF2-08d8ef":           {TokenID: []byte("MOAXRIDEF2-08d8ef")},
+			"NFT-abcdef-0a":               {TokenID: []byte("NFT-abcdef"), Nonce: 10},
+			"NFT-abcdef-0100":             {TokenID: []byte("NFT-abcdef"), Nonce: 256},
+			"NFT-abcdef-ffffffffffffffff": {TokenID: []byte("NFT-abcdef"), Nonce: math.MaxUint64},
+		}
+		for identifier, expected := range validIdentifiers {
+			tokenIdentifier, err := ParseTokenIdentifier(identifier)
+			require.Nil(t, err, identifier)
+			assert.Equal(t, expected, tokenIdentifier, identifier)
+			assert.Equal(t, identifier, tokenIdentifier.String())
+		}
+	})
+	t.Run("invalid identifiers should error", func(t *testing.T) {
+		t.Parallel()
+
+		invalidIdentifiers := []string{
+			"",
+			"ALC6258d2",
+			"AL-6258d2",
+			"alc-6258d2",
+			"ALCCCCCCCCC-6258d2",
+			"ALC-6258D2",
+			"ALC-6258d",
+			"ALC-6258d2ff",
+			"NFT-abcdef-",
+			"NFT-abcdef-a",
+			"NFT-abcdef-00",
+			"NFT-abcdef-000a",
+			"NFT-abcdef-010000000000000000",
+			"NFT-abcdef-zz",
+		}
+		for _, identifier := range invalidIdentifiers {
+			_, err := ParseTokenIdentifier(identifier)
+			assert.True(t, errors.Is(err, ErrInvalidTokenIdentifier), identifier)
+		}
+	})
+}
+
+func TestParseTokenIdentifierWithBinaryNonce(t *testing.T) {
+	t.Parallel()
+
+	raw, _ := hex.DecodeString("534b4537592d37336262636404")
+	tokenIdentifier := ParseTokenIdentifierWithBinaryNonce(raw)
+	assert.Equal(t, TokenIdentifier{TokenID: []byte("SKE7Y-73bbcd"), Nonce: 4}, tokenIdentifier)
+	assert.Equal(t, raw, tokenIdentifier.BinaryNonceForm())
+
+	raw, _ = hex.DecodeString("574D4F41582D376662623930")
+	assert.Equal(t, TokenIdentifier{TokenID: []byte("WMOAX-7fbb90")}, ParseTokenIdentifierWithBinaryNonce(raw))
+
+	// a nonce byte equal to the separator is still part of the nonce
+	raw = append([]byte("NFT-abcdef"), 1, '-')
+	assert.Equal(t, TokenIdentifier{TokenID: []byte("NFT-abcdef"), Nonce: 0x012d}, ParseTokenIdentifierWithBinaryNonce(raw))
+
+	raw = []byte("TOKEN-abcd-01")
+	assert.Equal(t, TokenIdentifier{TokenID: raw}, ParseTokenIdentifierWithBinaryNonce(raw))
+	assert.Equal(t, TokenIdentifier{TokenID: []byte("TOKEN")}, ParseTokenIdentifierWithBinaryNonce([]byte("TOKEN")))
+}
+
+func TestParseTokenIdentifierWithLegacySplit(t *testing.T) {
+	t.Parallel()
+
+	raw, _ := hex.DecodeString("534b4537592d37336262636404")
+	assert.Equal(t, TokenIdentifier{TokenID: []byte("SKE7Y-73bbcd"), Nonce: 4}, ParseTokenIdentifierWithLegacySplit(raw))
+
+	raw, _ = hex.DecodeString("574D4F41582D376662623930")
+	assert.Equal(t, TokenIdentifier{TokenID: []byte("WMOAX-7fbb90")}, ParseTokenIdentifierWithLegacySplit(raw))
+
+	// the nonce stops at the first nonce byte equal to the separator and the remaining parts are dropped
+	raw = append([]byte("NFT-abcdef"), 1, '-', 2)
+	assert.Equal(t, TokenIdentifier{TokenID: []byte("NFT-abcdef"), Nonce: 1}, ParseTokenIdentifierWithLegacySplit(raw))
+
+	// a nonce starting with the separator byte leaves the whole argument as the token ID
+	raw = append([]byte("NFT-abcdef"), '-', 1)
+	assert.Equal(t, TokenIdentifier{TokenID: raw}, ParseTokenIdentifierWithLegacySplit(raw))
+
+	assert.Equal(t, TokenIdentifier{TokenID: []byte("TOKEN")}, ParseTokenIdentifierWithLegacySplit([]byte("TOKEN")))
+}
+
+func TestTokenIdentifier_Parts(t *testing.T) {
+	t.Parallel()
+
+	tokenIdentifier := TokenIdentifier{TokenID: []byte("NFT-abcdef"), Nonce: 10}
+	assert.Equal(t, []byte("NFT"), tokenIdentifier.Ticker())
+	assert.Equal(t, []byte("abcdef"), tokenIdentifier.RandomSuffix())
+	assert.Equal(t, []byte(core.ProtectedKeyPrefix+core.DCTKeyIdentifier+"NFT-abcdef"), tokenIdentifier.StorageKeyPrefix())
+	assert.Equal(t, []byte(core.ProtectedKeyPrefix+core.DCTKeyIdentifier+"NFT-abcdef\x0a"), tokenIdentifier.StorageKey())
+
+	fungible := TokenIdentifier{TokenID: []byte("TKN-abcdef")}
+	assert.Equal(t, fungible.StorageKeyPrefix(), fungible.StorageKey())
+	assert.Nil(t, TokenIdentifier{TokenID: []byte("TKN")}.RandomSuffix())
+}
@@ -0,0 +1,726 @@
+package vmcommon
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/subrahamanyam341/andes-core-16/core"
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	"github.com/subrahamanyam341/andes-core-16/data/vm"
+)
+
+var callTypeNames = map[vm.CallType]string{
+	vm.DirectCall:            vm.DirectCallStr,
+	vm.AsynchronousCall:      vm.AsynchronousCallStr,
+	vm.AsynchronousCallBack:  vm.AsynchronousCallBackStr,
+	vm.DCTTransferAndExecute: vm.DCTTransferAndExecuteStr,
+	vm.ExecOnDestByCaller:    vm.ExecOnDestByCallerStr,
+}
+
+// ArgsNewVMJSONCodec is the argument structure used to create a new VM JSON codec
+type ArgsNewVMJSONCodec struct {
+	// AddressConverter encodes the addresses with its length, the other addresses are written as hex.
+	// The addresses are written as hex when it is nil.
+	AddressConverter core.PubkeyConverter
+	// WithUTF8Hints adds the UTF-8 form next to the hex form of the printable arguments, storage and log values.
+	// The hints are ignored when decoding.
+	WithUTF8Hints bool
+}
+
+type vmJSONCodec struct {
+	addressConverter core.PubkeyConverter
+	withUTF8Hints    bool
+}
+
+// NewVMJSONCodec creates a codec that writes the VM inputs and outputs as JSON with stable field names: the
+// addresses as bech32 or hex, the big ints as decimal strings, the byte values as hex and the call types and
+// return codes by name. A nil and an empty value are written differently, so the decoding is lossless.
+func NewVMJSONCodec(args ArgsNewVMJSONCodec) *vmJSONCodec {
+	codec := &vmJSONCodec{
+		withUTF8Hints: args.WithUTF8Hints,
+	}
+	if !check.IfNil(args.AddressConverter) {
+		codec.addressConverter = args.AddressConverter
+	}
+
+	return codec
+}
+
+type jsonBytes struct {
+	Hex  string `json:"hex"`
+	UTF8 string `json:"utf8,omitempty"`
+}
+
+type jsonAsyncArguments struct {
+	CallID                       *string `json:"callID,omitempty"`
+	CallerCallID                 *string `json:"callerCallID,omitempty"`
+	CallbackAsyncInitiatorCallID *string `json:"callbackAsyncInitiatorCallID,omitempty"`
+	GasAccumulated               uint64  `json:"gasAccumulated"`
+}
+
+type jsonDCTTransfer struct {
+	Value      *string    `json:"value,omitempty"`
+	TokenName  *jsonBytes `json:"tokenName,omitempty"`
+	TokenType  uint32     `json:"tokenType"`
+	TokenNonce uint64     `json:"tokenNonce"`
+}
+
+type jsonVMInput struct {
+	Caller               *string             `json:"caller,omitempty"`
+	Arguments            []*jsonBytes        `json:"arguments"`
+	AsyncArguments       *jsonAsyncArguments `json:"asyncArguments,omitempty"`
+	CallValue            *string             `json:"callValue,omitempty"`
+	CallType             string              `json:"callType"`
+	GasPrice             uint64              `json:"gasPrice"`
+	GasProvided          uint64              `json:"gasProvided"`
+	GasLocked            uint64              `json:"gasLocked"`
+	OriginalTxHash       *string             `json:"originalTxHash,omitempty"`
+	CurrentTxHash        *string             `json:"currentTxHash,omitempty"`
+	PrevTxHash           *string             `json:"prevTxHash,omitempty"`
+	DCTTransfers         []*jsonDCTTransfer  `json:"dctTransfers"`
+	ReturnCallAfterError bool                `json:"returnCallAfterError"`
+	TxGuardian           *string             `json:"txGuardian,omitempty"`
+	OriginalCaller       *string             `json:"originalCaller,omitempty"`
+}
+
+type jsonContractCallInput struct {
+	jsonVMInput
+	Recipient         *string `json:"recipient,omitempty"`
+	Function          string  `json:"function"`
+	AllowInitFunction bool    `json:"allowInitFunction"`
+}
+
+type jsonContractCreateInput struct {
+	jsonVMInput
+	ContractCode         *string `json:"contractCode,omitempty"`
+	ContractCodeMetadata *string `json:"contractCodeMetadata,omitempty"`
+}
+
+type jsonStorageUpdate struct {
+	Offset  *jsonBytes `json:"offset,omitempty"`
+	Data    *jsonBytes `json:"data,omitempty"`
+	Written bool       `json:"written"`
+}
+
+type jsonOutputTransfer struct {
+	Index     uint32     `json:"index"`
+	Value     *string    `json:"value,omitempty"`
+	GasLimit  uint64     `json:"gasLimit"`
+	GasLocked uint64     `json:"gasLocked"`
+	AsyncData *string    `json:"asyncData,omitempty"`
+	Data      *jsonBytes `json:"data,omitempty"`
+	CallType  string     `json:"callType"`
+	Sender    *string    `json:"sender,omitempty"`
+}
+
+type jsonOutputAccount struct {
+	Address                       *string                       `json:"address,omitempty"`
+	Nonce                         uint64                        `json:"nonce"`
+	Balance                       *string                       `json:"balance,omitempty"`
+	StorageUpdates                map[string]*jsonStorageUpdate `json:"storageUpdates"`
+	Code                          *string                       `json:"code,omitempty"`
+	CodeMetadata                  *string                       `json:"codeMetadata,omitempty"`
+	CodeDeployerAddress           *string                       `json:"codeDeployerAddress,omitempty"`
+	BalanceDelta                  *string                       `json:"balanceDelta,omitempty"`
+	OutputTransfers               []jsonOutputTransfer          `json:"outputTransfers"`
+	GasUsed                       uint64                        `json:"gasUsed"`
+	BytesAddedToStorage           uint64                        `json:"bytesAddedToStorage"`
+	BytesDeletedFromStorage       uint64                        `json:"bytesDeletedFromStorage"`
+	BytesConsumedByTxAsNetworking uint64                        `json:"bytesConsumedByTxAsNetworking"`
+}
+
+type jsonLogEntry struct {
+	Identifier *jsonBytes   `json:"identifier,omitempty"`
+	Address    *string      `json:"address,omitempty"`
+	Topics     []*jsonBytes `json:"topics"`
+	Data       []*jsonBytes `json:"data"`
+}
+
+type jsonVMOutput struct {
+	ReturnData      []*jsonBytes                  `json:"returnData"`
+	ReturnCode      string                        `json:"returnCode"`
+	ReturnMessage   string                        `json:"returnMessage"`
+	GasRemaining    uint64                        `json:"gasRemaining"`
+	GasRefund       *string                       `json:"gasRefund,omitempty"`
+	OutputAccounts  map[string]*jsonOutputAccount `json:"outputAccounts"`
+	DeletedAccounts []*string                     `json:"deletedAccounts"`
+	TouchedAccounts []*string                     `json:"touchedAccounts"`
+	Logs            []*jsonLogEntry               `json:"logs"`
+}
+
+// EncodeContractCallInput returns the JSON form of the contract call input
+func (codec *vmJSONCodec) EncodeContractCallInput(input *ContractCallInput) ([]byte, error) {
+	if input == nil {
+		return nil, ErrNilVMInput
+	}
+
+	return json.Marshal(&jsonContractCallInput{
+		jsonVMInput:       codec.toJSONVMInput(&input.VMInput),
+		Recipient:         codec.encodeAddress(input.RecipientAddr),
+		Function:          input.Function,
+		AllowInitFunction: input.AllowInitFunction,
+	})
+}
+
+// DecodeContractCallInput returns the contract call input from its JSON form
+func (codec *vmJSONCodec) DecodeContractCallInput(buff []byte) (*ContractCallInput, error) {
+	decoded := &jsonContractCallInput{}
+	err := json.Unmarshal(buff, decoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidVMJSONEncoding, err.Error())
+	}
+
+	d := &jsonDecoder{codec: codec}
+	input := &ContractCallInput{
+		VMInput:           d.fromJSONVMInput(&decoded.jsonVMInput),
+		RecipientAddr:     d.decodeAddress("recipient", decoded.Recipient),
+		Function:          decoded.Function,
+		AllowInitFunction: decoded.AllowInitFunction,
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	return input, nil
+}
+
+// EncodeContractCreateInput returns the JSON form of the contract create input
+func (codec *vmJSONCodec) EncodeContractCreateInput(input *ContractCreateInput) ([]byte, error) {
+	if input == nil {
+		return nil, ErrNilVMInput
+	}
+
+	return json.Marshal(&jsonContractCreateInput{
+		jsonVMInput:          codec.toJSONVMInput(&input.VMInput),
+		ContractCode:         encodeHex(input.ContractCode),
+		ContractCodeMetadata: encodeHex(input.ContractCodeMetadata),
+	})
+}
+
+// DecodeContractCreateInput returns the contract create input from its JSON form
+func (codec *vmJSONCodec) DecodeContractCreateInput(buff []byte) (*ContractCreateInput, error) {
+	decoded := &jsonContractCreateInput{}
+	err := json.Unmarshal(buff, decoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidVMJSONEncoding, err.Error())
+	}
+
+	d := &jsonDecoder{codec: codec}
+	input := &ContractCreateInput{
+		VMInput:              d.fromJSONVMInput(&decoded.jsonVMInput),
+		ContractCode:         d.decodeHex("contractCode", decoded.ContractCode),
+		ContractCodeMetadata: d.decodeHex("contractCodeMetadata", decoded.ContractCodeMetadata),
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	return input, nil
+}
+
+// EncodeVMOutput returns the JSON form of the VM output. The output accounts are keyed by their encoded map keys
+// and the storage updates by their hex map keys.
+func (codec *vmJSONCodec) EncodeVMOutput(vmOutput *VMOutput) ([]byte, error) {
+	if vmOutput == nil {
+		return nil, ErrNilVMOutput
+	}
+
+	encoded := &jsonVMOutput{
+		ReturnData:      codec.encodeBytesList(vmOutput.ReturnData),
+		ReturnCode:      returnCodeToName(vmOutput.ReturnCode),
+		ReturnMessage:   vmOutput.ReturnMessage,
+		GasRemaining:    vmOutput.GasRemaining,
+		GasRefund:       encodeBigInt(vmOutput.GasRefund),
+		DeletedAccounts: codec.encodeAddresses(vmOutput.DeletedAccounts),
+		TouchedAccounts: codec.encodeAddresses(vmOutput.TouchedAccounts),
+	}
+	if vmOutput.OutputAccounts != nil {
+		encoded.OutputAccounts = make(map[string]*jsonOutputAccount, len(vmOutput.OutputAccounts))
+		for key, account := range vmOutput.OutputAccounts {
+			encoded.OutputAccounts[*codec.encodeAddress([]byte(key))] = codec.toJSONOutputAccount(account)
+		}
+	}
+	if vmOutput.Logs != nil {
+		encoded.Logs = make([]*jsonLogEntry, 0, len(vmOutput.Logs))
+		for _, logEntry := range vmOutput.Logs {
+			encoded.Logs = append(encoded.Logs, codec.toJSONLogEntry(logEntry))
+		}
+	}
+
+	return json.Marshal(encoded)
+}
+
+// DecodeVMOutput returns the VM output from its JSON form
+func (codec *vmJSONCodec) DecodeVMOutput(buff []byte) (*VMOutput, error) {
+	decoded := &jsonVMOutput{}
+	err := json.Unmarshal(buff, decoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidVMJSONEncoding, err.Error())
+	}
+
+	d := &jsonDecoder{codec: codec}
+	vmOutput := &VMOutput{
+		ReturnData:      d.decodeBytesList("returnData", decoded.ReturnData),
+		ReturnCode:      d.decodeReturnCode(decoded.ReturnCode),
+		ReturnMessage:   decoded.ReturnMessage,
+		GasRemaining:    decoded.GasRemaining,
+		GasRefund:       d.decodeBigInt("gasRefund", decoded.GasRefund),
+		DeletedAccounts: d.decodeAddresses("deletedAccounts", decoded.DeletedAccounts),
+		TouchedAccounts: d.decodeAddresses("touchedAccounts", decoded.TouchedAccounts),
+	}
+	if decoded.OutputAccounts != nil {
+		vmOutput.OutputAccounts = make(map[string]*OutputAccount, len(decoded.OutputAccounts))
+		for key, account := range decoded.OutputAccounts {
+			address := d.decodeAddress("outputAccounts key", &key)
+			vmOutput.OutputAccounts[string(address)] = d.fromJSONOutputAccount(account)
+		}
+	}
+	if decoded.Logs != nil {
+		vmOutput.Logs = make([]*LogEntry, 0, len(decoded.Logs))
+		for _, logEntry := range decoded.Logs {
+			vmOutput.Logs = append(vmOutput.Logs, d.fromJSONLogEntry(logEntry))
+		}
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	return vmOutput, nil
+}
+
+func (codec *vmJSONCodec) toJSONVMInput(input *VMInput) jsonVMInput {
+	encoded := jsonVMInput{
+		Caller:               codec.encodeAddress(input.CallerAddr),
+		Arguments:            codec.encodeBytesList(input.Arguments),
+		CallValue:            encodeBigInt(input.CallValue),
+		CallType:             callTypeToName(input.CallType),
+		GasPrice:             input.GasPrice,
+		GasProvided:          input.GasProvided,
+		GasLocked:            input.GasLocked,
+		OriginalTxHash:       encodeHex(input.OriginalTxHash),
+		CurrentTxHash:        encodeHex(input.CurrentTxHash),
+		PrevTxHash:           encodeHex(input.PrevTxHash),
+		ReturnCallAfterError: input.ReturnCallAfterError,
+		TxGuardian:           codec.encodeAddress(input.TxGuardian),
+		OriginalCaller:       codec.encodeAddress(input.OriginalCallerAddr),
+	}
+	if input.AsyncArguments != nil {
+		encoded.AsyncArguments = &jsonAsyncArguments{
+			CallID:                       encodeHex(input.AsyncArguments.CallID),
+			CallerCallID:                 encodeHex(input.AsyncArguments.CallerCallID),
+			CallbackAsyncInitiatorCallID: encodeHex(input.AsyncArguments.CallbackAsyncInitiatorCallID),
+			GasAccumulated:               input.AsyncArguments.GasAccumulated,
+		}
+	}
+	if input.DCTTransfers != nil {
+		encoded.DCTTransfers = make([]*jsonDCTTransfer, 0, len(input.DCTTransfers))
+		for _, transfer := range input.DCTTransfers {
+			if transfer == nil {
+				encoded.DCTTransfers = append(encoded.DCTTransfers, nil)
+				continue
+			}
+
+			encoded.DCTTransfers = append(encoded.DCTTransfers, &jsonDCTTransfer{
+				Value:      encodeBigInt(transfer.DCTValue),
+				TokenName:  codec.encodeBytes(transfer.DCTTokenName),
+				TokenType:  transfer.DCTTokenType,
+				TokenNonce: transfer.DCTTokenNonce,
+			})
+		}
+	}
+
+	return encoded
+}
+
+func (codec *vmJSONCodec) toJSONOutputAccount(account *OutputAccount) *jsonOutputAccount {
+	if account == nil {
+		return nil
+	}
+
+	encoded := &jsonOutputAccount{
+		Address:                       codec.encodeAddress(account.Address),
+		Nonce:                         account.Nonce,
+		Balance:                       encodeBigInt(account.Balance),
+		Code:                          encodeHex(account.Code),
+		CodeMetadata:                  encodeHex(account.CodeMetadata),
+		CodeDeployerAddress:           codec.encodeAddress(account.CodeDeployerAddress),
+		BalanceDelta:                  encodeBigInt(account.BalanceDelta),
+		GasUsed:                       account.GasUsed,
+		BytesAddedToStorage:           account.BytesAddedToStorage,
+		BytesDeletedFromStorage:       account.BytesDeletedFromStorage,
+		BytesConsumedByTxAsNetworking: account.BytesConsumedByTxAsNetworking,
+	}
+	if account.StorageUpdates != nil {
+		encoded.StorageUpdates = make(map[string]*jsonStorageUpdate, len(account.StorageUpdates))
+		for key, update := range account.StorageUpdates {
+			var encodedUpdate *jsonStorageUpdate
+			if update != nil {
+				encodedUpdate = &jsonStorageUpdate{
+					Offset:  codec.encodeBytes(update.Offset),
+					Data:    codec.encodeBytes(update.Data),
+					Written: update.Written,
+				}
+			}
+			encoded.StorageUpdates[hex.EncodeToString([]byte(key))] = encodedUpdate
+		}
+	}
+	if account.OutputTransfers != nil {
+		encoded.OutputTransfers = make([]jsonOutputTransfer, 0, len(account.OutputTransfers))
+		for _, transfer := range account.OutputTransfers {
+			encoded.OutputTransfers = append(encoded.OutputTransfers, jsonOutputTransfer{
+				Index:     transfer.Index,
+				Value:     encodeBigInt(transfer.Value),
+				GasLimit:  transfer.GasLimit,
+				GasLocked: transfer.GasLocked,
+				AsyncData: encodeHex(transfer.AsyncData),
+				Data:      codec.encodeBytes(transfer.Data),
+				CallType:  callTypeToName(transfer.CallType),
+				Sender:    codec.encodeAddress(transfer.SenderAddress),
+			})
+		}
+	}
+
+	return encoded
+}
+
+func (codec *vmJSONCodec) toJSONLogEntry(logEntry *LogEntry) *jsonLogEntry {
+	if logEntry == nil {
+		return nil
+	}
+
+	return &jsonLogEntry{
+		Identifier: codec.encodeBytes(logEntry.Identifier),
+		Address:    codec.encodeAddress(logEntry.Address),
+		Topics:     codec.encodeBytesList(logEntry.Topics),
+		Data:       codec.encodeBytesList(logEntry.Data),
+	}
+}
+
+// encodeAddress writes the address with the address converter if it has the converter length, or as hex otherwise
+func (codec *vmJSONCodec) encodeAddress(address []byte) *string {
+	if address == nil {
+		return nil
+	}
+	if codec.addressConverter != nil && len(address) == codec.addressConverter.Len() {
+		encoded, err := codec.addressConverter.Encode(address)
+		if err == nil {
+			return &encoded
+		}
+	}
+
+	return encodeHex(address)
+}
+
+func (codec *vmJSONCodec) encodeAddresses(addresses [][]byte) []*string {
+	if addresses == nil {
+		return nil
+	}
+
+	encoded := make([]*string, 0, len(addresses))
+	for _, address := range addresses {
+		encoded = append(encoded, codec.encodeAddress(address))
+	}
+
+	return encoded
+}
+
+func (codec *vmJSONCodec) encodeBytes(value []byte) *jsonBytes {
+	if value == nil {
+		return nil
+	}
+
+	encoded := &jsonBytes{
+		Hex: hex.EncodeToString(value),
+	}
+	if codec.withUTF8Hints && isPrintableText(value) {
+		encoded.UTF8 = string(value)
+	}
+
+	return encoded
+}
+
+func (codec *vmJSONCodec) encodeBytesList(values [][]byte) []*jsonBytes {
+	if values == nil {
+		return nil
+	}
+
+	encoded := make([]*jsonBytes, 0, len(values))
+	for _, value := range values {
+		encoded = append(encoded, codec.encodeBytes(value))
+	}
+
+	return encoded
+}
+
+func encodeHex(value []byte) *string {
+	if value == nil {
+		return nil
+	}
+
+	encoded := hex.EncodeToString(value)
+	return &encoded
+}
+
+func encodeBigInt(value *big.Int) *string {
+	if value == nil {
+		return nil
+	}
+
+	encoded := value.String()
+	return &encoded
+}
+
+// callTypeToName returns the name of the call type, or its number if the call type is unknown
+func callTypeToName(callType vm.CallType) string {
+	name, found := callTypeNames[callType]
+	if found {
+		return name
+	}
+
+	return strconv.Itoa(int(callType))
+}
+
+// returnCodeToName returns the name of the return code, or its number if the return code is unknown
+func returnCodeToName(returnCode ReturnCode) string {
+	if returnCode < Ok || returnCode > SimulateFailed {
+		return strconv.Itoa(int(returnCode))
+	}
+
+	return returnCode.String()
+}
+
+// jsonDecoder converts the decoded JSON values. After the first error all the conversions return zero values, so
+// the error is checked only once, at the end.
+type jsonDecoder struct {
+	codec *vmJSONCodec
+	err   error
+}
+
+func (d *jsonDecoder) fail(field string, reason string) {
+	if d.err == nil {
+		d.err = fmt.Errorf("%w: invalid %s, %s", ErrInvalidVMJSONEncoding, field, reason)
+	}
+}
+
+func (d *jsonDecoder) fromJSONVMInput(encoded *jsonVMInput) VMInput {
+	input := VMInput{
+		CallerAddr:           d.decodeAddress("caller", encoded.Caller),
+		Arguments:            d.decodeBytesList("arguments", encoded.Arguments),
+		CallValue:            d.decodeBigInt("callValue", encoded.CallValue),
+		CallType:             d.decodeCallType("callType", encoded.CallType),
+		GasPrice:             encoded.GasPrice,
+		GasProvided:          encoded.GasProvided,
+		GasLocked:            encoded.GasLocked,
+		OriginalTxHash:       d.decodeHex("originalTxHash", encoded.OriginalTxHash),
+		CurrentTxHash:        d.decodeHex("currentTxHash", encoded.CurrentTxHash),
+		PrevTxHash:           d.decodeHex("prevTxHash", encoded.PrevTxHash),
+		ReturnCallAfterError: encoded.ReturnCallAfterError,
+		TxGuardian:           d.decodeAddress("txGuardian", encoded.TxGuardian),
+		OriginalCallerAddr:   d.decodeAddress("originalCaller", encoded.OriginalCaller),
+	}
+	if encoded.AsyncArguments != nil {
+		input.AsyncArguments = &AsyncArguments{
+			CallID:                       d.decodeHex("callID", encoded.AsyncArguments.CallID),
+			CallerCallID:                 d.decodeHex("callerCallID", encoded.AsyncArguments.CallerCallID),
+			CallbackAsyncInitiatorCallID: d.decodeHex("callbackAsyncInitiatorCallID", encoded.AsyncArguments.CallbackAsyncInitiatorCallID),
+			GasAccumulated:               encoded.AsyncArguments.GasAccumulated,
+		}
+	}
+	if encoded.DCTTransfers != nil {
+		input.DCTTransfers = make([]*DCTTransfer, 0, len(encoded.DCTTransfers))
+		for _, transfer := range encoded.DCTTransfers {
+			if transfer == nil {
+				input.DCTTransfers = append(input.DCTTransfers, nil)
+				continue
+			}
+
+			input.DCTTransfers = append(input.DCTTransfers, &DCTTransfer{
+				DCTValue:      d.decodeBigInt("dctTransfers value", transfer.Value),
+				DCTTokenName:  d.decodeBytes("dctTransfers tokenName", transfer.TokenName),
+				DCTTokenType:  transfer.TokenType,
+				DCTTokenNonce: transfer.TokenNonce,
+			})
+		}
+	}
+
+	return input
+}
+
+func (d *jsonDecoder) fromJSONOutputAccount(encoded *jsonOutputAccount) *OutputAccount {
+	if encoded == nil {
+		return nil
+	}
+
+	account := &OutputAccount{
+		Address:                       d.decodeAddress("address", encoded.Address),
+		Nonce:                         encoded.Nonce,
+		Balance:                       d.decodeBigInt("balance", encoded.Balance),
+		Code:                          d.decodeHex("code", encoded.Code),
+		CodeMetadata:                  d.decodeHex("codeMetadata", encoded.CodeMetadata),
+		CodeDeployerAddress:           d.decodeAddress("codeDeployerAddress", encoded.CodeDeployerAddress),
+		BalanceDelta:                  d.decodeBigInt("balanceDelta", encoded.BalanceDelta),
+		GasUsed:                       encoded.GasUsed,
+		BytesAddedToStorage:           encoded.BytesAddedToStorage,
+		BytesDeletedFromStorage:       encoded.BytesDeletedFromStorage,
+		BytesConsumedByTxAsNetworking: encoded.BytesConsumedByTxAsNetworking,
+	}
+	if encoded.StorageUpdates != nil {
+		account.StorageUpdates = make(map[string]*StorageUpdate, len(encoded.StorageUpdates))
+		for key, update := range encoded.StorageUpdates {
+			decodedKey := d.decodeHex("storageUpdates key", &key)
+			if update == nil {
+				account.StorageUpdates[string(decodedKey)] = nil
+				continue
+			}
+
+			account.StorageUpdates[string(decodedKey)] = &StorageUpdate{
+				Offset:  d.decodeBytes("offset", update.Offset),
+				Data:    d.decodeBytes("data", update.Data),
+				Written: update.Written,
+			}
+		}
+	}
+	if encoded.OutputTransfers != nil {
+		account.OutputTransfers = make([]OutputTransfer, 0, len(encoded.OutputTransfers))
+		for _, transfer := range encoded.OutputTransfers {
+			account.OutputTransfers = append(account.OutputTransfers, OutputTransfer{
+				Index:         transfer.Index,
+				Value:         d.decodeBigInt("outputTransfers value", transfer.Value),
+				GasLimit:      transfer.GasLimit,
+				GasLocked:     transfer.GasLocked,
+				AsyncData:     d.decodeHex("asyncData", transfer.AsyncData),
+				Data:          d.decodeBytes("outputTransfers data", transfer.Data),
+				CallType:      d.decodeCallType("outputTransfers callType", transfer.CallType),
+				SenderAddress: d.decodeAddress("sender", transfer.Sender),
+			})
+		}
+	}
+
+	return account
+}
+
+func (d *jsonDecoder) fromJSONLogEntry(encoded *jsonLogEntry) *LogEntry {
+	if encoded == nil {
+		return nil
+	}
+
+	return &LogEntry{
+		Identifier: d.decodeBytes("log identifier", encoded.Identifier),
+		Address:    d.decodeAddress("log address", encoded.Address),
+		Topics:     d.decodeBytesList("log topics", encoded.Topics),
+		Data:       d.decodeBytesList("log data", encoded.Data),
+	}
+}
+
+// decodeAddress reads the address with the address converter and falls back to hex, as the addresses that do not
+// have the converter length are written as hex
+func (d *jsonDecoder) decodeAddress(field string, encoded *string) []byte {
+	if encoded == nil || d.err != nil {
+		return nil
+	}
+	if d.codec.addressConverter != nil {
+		address, err := d.codec.addressConverter.Decode(*encoded)
+		if err == nil {
+			return address
+		}
+	}
+
+	return d.decodeHex(field, encoded)
+}
+
+func (d *jsonDecoder) decodeAddresses(field string, encoded []*string) [][]byte {
+	if encoded == nil {
+		return nil
+	}
+
+	addresses := make([][]byte, 0, len(encoded))
+	for _, address := range encoded {
+		addresses = append(addresses, d.decodeAddress(field, address))
+	}
+
+	return addresses
+}
+
+func (d *jsonDecoder) decodeHex(field string, encoded *string) []byte {
+	if encoded == nil || d.err != nil {
+		return nil
+	}
+
+	value, err := hex.DecodeString(*encoded)
+	if err != nil {
+		d.fail(field, err.Error())
+		return nil
+	}
+
+	return value
+}
+
+func (d *jsonDecoder) decodeBytes(field string, encoded *jsonBytes) []byte {
+	if encoded == nil {
+		return nil
+	}
+
+	return d.decodeHex(field, &encoded.Hex)
+}
+
+func (d *jsonDecoder) decodeBytesList(field string, encoded []*jsonBytes) [][]byte {
+	if encoded == nil {
+		return nil
+	}
+
+	values := make([][]byte, 0, len(encoded))
+	for _, value := range encoded {
+		values = append(values, d.decodeBytes(field, value))
+	}
+
+	return values
+}
+
+func (d *jsonDecoder) decodeBigInt(field string, encoded *string) *big.Int {
+	if encoded == nil || d.err != nil {
+		return nil
+	}
+
+	value, ok := big.NewInt(0).SetString(*encoded, 10)
+	if !ok {
+		d.fail(field, "not a decimal number")
+		return nil
+	}
+
+	return value
+}
+
+func (d *jsonDecoder) decodeCallType(field string, name string) vm.CallType {
+	for callType, callTypeName := range callTypeNames {
+		if callTypeName == name {
+			return callType
+		}
+	}
+
+	value, err := strconv.Atoi(name)
+	if err != nil {
+		d.fail(field, "unknown call type "+name)
+		return vm.DirectCall
+	}
+
+	return vm.CallType(value)
+}
+
+func (d *jsonDecoder) decodeReturnCode(name string) ReturnCode {
+	for returnCode := Ok; returnCode <= SimulateFailed; returnCode++ {
+		if returnCode.String() == name {
+			return returnCode
+		}
+	}
+
+	value, err := strconv.Atoi(name)
+	if err != nil {
+		d.fail("returnCode", "unknown return code "+name)
+		return Ok
+	}
+
+	return ReturnCode(value)
+}
@@ -0,0 +1,189 @@
+package vmcommon
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core/pubkeyConverter"
+	"github.com/subrahamanyam341/andes-core-16/data/vm"
+)
+
+func createVMJSONCodec(t *testing.T) *vmJSONCodec {
+	converter, err := pubkeyConverter.NewBech32PubkeyConverter(32, "erd")
+	require.Nil(t, err)
+
+	return NewVMJSONCodec(ArgsNewVMJSONCodec{
+		AddressConverter: converter,
+		WithUTF8Hints:    true,
+	})
+}
+
+func createVMInputForJSON() VMInput {
+	return VMInput{
+		CallerAddr: bytes.Repeat([]byte{1}, 32),
+		Arguments:  [][]byte{[]byte("arg"), {0xff}, {}, nil},
+		AsyncArguments: &AsyncArguments{
+			CallID:         []byte{1},
+			CallerCallID:   []byte{},
+			GasAccumulated: 5,
+		},
+		CallValue:      big.NewInt(-7),
+		CallType:       vm.AsynchronousCallBack,
+		GasPrice:       1,
+		GasProvided:    2,
+		GasLocked:      3,
+		OriginalTxHash: []byte("hash"),
+		DCTTransfers: []*DCTTransfer{
+			{DCTValue: big.NewInt(10), DCTTokenName: []byte("TOKEN-abcdef"), DCTTokenType: 1, DCTTokenNonce: 2},
+			nil,
+		},
+		ReturnCallAfterError: true,
+		TxGuardian:           []byte("short"),
+	}
+}
+
+func TestVMJSONCodec_ContractCallInput(t *testing.T) {
+	t.Parallel()
+
+	codec := createVMJSONCodec(t)
+	_, err := codec.EncodeContractCallInput(nil)
+	assert.Equal(t, ErrNilVMInput, err)
+
+	input := &ContractCallInput{
+		VMInput:           createVMInputForJSON(),
+		RecipientAddr:     bytes.Repeat([]byte{2}, 32),
+		Function:          "function",
+		AllowInitFunction: true,
+	}
+	buff, err := codec.EncodeContractCallInput(input)
+	require.Nil(t, err)
+
+	encoded := make(map[string]interface{})
+	require.Nil(t, json.Unmarshal(buff, &encoded))
+	assert.True(t, strings.HasPrefix(encoded["caller"].(string), "erd1"))
+	assert.Equal(t, "73686f7274", encoded["txGuardian"])
+	assert.Equal(t, "-7", encoded["callValue"])
+	assert.Equal(t, vm.AsynchronousCallBackStr, encoded["callType"])
+	assert.Equal(t, map[string]interface{}{"hex": "617267", "utf8": "arg"}, encoded["arguments"].([]interface{})[0])
+	assert.Equal(t, map[string]interface{}{"hex": "ff"}, encoded["arguments"].([]interface{})[1])
+
+	decoded, err := codec.DecodeContractCallInput(buff)
+	require.Nil(t, err)
+	assert.Equal(t, input, decoded)
+
+	decoded, err = NewVMJSONCodec(ArgsNewVMJSONCodec{}).DecodeContractCallInput(buff)
+	assert.Nil(t, decoded)
+	assert.True(t, errors.Is(err, ErrInvalidVMJSONEncoding))
+}
+
+func TestVMJSONCodec_ContractCreateInput(t *testing.T) {
+	t.Parallel()
+
+	codec := NewVMJSONCodec(ArgsNewVMJSONCodec{})
+	_, err := codec.EncodeContractCreateInput(nil)
+	assert.Equal(t, ErrNilVMInput, err)
+
+	input := &ContractCreateInput{
+		VMInput:              createVMInputForJSON(),
+		ContractCode:         []byte("code"),
+		ContractCodeMetadata: []byte{1, 0},
+	}
+	buff, err := codec.EncodeContractCreateInput(input)
+	require.Nil(t, err)
+	assert.NotContains(t, string(buff), "utf8")
+
+	decoded, err := codec.DecodeContractCreateInput(buff)
+	require.Nil(t, err)
+	assert.Equal(t, input, decoded)
+}
+
+func TestVMJSONCodec_VMOutput(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should round trip", func(t *testing.T) {
+		t.Parallel()
+
+		codec := createVMJSONCodec(t)
+		_, err := codec.EncodeVMOutput(nil)
+		assert.Equal(t, ErrNilVMOutput, err)
+
+		vmOutputs := []*VMOutput{
+			createVMOutputForEncoding(),
+			{},
+			{
+				ReturnCode: ReturnCode(100),
+				OutputAccounts: map[string]*OutputAccount{
+					string(bytes.Repeat([]byte{3}, 32)): {
+						Address:         bytes.Repeat([]byte{3}, 32),
+						StorageUpdates:  map[string]*StorageUpdate{"": nil, "\x00": {Data: []byte{}}},
+						OutputTransfers: []OutputTransfer{{CallType: vm.CallType(42)}},
+					},
+					"nil account": nil,
+				},
+				DeletedAccounts: [][]byte{},
+				Logs:            []*LogEntry{nil, {Topics: [][]byte{}}},
+			},
+		}
+		for _, vmOutput := range vmOutputs {
+			buff, errEncode := codec.EncodeVMOutput(vmOutput)
+			require.Nil(t, errEncode)
+
+			decoded, errDecode := codec.DecodeVMOutput(buff)
+			require.Nil(t, errDecode)
+			assert.Equal(t, vmOutput, decoded)
+		}
+	})
+	t.Run("storage trace should not be encoded", func(t *testing.T) {
+		t.Parallel()
+
+		codec := createVMJSONCodec(t)
+		expected, err := codec.EncodeVMOutput(createVMOutputForEncoding())
+		require.Nil(t, err)
+
+		traced := createVMOutputForEncoding()
+		traced.StorageTrace = &StorageTrace{
+			Function: "function",
+			Accesses: []*StorageAccess{{Kind: SaveKeyValueAccess, Address: []byte("address1"), Key: []byte("key1"), Value: []byte("value")}},
+		}
+		buff, err := codec.EncodeVMOutput(traced)
+		require.Nil(t, err)
+		assert.Equal(t, expected, buff)
+
+		decoded, err := codec.DecodeVMOutput(buff)
+		require.Nil(t, err)
+		assert.Nil(t, decoded.StorageTrace)
+	})
+	t.Run("enums should be written by name", func(t *testing.T) {
+		t.Parallel()
+
+		buff, err := createVMJSONCodec(t).EncodeVMOutput(createVMOutputForEncoding())
+		require.Nil(t, err)
+		assert.Contains(t, string(buff), `"returnCode":"user error"`)
+		assert.Contains(t, string(buff), `"callType":"asynchronousCall"`)
+		assert.Contains(t, string(buff), `"gasRefund":"-5"`)
+	})
+	t.Run("invalid values should error", func(t *testing.T) {
+		t.Parallel()
+
+		invalidEncodings := map[string]string{
+			"not json":            `[`,
+			"unknown return code": `{"returnCode":"fine"}`,
+			"invalid big int":     `{"returnCode":"ok","gasRefund":"1.5"}`,
+			"invalid hex":         `{"returnCode":"ok","returnData":[{"hex":"xyz"}]}`,
+			"unknown call type":   `{"returnCode":"ok","outputAccounts":{"00":{"outputTransfers":[{"callType":"other"}]}}}`,
+		}
+
+		codec := createVMJSONCodec(t)
+		for name, invalid := range invalidEncodings {
+			decoded, err := codec.DecodeVMOutput([]byte(invalid))
+			assert.Nil(t, decoded, name)
+			assert.True(t, errors.Is(err, ErrInvalidVMJSONEncoding), name)
+		}
+	})
+}
@@ -0,0 +1,492 @@
+package vmcommon
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DifferenceKind tells which part of the VM outputs is different
+type DifferenceKind int
+
+const (
+	// ReturnCodeDifference signals different return codes
+	ReturnCodeDifference DifferenceKind = iota
+	// ReturnMessageDifference signals different return messages
+	ReturnMessageDifference
+	// GasRemainingDifference signals different remaining gas
+	GasRemainingDifference
+	// GasRefundDifference signals different gas refunds
+	GasRefundDifference
+	// AccountAddedDifference signals an output account found only in the second output
+	AccountAddedDifference
+	// AccountRemovedDifference signals an output account found only in the first output
+	AccountRemovedDifference
+	// BalanceDeltaDifference signals different balance deltas of an output account
+	BalanceDeltaDifference
+	// NonceDifference signals different nonces of an output account
+	NonceDifference
+	// CodeDifference signals different codes of an output account
+	CodeDifference
+	// CodeMetadataDifference signals different code metadata of an output account
+	CodeMetadataDifference
+	// StorageAddedDifference signals a storage update found only in the second output
+	StorageAddedDifference
+	// StorageRemovedDifference signals a storage update found only in the first output
+	StorageRemovedDifference
+	// StorageChangedDifference signals a storage update with different values
+	StorageChangedDifference
+	// TransferAddedDifference signals an output transfer index found only in the second output
+	TransferAddedDifference
+	// TransferRemovedDifference signals an output transfer index found only in the first output
+	TransferRemovedDifference
+	// TransferChangedDifference signals output transfers with the same index but different fields
+	TransferChangedDifference
+	// LogAddedDifference signals a log entry found only in the second output
+	LogAddedDifference
+	// LogRemovedDifference signals a log entry found only in the first output
+	LogRemovedDifference
+	// LogChangedDifference signals log entries with the same identifier and topics but different address or data
+	LogChangedDifference
+)
+
+// String returns the human-readable name of the difference kind
+func (kind DifferenceKind) String() string {
+	switch kind {
+	case ReturnCodeDifference:
+		return "return code"
+	case ReturnMessageDifference:
+		return "return message"
+	case GasRemainingDifference:
+		return "gas remaining"
+	case GasRefundDifference:
+		return "gas refund"
+	case AccountAddedDifference:
+		return "account added"
+	case AccountRemovedDifference:
+		return "account removed"
+	case BalanceDeltaDifference:
+		return "balance delta"
+	case NonceDifference:
+		return "nonce"
+	case CodeDifference:
+		return "code"
+	case CodeMetadataDifference:
+		return "code metadata"
+	case StorageAddedDifference:
+		return "storage added"
+	case StorageRemovedDifference:
+		return "storage removed"
+	case StorageChangedDifference:
+		return "storage changed"
+	case TransferAddedDifference:
+		return "transfer added"
+	case TransferRemovedDifference:
+		return "transfer removed"
+	case TransferChangedDifference:
+		return "transfer changed"
+	case LogAddedDifference:
+		return "log added"
+	case LogRemovedDifference:
+		return "log removed"
+	case LogChangedDifference:
+		return "log changed"
+	default:
+		return fmt.Sprintf("unknown difference: %d", int(kind))
+	}
+}
+
+// VMOutputDifference is a single difference between two VM outputs. First and Second hold the values from the first
+// and the second output, with the type of the compared field: ReturnCode, string, uint64, *big.Int, []byte,
+// *OutputAccount, *StorageUpdate, *OutputTransfer or *LogEntry. The value is nil when the item is missing.
+type VMOutputDifference struct {
+	Kind DifferenceKind
+	// Account is the key of the output account, empty for the differences of the output itself
+	Account []byte
+	// Key is the storage key for the storage differences
+	Key []byte
+	// Index is the transfer index for the transfer differences and the position in the first output (or in the
+	// second one, if missing from the first) for the log differences
+	Index  uint32
+	First  interface{}
+	Second interface{}
+}
+
+// String returns the difference in a human-readable form
+func (diff *VMOutputDifference) String() string {
+	location := ""
+	if len(diff.Account) > 0 {
+		location += " account " + formatDiffBytes(diff.Account)
+	}
+	switch diff.Kind {
+	case StorageAddedDifference, StorageRemovedDifference, StorageChangedDifference:
+		location += " key " + formatDiffBytes(diff.Key)
+	case TransferAddedDifference, TransferRemovedDifference, TransferChangedDifference:
+		location += fmt.Sprintf(" index %d", diff.Index)
+	case LogAddedDifference, LogRemovedDifference, LogChangedDifference:
+		location += fmt.Sprintf(" position %d", diff.Index)
+	}
+
+	return fmt.Sprintf("%s%s: %s -> %s", diff.Kind, location, formatDiffValue(diff.First), formatDiffValue(diff.Second))
+}
+
+// VMOutputDifferences is the list of differences between two VM outputs
+type VMOutputDifferences []*VMOutputDifference
+
+// String returns the differences in a human-readable form, one per line
+func (diffs VMOutputDifferences) String() string {
+	lines := make([]string, 0, len(diffs))
+	for _, diff := range diffs {
+		lines = append(lines, diff.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// DiffVMOutputs returns the differences between two VM outputs, in a deterministic order: first the ones of the
+// outputs themselves, then the ones of the output accounts sorted by their keys and finally the ones of the logs.
+// The transfers are matched by their index and the logs by their identifier and topics. A nil output is compared
+// as an empty one and a nil big int as zero.
+func DiffVMOutputs(first, second *VMOutput) VMOutputDifferences {
+	if first == nil {
+		first = &VMOutput{}
+	}
+	if second == nil {
+		second = &VMOutput{}
+	}
+
+	diffs := make(VMOutputDifferences, 0)
+	addDiff := func(kind DifferenceKind, firstValue, secondValue interface{}) {
+		diffs = append(diffs, &VMOutputDifference{Kind: kind, First: firstValue, Second: secondValue})
+	}
+
+	if first.ReturnCode != second.ReturnCode {
+		addDiff(ReturnCodeDifference, first.ReturnCode, second.ReturnCode)
+	}
+	if first.ReturnMessage != second.ReturnMessage {
+		addDiff(ReturnMessageDifference, first.ReturnMessage, second.ReturnMessage)
+	}
+	if first.GasRemaining != second.GasRemaining {
+		addDiff(GasRemainingDifference, first.GasRemaining, second.GasRemaining)
+	}
+	if ZeroValueIfNil(first.GasRefund).Cmp(ZeroValueIfNil(second.GasRefund)) != 0 {
+		addDiff(GasRefundDifference, first.GasRefund, second.GasRefund)
+	}
+
+	diffs = append(diffs, diffOutputAccounts(first.OutputAccounts, second.OutputAccounts)...)
+	diffs = append(diffs, diffLogs(first.Logs, second.Logs)...)
+
+	return diffs
+}
+
+func diffOutputAccounts(first, second map[string]*OutputAccount) VMOutputDifferences {
+	keys := make(map[string]struct{})
+	for key := range first {
+		keys[key] = struct{}{}
+	}
+	for key := range second {
+		keys[key] = struct{}{}
+	}
+
+	diffs := make(VMOutputDifferences, 0)
+	for _, key := range sortedKeys(keys) {
+		firstAccount, foundInFirst := first[key]
+		secondAccount, foundInSecond := second[key]
+		switch {
+		case !foundInFirst:
+			diffs = append(diffs, &VMOutputDifference{Kind: AccountAddedDifference, Account: []byte(key), Second: secondAccount})
+		case !foundInSecond:
+			diffs = append(diffs, &VMOutputDifference{Kind: AccountRemovedDifference, Account: []byte(key), First: firstAccount})
+		default:
+			diffs = append(diffs, diffOutputAccount([]byte(key), firstAccount, secondAccount)...)
+		}
+	}
+
+	return diffs
+}
+
+func diffOutputAccount(key []byte, first, second *OutputAccount) VMOutputDifferences {
+	if first == nil {
+		first = &OutputAccount{}
+	}
+	if second == nil {
+		second = &OutputAccount{}
+	}
+
+	diffs := make(VMOutputDifferences, 0)
+	addDiff := func(kind DifferenceKind, firstValue, secondValue interface{}) {
+		diffs = append(diffs, &VMOutputDifference{Kind: kind, Account: key, First: firstValue, Second: secondValue})
+	}
+
+	if ZeroValueIfNil(first.BalanceDelta).Cmp(ZeroValueIfNil(second.BalanceDelta)) != 0 {
+		addDiff(BalanceDeltaDifference, first.BalanceDelta, second.BalanceDelta)
+	}
+	if first.Nonce != second.Nonce {
+		addDiff(NonceDifference, first.Nonce, second.Nonce)
+	}
+	if !bytes.Equal(first.Code, second.Code) {
+		addDiff(CodeDifference, first.Code, second.Code)
+	}
+	if !bytes.Equal(first.CodeMetadata, second.CodeMetadata) {
+		addDiff(CodeMetadataDifference, first.CodeMetadata, second.CodeMetadata)
+	}
+
+	diffs = append(diffs, diffStorageUpdates(key, first.StorageUpdates, second.StorageUpdates)...)
+	diffs = append(diffs, diffOutputTransfers(key, first.OutputTransfers, second.OutputTransfers)...)
+
+	return diffs
+}
+
+func diffStorageUpdates(account []byte, first, second map[string]*StorageUpdate) VMOutputDifferences {
+	keys := make(map[string]struct{})
+	for key := range first {
+		keys[key] = struct{}{}
+	}
+	for key := range second {
+		keys[key] = struct{}{}
+	}
+
+	diffs := make(VMOutputDifferences, 0)
+	for _, key := range sortedKeys(keys) {
+		firstUpdate, foundInFirst := first[key]
+		secondUpdate, foundInSecond := second[key]
+		diff := &VMOutputDifference{Account: account, Key: []byte(key), First: firstUpdate, Second: secondUpdate}
+		switch {
+		case !foundInFirst:
+			diff.Kind = StorageAddedDifference
+		case !foundInSecond:
+			diff.Kind = StorageRemovedDifference
+		case !areStorageUpdatesEqual(firstUpdate, secondUpdate):
+			diff.Kind = StorageChangedDifference
+		default:
+			continue
+		}
+
+		diffs = append(diffs, diff)
+	}
+
+	return diffs
+}
+
+// areStorageUpdatesEqual compares the updates field by field, a nil update or slice being equal to an empty one
+func areStorageUpdatesEqual(first, second *StorageUpdate) bool {
+	if first == nil {
+		first = &StorageUpdate{}
+	}
+	if second == nil {
+		second = &StorageUpdate{}
+	}
+
+	return bytes.Equal(first.Offset, second.Offset) &&
+		bytes.Equal(first.Data, second.Data) &&
+		first.Written == second.Written
+}
+
+func diffOutputTransfers(account []byte, first, second []OutputTransfer) VMOutputDifferences {
+	firstByIndex := indexOutputTransfers(first)
+	secondByIndex := indexOutputTransfers(second)
+	indexes := make([]uint32, 0, len(firstByIndex)+len(secondByIndex))
+	for index := range firstByIndex {
+		indexes = append(indexes, index)
+	}
+	for index := range secondByIndex {
+		if _, found := firstByIndex[index]; !found {
+			indexes = append(indexes, index)
+		}
+	}
+	sort.Slice(indexes, func(i, j int) bool {
+		return indexes[i] < indexes[j]
+	})
+
+	diffs := make(VMOutputDifferences, 0)
+	for _, index := range indexes {
+		firstTransfer, foundInFirst := firstByIndex[index]
+		secondTransfer, foundInSecond := secondByIndex[index]
+		diff := &VMOutputDifference{Account: account, Index: index}
+		switch {
+		case !foundInFirst:
+			diff.Kind = TransferAddedDifference
+			diff.Second = secondTransfer
+		case !foundInSecond:
+			diff.Kind = TransferRemovedDifference
+			diff.First = firstTransfer
+		case !areOutputTransfersEqual(firstTransfer, secondTransfer):
+			diff.Kind = TransferChangedDifference
+			diff.First = firstTransfer
+			diff.Second = secondTransfer
+		default:
+			continue
+		}
+
+		diffs = append(diffs, diff)
+	}
+
+	return diffs
+}
+
+// indexOutputTransfers maps the transfers by their index. If more transfers have the same index, the first one is kept.
+func indexOutputTransfers(transfers []OutputTransfer) map[uint32]*OutputTransfer {
+	byIndex := make(map[uint32]*OutputTransfer, len(transfers))
+	for i := range transfers {
+		_, found := byIndex[transfers[i].Index]
+		if !found {
+			byIndex[transfers[i].Index] = &transfers[i]
+		}
+	}
+
+	return byIndex
+}
+
+func areOutputTransfersEqual(first, second *OutputTransfer) bool {
+	return ZeroValueIfNil(first.Value).Cmp(ZeroValueIfNil(second.Value)) == 0 &&
+		first.GasLimit == second.GasLimit &&
+		first.GasLocked == second.GasLocked &&
+		bytes.Equal(first.AsyncData, second.AsyncData) &&
+		bytes.Equal(first.Data, second.Data) &&
+		first.CallType == second.CallType &&
+		bytes.Equal(first.SenderAddress, second.SenderAddress)
+}
+
+// diffLogs matches the logs with the same identifier and topics in their order, so that the n-th such log from the
+// first output is compared with the n-th one from the second output
+func diffLogs(first, second []*LogEntry) VMOutputDifferences {
+	unmatched := make(map[string][]int)
+	for i, logEntry := range second {
+		key := logMatchKey(logEntry)
+		unmatched[key] = append(unmatched[key], i)
+	}
+
+	diffs := make(VMOutputDifferences, 0)
+	for i, logEntry := range first {
+		key := logMatchKey(logEntry)
+		candidates := unmatched[key]
+		if len(candidates) == 0 {
+			diffs = append(diffs, &VMOutputDifference{Kind: LogRemovedDifference, Index: uint32(i), First: logEntry})
+			continue
+		}
+
+		secondEntry := second[candidates[0]]
+		unmatched[key] = candidates[1:]
+		if !areLogEntriesEqual(logEntry, secondEntry) {
+			diffs = append(diffs, &VMOutputDifference{Kind: LogChangedDifference, Index: uint32(i), First: logEntry, Second: secondEntry})
+		}
+	}
+
+	added := make([]int, 0)
+	for _, positions := range unmatched {
+		added = append(added, positions...)
+	}
+	sort.Ints(added)
+	for _, position := range added {
+		diffs = append(diffs, &VMOutputDifference{Kind: LogAddedDifference, Index: uint32(position), Second: second[position]})
+	}
+
+	return diffs
+}
+
+// areLogEntriesEqual compares the logs field by field, a nil log or slice being equal to an empty one
+func areLogEntriesEqual(first, second *LogEntry) bool {
+	if first == nil {
+		first = &LogEntry{}
+	}
+	if second == nil {
+		second = &LogEntry{}
+	}
+
+	return bytes.Equal(first.Identifier, second.Identifier) &&
+		bytes.Equal(first.Address, second.Address) &&
+		areBytesListsEqual(first.Topics, second.Topics) &&
+		areBytesListsEqual(first.Data, second.Data)
+}
+
+func areBytesListsEqual(first, second [][]byte) bool {
+	if len(first) != len(second) {
+		return false
+	}
+	for i := range first {
+		if !bytes.Equal(first[i], second[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func logMatchKey(logEntry *LogEntry) string {
+	if logEntry == nil {
+		return ""
+	}
+
+	parts := make([]string, 0, len(logEntry.Topics)+1)
+	parts = append(parts, hex.EncodeToString(logEntry.Identifier))
+	for _, topic := range logEntry.Topics {
+		parts = append(parts, hex.EncodeToString(topic))
+	}
+
+	return "@" + strings.Join(parts, "@")
+}
+
+func formatDiffValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "<missing>"
+	case []byte:
+		return formatDiffBytes(v)
+	case string:
+		return fmt.Sprintf("%q", v)
+	case *big.Int:
+		return ZeroValueIfNil(v).String()
+	case *OutputAccount:
+		return fmt.Sprintf("{balance delta: %s, nonce: %d}", ZeroValueIfNil(v.BalanceDelta), v.Nonce)
+	case *StorageUpdate:
+		return fmt.Sprintf("{data: %s, written: %t}", formatDiffBytes(v.Data), v.Written)
+	case *OutputTransfer:
+		return fmt.Sprintf("{value: %s, gas limit: %d, gas locked: %d, data: %s, async data: %s, call type: %d, sender: %s}",
+			ZeroValueIfNil(v.Value), v.GasLimit, v.GasLocked, formatDiffBytes(v.Data), formatDiffBytes(v.AsyncData),
+			v.CallType, formatDiffBytes(v.SenderAddress))
+	case *LogEntry:
+		return fmt.Sprintf("{identifier: %s, address: %s, topics: %s, data: %s}",
+			formatDiffBytes(v.Identifier), formatDiffBytes(v.Address), formatDiffBytesList(v.Topics), formatDiffBytesList(v.Data))
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// formatDiffBytes returns the bytes as a quoted string if they are printable text, or as hex otherwise
+func formatDiffBytes(value []byte) string {
+	if len(value) == 0 {
+		return `""`
+	}
+	if isPrintableText(value) {
+		return fmt.Sprintf("%q", value)
+	}
+
+	return "0x" + hex.EncodeToString(value)
+}
+
+func formatDiffBytesList(values [][]byte) string {
+	formatted := make([]string, 0, len(values))
+	for _, value := range values {
+		formatted = append(formatted, formatDiffBytes(value))
+	}
+
+	return "[" + strings.Join(formatted, ", ") + "]"
+}
+
+func isPrintableText(value []byte) bool {
+	if !utf8.Valid(value) {
+		return false
+	}
+	for _, r := range string(value) {
+		if !unicode.IsPrint(r) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,152 @@
+package vmcommon
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffVMOutputs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("equal outputs should have no differences", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Empty(t, DiffVMOutputs(createVMOutputForEncoding(), createVMOutputForEncoding()))
+		assert.Empty(t, DiffVMOutputs(nil, &VMOutput{}))
+		assert.Empty(t, DiffVMOutputs(&VMOutput{GasRefund: big.NewInt(0)}, &VMOutput{}))
+	})
+	t.Run("storage trace should be ignored", func(t *testing.T) {
+		t.Parallel()
+
+		traced := createVMOutputForEncoding()
+		traced.StorageTrace = &StorageTrace{
+			Function: "function",
+			Accesses: []*StorageAccess{{Kind: SaveKeyValueAccess, Address: []byte("address1"), Key: []byte("key1"), Value: []byte("value")}},
+		}
+		assert.Empty(t, DiffVMOutputs(createVMOutputForEncoding(), traced))
+	})
+	t.Run("nil and empty values should be equal", func(t *testing.T) {
+		t.Parallel()
+
+		first := &VMOutput{
+			OutputAccounts: map[string]*OutputAccount{
+				"address": {
+					BalanceDelta:   big.NewInt(0),
+					StorageUpdates: map[string]*StorageUpdate{"key": {Offset: []byte("key"), Data: []byte{}}},
+				},
+			},
+			Logs: []*LogEntry{{Identifier: []byte("a"), Address: []byte{}, Topics: [][]byte{{}}, Data: [][]byte{}}},
+		}
+		second := &VMOutput{
+			OutputAccounts: map[string]*OutputAccount{
+				"address": {
+					StorageUpdates: map[string]*StorageUpdate{"key": {Offset: []byte("key")}},
+				},
+			},
+			Logs: []*LogEntry{{Identifier: []byte("a"), Topics: [][]byte{nil}}},
+		}
+
+		assert.Empty(t, DiffVMOutputs(first, second))
+	})
+	t.Run("should report the differences in order", func(t *testing.T) {
+		t.Parallel()
+
+		first := createVMOutputForEncoding()
+		second := createVMOutputForEncoding()
+		second.ReturnCode = Ok
+		second.GasRemaining = 50
+		account := second.OutputAccounts["address1"]
+		account.BalanceDelta = big.NewInt(5)
+		account.Nonce = 4
+		account.Code = []byte{0, 0x61, 0x73, 0x6d}
+		delete(account.StorageUpdates, "key1")
+		account.StorageUpdates["key2"].Data = []byte("other")
+		account.StorageUpdates["key3"] = &StorageUpdate{Offset: []byte("key3"), Data: []byte{0xff}}
+		account.OutputTransfers[0].Value = big.NewInt(8)
+		account.OutputTransfers = append(account.OutputTransfers, OutputTransfer{Index: 2})
+		delete(second.OutputAccounts, "address2")
+		second.OutputAccounts["address3"] = &OutputAccount{Address: []byte("address3")}
+		second.Logs[0].Data = [][]byte{[]byte("other")}
+		second.Logs = append(second.Logs, &LogEntry{Identifier: []byte("new")})
+
+		diffs := DiffVMOutputs(first, second)
+		kinds := make([]DifferenceKind, 0, len(diffs))
+		for _, diff := range diffs {
+			kinds = append(kinds, diff.Kind)
+		}
+		expectedKinds := []DifferenceKind{
+			ReturnCodeDifference,
+			GasRemainingDifference,
+			BalanceDeltaDifference,
+			NonceDifference,
+			CodeDifference,
+			StorageRemovedDifference,
+			StorageChangedDifference,
+			StorageAddedDifference,
+			TransferChangedDifference,
+			TransferAddedDifference,
+			AccountRemovedDifference,
+			AccountAddedDifference,
+			LogChangedDifference,
+			LogAddedDifference,
+		}
+		require.Equal(t, expectedKinds, kinds)
+
+		assert.Equal(t, UserError, diffs[0].First)
+		assert.Equal(t, Ok, diffs[0].Second)
+		assert.Equal(t, []byte("key1"), diffs[5].Key)
+		assert.Equal(t, uint32(2), diffs[9].Index)
+		assert.Nil(t, diffs[9].First)
+		assert.Equal(t, []byte("address2"), diffs[10].Account)
+		assert.Equal(t, uint32(1), diffs[13].Index)
+	})
+	t.Run("logs should be matched by identifier and topics", func(t *testing.T) {
+		t.Parallel()
+
+		createLog := func(identifier string, topic string) *LogEntry {
+			return &LogEntry{Identifier: []byte(identifier), Topics: [][]byte{[]byte(topic)}}
+		}
+		first := &VMOutput{Logs: []*LogEntry{createLog("a", "1"), createLog("b", "1"), createLog("a", "1")}}
+		second := &VMOutput{Logs: []*LogEntry{createLog("a", "1"), createLog("a", "1"), createLog("b", "2")}}
+
+		diffs := DiffVMOutputs(first, second)
+		require.Len(t, diffs, 2)
+		assert.Equal(t, LogRemovedDifference, diffs[0].Kind)
+		assert.Equal(t, uint32(1), diffs[0].Index)
+		assert.Equal(t, LogAddedDifference, diffs[1].Kind)
+		assert.Equal(t, uint32(2), diffs[1].Index)
+	})
+}
+
+func TestVMOutputDifferences_String(t *testing.T) {
+	t.Parallel()
+
+	first := &VMOutput{
+		ReturnMessage: "message",
+		OutputAccounts: map[string]*OutputAccount{
+			"address": {
+				StorageUpdates: map[string]*StorageUpdate{
+					"key": {Data: []byte{1, 2}},
+				},
+			},
+		},
+	}
+	second := &VMOutput{
+		OutputAccounts: map[string]*OutputAccount{
+			"address": {
+				BalanceDelta: big.NewInt(-3),
+				StorageUpdates: map[string]*StorageUpdate{
+					"key": {Data: []byte("value"), Written: true},
+				},
+			},
+		},
+	}
+
+	expected := `return message: "message" -> ""` + "\n" +
+		`balance delta account "address": 0 -> -3` + "\n" +
+		`storage changed account "address" key "key": {data: 0x0102, written: false} -> {data: "value", written: true}`
+	assert.Equal(t, expected, DiffVMOutputs(first, second).String())
+}
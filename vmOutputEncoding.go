@@ -0,0 +1,446 @@
+package vmcommon
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	"github.com/subrahamanyam341/andes-core-16/data/vm"
+	"github.com/subrahamanyam341/andes-core-16/hashing"
+)
+
+const vmOutputEncodingVersion = byte(1)
+
+const (
+	absentValue  = byte(0)
+	presentValue = byte(1)
+)
+
+// EncodeCanonical encodes the output in a binary format that only depends on its content. The output accounts and
+// the storage updates are written sorted by their map keys, so two equal outputs always have the same encoding.
+// The slices keep their order, as it is part of the execution result. A nil and an empty slice or map are
+// encoded the same way, while a nil big int is distinguished from zero.
+func (vmOutput *VMOutput) EncodeCanonical() []byte {
+	w := &canonicalWriter{}
+	w.buff.WriteByte(vmOutputEncodingVersion)
+
+	w.writeBytesList(vmOutput.ReturnData)
+	w.writeUint64(uint64(vmOutput.ReturnCode))
+	w.writeBytes([]byte(vmOutput.ReturnMessage))
+	w.writeUint64(vmOutput.GasRemaining)
+	w.writeBigInt(vmOutput.GasRefund)
+
+	keys := sortedKeys(vmOutput.OutputAccounts)
+	w.writeUint64(uint64(len(keys)))
+	for _, key := range keys {
+		w.writeBytes([]byte(key))
+		w.writeOutputAccount(vmOutput.OutputAccounts[key])
+	}
+
+	w.writeBytesList(vmOutput.DeletedAccounts)
+	w.writeBytesList(vmOutput.TouchedAccounts)
+
+	w.writeUint64(uint64(len(vmOutput.Logs)))
+	for _, logEntry := range vmOutput.Logs {
+		w.writeLogEntry(logEntry)
+	}
+
+	return w.buff.Bytes()
+}
+
+// Hash returns the hash of the canonical encoding of the output
+func (vmOutput *VMOutput) Hash(hasher hashing.Hasher) ([]byte, error) {
+	if check.IfNil(hasher) {
+		return nil, ErrNilHasher
+	}
+
+	return hasher.Compute(string(vmOutput.EncodeCanonical())), nil
+}
+
+// DecodeVMOutput decodes an output from its canonical encoding
+func DecodeVMOutput(buff []byte) (*VMOutput, error) {
+	r := &canonicalReader{buff: buff}
+	version := r.readByte()
+	if r.err == nil && version != vmOutputEncodingVersion {
+		return nil, fmt.Errorf("%w: unknown version %d", ErrInvalidVMOutputEncoding, version)
+	}
+
+	vmOutput := &VMOutput{}
+	vmOutput.ReturnData = r.readBytesList()
+	vmOutput.ReturnCode = ReturnCode(r.readUint64())
+	vmOutput.ReturnMessage = string(r.readBytes())
+	vmOutput.GasRemaining = r.readUint64()
+	vmOutput.GasRefund = r.readBigInt()
+
+	numAccounts := r.readLength()
+	if numAccounts > 0 {
+		vmOutput.OutputAccounts = make(map[string]*OutputAccount, numAccounts)
+	}
+	for i := 0; i < numAccounts && r.err == nil; i++ {
+		key := string(r.readBytes())
+		vmOutput.OutputAccounts[key] = r.readOutputAccount()
+	}
+
+	vmOutput.DeletedAccounts = r.readBytesList()
+	vmOutput.TouchedAccounts = r.readBytesList()
+
+	numLogs := r.readLength()
+	for i := 0; i < numLogs && r.err == nil; i++ {
+		vmOutput.Logs = append(vmOutput.Logs, r.readLogEntry())
+	}
+
+	if r.err == nil && len(r.buff) > 0 {
+		r.err = fmt.Errorf("%w: %d trailing bytes", ErrInvalidVMOutputEncoding, len(r.buff))
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return vmOutput, nil
+}
+
+type vmOutputMarshalizer struct {
+}
+
+// NewVMOutputMarshalizer creates a marshalizer that uses the canonical encoding. It only accepts *VMOutput objects.
+func NewVMOutputMarshalizer() *vmOutputMarshalizer {
+	return &vmOutputMarshalizer{}
+}
+
+// Marshal returns the canonical encoding of the provided output
+func (marshalizer *vmOutputMarshalizer) Marshal(obj interface{}) ([]byte, error) {
+	vmOutput, ok := obj.(*VMOutput)
+	if !ok || vmOutput == nil {
+		return nil, ErrWrongTypeAssertion
+	}
+
+	return vmOutput.EncodeCanonical(), nil
+}
+
+// Unmarshal decodes the canonical encoding into the provided output
+func (marshalizer *vmOutputMarshalizer) Unmarshal(obj interface{}, buff []byte) error {
+	vmOutput, ok := obj.(*VMOutput)
+	if !ok || vmOutput == nil {
+		return ErrWrongTypeAssertion
+	}
+
+	decoded, err := DecodeVMOutput(buff)
+	if err != nil {
+		return err
+	}
+
+	*vmOutput = *decoded
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (marshalizer *vmOutputMarshalizer) IsInterfaceNil() bool {
+	return marshalizer == nil
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+type canonicalWriter struct {
+	buff bytes.Buffer
+}
+
+func (w *canonicalWriter) writeUint64(value uint64) {
+	var varint [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(varint[:], value)
+	w.buff.Write(varint[:n])
+}
+
+func (w *canonicalWriter) writeBool(value bool) {
+	if value {
+		w.buff.WriteByte(1)
+		return
+	}
+	w.buff.WriteByte(0)
+}
+
+func (w *canonicalWriter) writeBytes(value []byte) {
+	w.writeUint64(uint64(len(value)))
+	w.buff.Write(value)
+}
+
+func (w *canonicalWriter) writeBytesList(values [][]byte) {
+	w.writeUint64(uint64(len(values)))
+	for _, value := range values {
+		w.writeBytes(value)
+	}
+}
+
+func (w *canonicalWriter) writeBigInt(value *big.Int) {
+	if value == nil {
+		w.buff.WriteByte(absentValue)
+		return
+	}
+
+	w.buff.WriteByte(presentValue)
+	w.writeBool(value.Sign() < 0)
+	w.writeBytes(value.Bytes())
+}
+
+func (w *canonicalWriter) writeOutputAccount(account *OutputAccount) {
+	if account == nil {
+		w.buff.WriteByte(absentValue)
+		return
+	}
+
+	w.buff.WriteByte(presentValue)
+	w.writeBytes(account.Address)
+	w.writeUint64(account.Nonce)
+	w.writeBigInt(account.Balance)
+
+	keys := sortedKeys(account.StorageUpdates)
+	w.writeUint64(uint64(len(keys)))
+	for _, key := range keys {
+		w.writeBytes([]byte(key))
+		w.writeStorageUpdate(account.StorageUpdates[key])
+	}
+
+	w.writeBytes(account.Code)
+	w.writeBytes(account.CodeMetadata)
+	w.writeBytes(account.CodeDeployerAddress)
+	w.writeBigInt(account.BalanceDelta)
+
+	w.writeUint64(uint64(len(account.OutputTransfers)))
+	for _, transfer := range account.OutputTransfers {
+		w.writeOutputTransfer(transfer)
+	}
+
+	w.writeUint64(account.GasUsed)
+	w.writeUint64(account.BytesAddedToStorage)
+	w.writeUint64(account.BytesDeletedFromStorage)
+	w.writeUint64(account.BytesConsumedByTxAsNetworking)
+}
+
+func (w *canonicalWriter) writeStorageUpdate(update *StorageUpdate) {
+	if update == nil {
+		w.buff.WriteByte(absentValue)
+		return
+	}
+
+	w.buff.WriteByte(presentValue)
+	w.writeBytes(update.Offset)
+	w.writeBytes(update.Data)
+	w.writeBool(update.Written)
+}
+
+func (w *canonicalWriter) writeOutputTransfer(transfer OutputTransfer) {
+	w.writeUint64(uint64(transfer.Index))
+	w.writeBigInt(transfer.Value)
+	w.writeUint64(transfer.GasLimit)
+	w.writeUint64(transfer.GasLocked)
+	w.writeBytes(transfer.AsyncData)
+	w.writeBytes(transfer.Data)
+	w.writeUint64(uint64(transfer.CallType))
+	w.writeBytes(transfer.SenderAddress)
+}
+
+func (w *canonicalWriter) writeLogEntry(logEntry *LogEntry) {
+	if logEntry == nil {
+		w.buff.WriteByte(absentValue)
+		return
+	}
+
+	w.buff.WriteByte(presentValue)
+	w.writeBytes(logEntry.Identifier)
+	w.writeBytes(logEntry.Address)
+	w.writeBytesList(logEntry.Topics)
+	w.writeBytesList(logEntry.Data)
+}
+
+// canonicalReader reads the values written by the canonical writer. After the first error all the reads return
+// zero values, so the error is checked only once, at the end.
+type canonicalReader struct {
+	buff []byte
+	err  error
+}
+
+func (r *canonicalReader) fail(what string) {
+	if r.err == nil {
+		r.err = fmt.Errorf("%w: cannot read %s", ErrInvalidVMOutputEncoding, what)
+	}
+	r.buff = nil
+}
+
+func (r *canonicalReader) readByte() byte {
+	if r.err != nil || len(r.buff) == 0 {
+		r.fail("byte")
+		return 0
+	}
+
+	value := r.buff[0]
+	r.buff = r.buff[1:]
+	return value
+}
+
+func (r *canonicalReader) readUint64() uint64 {
+	if r.err != nil {
+		return 0
+	}
+
+	value, n := binary.Uvarint(r.buff)
+	if n <= 0 {
+		r.fail("integer")
+		return 0
+	}
+
+	r.buff = r.buff[n:]
+	return value
+}
+
+// readLength reads a length and checks it against the remaining bytes, as each item takes at least one byte
+func (r *canonicalReader) readLength() int {
+	length := r.readUint64()
+	if length > uint64(len(r.buff)) {
+		r.fail("length")
+		return 0
+	}
+
+	return int(length)
+}
+
+func (r *canonicalReader) readBool() bool {
+	value := r.readByte()
+	if value > 1 {
+		r.fail("bool")
+		return false
+	}
+
+	return value == 1
+}
+
+func (r *canonicalReader) isPresent() bool {
+	return r.readBool()
+}
+
+func (r *canonicalReader) readBytes() []byte {
+	length := r.readLength()
+	if r.err != nil || length == 0 {
+		return nil
+	}
+
+	value := make([]byte, length)
+	copy(value, r.buff)
+	r.buff = r.buff[length:]
+	return value
+}
+
+func (r *canonicalReader) readBytesList() [][]byte {
+	length := r.readLength()
+	var values [][]byte
+	for i := 0; i < length && r.err == nil; i++ {
+		values = append(values, r.readBytes())
+	}
+
+	return values
+}
+
+func (r *canonicalReader) readBigInt() *big.Int {
+	if !r.isPresent() {
+		return nil
+	}
+
+	isNegative := r.readBool()
+	absBytes := r.readBytes()
+	isCanonical := len(absBytes) == 0 && !isNegative || len(absBytes) > 0 && absBytes[0] != 0
+	if !isCanonical {
+		r.fail("big int")
+		return nil
+	}
+
+	value := big.NewInt(0).SetBytes(absBytes)
+	if isNegative {
+		value.Neg(value)
+	}
+
+	return value
+}
+
+func (r *canonicalReader) readOutputAccount() *OutputAccount {
+	if !r.isPresent() {
+		return nil
+	}
+
+	account := &OutputAccount{}
+	account.Address = r.readBytes()
+	account.Nonce = r.readUint64()
+	account.Balance = r.readBigInt()
+
+	numUpdates := r.readLength()
+	if numUpdates > 0 {
+		account.StorageUpdates = make(map[string]*StorageUpdate, numUpdates)
+	}
+	for i := 0; i < numUpdates && r.err == nil; i++ {
+		key := string(r.readBytes())
+		account.StorageUpdates[key] = r.readStorageUpdate()
+	}
+
+	account.Code = r.readBytes()
+	account.CodeMetadata = r.readBytes()
+	account.CodeDeployerAddress = r.readBytes()
+	account.BalanceDelta = r.readBigInt()
+
+	numTransfers := r.readLength()
+	for i := 0; i < numTransfers && r.err == nil; i++ {
+		account.OutputTransfers = append(account.OutputTransfers, r.readOutputTransfer())
+	}
+
+	account.GasUsed = r.readUint64()
+	account.BytesAddedToStorage = r.readUint64()
+	account.BytesDeletedFromStorage = r.readUint64()
+	account.BytesConsumedByTxAsNetworking = r.readUint64()
+
+	return account
+}
+
+func (r *canonicalReader) readStorageUpdate() *StorageUpdate {
+	if !r.isPresent() {
+		return nil
+	}
+
+	return &StorageUpdate{
+		Offset:  r.readBytes(),
+		Data:    r.readBytes(),
+		Written: r.readBool(),
+	}
+}
+
+func (r *canonicalReader) readOutputTransfer() OutputTransfer {
+	return OutputTransfer{
+		Index:         uint32(r.readUint64()),
+		Value:         r.readBigInt(),
+		GasLimit:      r.readUint64(),
+		GasLocked:     r.readUint64(),
+		AsyncData:     r.readBytes(),
+		Data:          r.readBytes(),
+		CallType:      vm.CallType(r.readUint64()),
+		SenderAddress: r.readBytes(),
+	}
+}
+
+func (r *canonicalReader) readLogEntry() *LogEntry {
+	if !r.isPresent() {
+		return nil
+	}
+
+	return &LogEntry{
+		Identifier: r.readBytes(),
+		Address:    r.readBytes(),
+		Topics:     r.readBytesList(),
+		Data:       r.readBytesList(),
+	}
+}
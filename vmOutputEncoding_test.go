@@ -0,0 +1,213 @@
+package vmcommon
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	"github.com/subrahamanyam341/andes-core-16/data/vm"
+	"github.com/subrahamanyam341/andes-core-16/hashing/sha256"
+)
+
+func createVMOutputForEncoding() *VMOutput {
+	return &VMOutput{
+		ReturnData:    [][]byte{[]byte("first"), []byte("second")},
+		ReturnCode:    UserError,
+		ReturnMessage: "message",
+		GasRemaining:  100,
+		GasRefund:     big.NewInt(-5),
+		OutputAccounts: map[string]*OutputAccount{
+			"address1": {
+				Address: []byte("address1"),
+				Nonce:   3,
+				Balance: big.NewInt(1000),
+				StorageUpdates: map[string]*StorageUpdate{
+					"key1": {Offset: []byte("key1"), Data: []byte("value1"), Written: true},
+					"key2": {Offset: []byte("key2"), Data: []byte("value2")},
+				},
+				Code:                []byte("code"),
+				CodeMetadata:        []byte{1, 2},
+				CodeDeployerAddress: []byte("deployer"),
+				BalanceDelta:        big.NewInt(-10),
+				OutputTransfers: []OutputTransfer{
+					{
+						Index:         1,
+						Value:         big.NewInt(7),
+						GasLimit:      8,
+						GasLocked:     9,
+						AsyncData:     []byte("async"),
+						Data:          []byte("data"),
+						CallType:      vm.AsynchronousCall,
+						SenderAddress: []byte("sender"),
+					},
+				},
+				GasUsed:                       11,
+				BytesAddedToStorage:           12,
+				BytesDeletedFromStorage:       13,
+				BytesConsumedByTxAsNetworking: 14,
+			},
+			"address2": {
+				Address:      []byte("address2"),
+				BalanceDelta: big.NewInt(10),
+			},
+		},
+		DeletedAccounts: [][]byte{[]byte("deleted")},
+		TouchedAccounts: [][]byte{[]byte("touched1"), []byte("touched2")},
+		Logs: []*LogEntry{
+			{
+				Identifier: []byte("identifier"),
+				Address:    []byte("address1"),
+				Topics:     [][]byte{[]byte("topic1"), []byte("topic2")},
+				Data:       [][]byte{[]byte("data")},
+			},
+		},
+	}
+}
+
+func TestVMOutput_EncodeCanonical(t *testing.T) {
+	t.Parallel()
+
+	t.Run("encoding should not depend on the map iteration order", func(t *testing.T) {
+		t.Parallel()
+
+		expected := createVMOutputForEncoding().EncodeCanonical()
+		for i := 0; i < 20; i++ {
+			assert.Equal(t, expected, createVMOutputForEncoding().EncodeCanonical())
+		}
+	})
+	t.Run("nil and empty collections should be encoded the same", func(t *testing.T) {
+		t.Parallel()
+
+		empty := &VMOutput{
+			ReturnData:      make([][]byte, 0),
+			OutputAccounts:  make(map[string]*OutputAccount),
+			DeletedAccounts: make([][]byte, 0),
+			TouchedAccounts: make([][]byte, 0),
+			Logs:            make([]*LogEntry, 0),
+		}
+		assert.Equal(t, (&VMOutput{}).EncodeCanonical(), empty.EncodeCanonical())
+	})
+	t.Run("storage trace should not change the encoding", func(t *testing.T) {
+		t.Parallel()
+
+		traced := createVMOutputForEncoding()
+		traced.StorageTrace = &StorageTrace{
+			Function: "function",
+			Accesses: []*StorageAccess{{Kind: SaveKeyValueAccess, Address: []byte("address1"), Key: []byte("key1"), Value: []byte("value")}},
+		}
+		assert.Equal(t, createVMOutputForEncoding().EncodeCanonical(), traced.EncodeCanonical())
+	})
+	t.Run("any changed field should change the encoding", func(t *testing.T) {
+		t.Parallel()
+
+		expected := createVMOutputForEncoding().EncodeCanonical()
+		changes := map[string]func(vmOutput *VMOutput){
+			"return data order": func(vmOutput *VMOutput) {
+				vmOutput.ReturnData[0], vmOutput.ReturnData[1] = vmOutput.ReturnData[1], vmOutput.ReturnData[0]
+			},
+			"nil gas refund": func(vmOutput *VMOutput) {
+				vmOutput.GasRefund = nil
+			},
+			"storage written flag": func(vmOutput *VMOutput) {
+				vmOutput.OutputAccounts["address1"].StorageUpdates["key2"].Written = true
+			},
+			"transfer call type": func(vmOutput *VMOutput) {
+				vmOutput.OutputAccounts["address1"].OutputTransfers[0].CallType = vm.DirectCall
+			},
+			"balance delta sign": func(vmOutput *VMOutput) {
+				vmOutput.OutputAccounts["address2"].BalanceDelta = big.NewInt(-10)
+			},
+			"log topic": func(vmOutput *VMOutput) {
+				vmOutput.Logs[0].Topics[1] = []byte("other")
+			},
+		}
+
+		for name, change := range changes {
+			vmOutput := createVMOutputForEncoding()
+			change(vmOutput)
+			assert.NotEqual(t, expected, vmOutput.EncodeCanonical(), name)
+		}
+	})
+}
+
+func TestVMOutput_Hash(t *testing.T) {
+	t.Parallel()
+
+	_, err := createVMOutputForEncoding().Hash(nil)
+	assert.Equal(t, ErrNilHasher, err)
+
+	hasher := sha256.NewSha256()
+	hash, err := createVMOutputForEncoding().Hash(hasher)
+	assert.Nil(t, err)
+	assert.Equal(t, hasher.Compute(string(createVMOutputForEncoding().EncodeCanonical())), hash)
+
+	// the encoding must not change between releases, a new format needs a new version
+	assert.Equal(t, "e66b20b9472524f0f35be405eb85c3e1cafaa473751ae07b5580c2e63e42b205", hex.EncodeToString(hash))
+}
+
+func TestDecodeVMOutput(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should round trip", func(t *testing.T) {
+		t.Parallel()
+
+		vmOutput := createVMOutputForEncoding()
+		decoded, err := DecodeVMOutput(vmOutput.EncodeCanonical())
+		require.Nil(t, err)
+		assert.Equal(t, vmOutput, decoded)
+	})
+	t.Run("nil values should round trip", func(t *testing.T) {
+		t.Parallel()
+
+		vmOutput := &VMOutput{
+			OutputAccounts: map[string]*OutputAccount{"address": nil},
+			Logs:           []*LogEntry{nil},
+		}
+		decoded, err := DecodeVMOutput(vmOutput.EncodeCanonical())
+		require.Nil(t, err)
+		assert.Equal(t, vmOutput, decoded)
+	})
+	t.Run("invalid encodings should error", func(t *testing.T) {
+		t.Parallel()
+
+		encoded := createVMOutputForEncoding().EncodeCanonical()
+		invalidEncodings := map[string][]byte{
+			"empty":           nil,
+			"unknown version": append([]byte{vmOutputEncodingVersion + 1}, encoded[1:]...),
+			"truncated":       encoded[:len(encoded)-1],
+			"trailing bytes":  append(append([]byte{}, encoded...), 0),
+			"huge length":     {vmOutputEncodingVersion, 0xff, 0xff, 0x03},
+			"negative zero":   {vmOutputEncodingVersion, 0, 0, 0, 0, 1, 1, 0},
+		}
+
+		for name, invalid := range invalidEncodings {
+			decoded, err := DecodeVMOutput(invalid)
+			assert.Nil(t, decoded, name)
+			assert.True(t, errors.Is(err, ErrInvalidVMOutputEncoding), name)
+		}
+	})
+}
+
+func TestVMOutputMarshalizer(t *testing.T) {
+	t.Parallel()
+
+	marshalizer := NewVMOutputMarshalizer()
+	assert.False(t, check.IfNil(marshalizer))
+
+	_, err := marshalizer.Marshal(&OutputAccount{})
+	assert.Equal(t, ErrWrongTypeAssertion, err)
+	assert.Equal(t, ErrWrongTypeAssertion, marshalizer.Unmarshal(&OutputAccount{}, nil))
+
+	vmOutput := createVMOutputForEncoding()
+	buff, err := marshalizer.Marshal(vmOutput)
+	require.Nil(t, err)
+
+	decoded := &VMOutput{}
+	err = marshalizer.Unmarshal(decoded, buff)
+	require.Nil(t, err)
+	assert.Equal(t, vmOutput, decoded)
+}